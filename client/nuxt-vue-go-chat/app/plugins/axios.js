@@ -1,4 +1,8 @@
 export default ({ app, $axios, redirect }) => {
+  // send back the CSRF token which the server sets to the cookie
+  $axios.defaults.xsrfCookieName = 'CSRF_TOKEN'
+  $axios.defaults.xsrfHeaderName = 'X-CSRF-Token'
+
   $axios.onError(error => {
     if (error.response.status === 401) {
       console.error(`failed to authenticate: ${JSON.stringify(error)}`)
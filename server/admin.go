@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/application"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/config"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/eventbus"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+)
+
+// adminUsage is the usage of admin subcommand.
+const adminUsage = "usage: admin grant <name>"
+
+// runAdmin runs admin subcommand with args, i.e. grant <name>.
+// It makes the first admin, who can change the roles of the others by the API.
+func runAdmin(c config.DB, args []string, out io.Writer) error {
+	if len(args) != 2 || args[0] != "grant" {
+		return errors.New(adminUsage)
+	}
+
+	dbm, err := db.NewDBManager(c)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := dbm.Close(); err != nil {
+			logger.Logger.Error("failed to close db", zap.String("error message", err.Error()))
+		}
+	}()
+
+	// no one subscribes the events, because the server is not running in this process.
+	aApp := application.NewAdminService(dbm, db.NewUserRepository(), eventbus.NewMemoryBus(), db.CloseTransaction)
+
+	user, err := aApp.GrantAdmin(context.Background(), args[1])
+	if err != nil {
+		return err
+	}
+
+	return writeLines(out, []string{fmt.Sprintf("granted admin to %s (id: %d)", user.Name, user.ID)})
+}
@@ -0,0 +1,200 @@
+package application
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/event"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+)
+
+// AdminService is interface of AdminService.
+type AdminService interface {
+	ListUsers(ctx context.Context, limit int, cursor uint32) (*model.UserList, error)
+	ChangeRole(ctx context.Context, id uint32, role model.Role) (*model.User, error)
+	BanUser(ctx context.Context, id uint32) (*model.User, error)
+	UnbanUser(ctx context.Context, id uint32) (*model.User, error)
+	GrantAdmin(ctx context.Context, name string) (*model.User, error)
+}
+
+// adminService is application service of administration.
+type adminService struct {
+	m        query.DBManager
+	repo     repository.UserRepository
+	events   event.Publisher
+	txCloser CloseTransaction
+}
+
+// NewAdminService generates and returns AdminService.
+func NewAdminService(m query.DBManager, repo repository.UserRepository, events event.Publisher, txCloser CloseTransaction) AdminService {
+	return &adminService{
+		m:        m,
+		repo:     repo,
+		events:   events,
+		txCloser: txCloser,
+	}
+}
+
+// ListUsers gets UserList.
+func (a *adminService) ListUsers(ctx context.Context, limit int, cursor uint32) (*model.UserList, error) {
+	users, err := a.repo.ListUsers(ctx, a.m, cursor, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list users")
+	}
+
+	return users, nil
+}
+
+// ChangeRole changes the role of the User.
+func (a *adminService) ChangeRole(ctx context.Context, id uint32, role model.Role) (user *model.User, err error) {
+	if !role.IsValid() {
+		err = &model.InvalidParamError{
+			PropertyName:  model.RoleProperty,
+			PropertyValue: role,
+			InvalidReason: "role is not defined",
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	if err := a.checkNotSelf(ctx, id); err != nil {
+		return nil, err
+	}
+
+	tx, err := a.m.Begin()
+	if err != nil {
+		return nil, beginTxErrorMsg(err)
+	}
+
+	defer func() {
+		if closeErr := a.txCloser(tx, err); closeErr != nil {
+			user = nil
+			err = errors.Wrap(closeErr, "failed to close tx")
+		}
+	}()
+
+	user, err = a.repo.GetUserByID(ctx, tx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user by id")
+	}
+
+	if user.Role == role {
+		return user, nil
+	}
+
+	if err := a.repo.UpdateUserRole(ctx, tx, id, role); err != nil {
+		return nil, errors.Wrap(err, "failed to update role of user")
+	}
+	user.Role = role
+
+	return user, nil
+}
+
+// GrantAdmin makes the User of the name an admin.
+// It does not check the requester, so that the first admin can be made by the admin subcommand.
+// It must not be exposed by the API.
+func (a *adminService) GrantAdmin(ctx context.Context, name string) (user *model.User, err error) {
+	tx, err := a.m.Begin()
+	if err != nil {
+		return nil, beginTxErrorMsg(err)
+	}
+
+	defer func() {
+		if closeErr := a.txCloser(tx, err); closeErr != nil {
+			user = nil
+			err = errors.Wrap(closeErr, "failed to close tx")
+		}
+	}()
+
+	user, err = a.repo.GetUserByName(ctx, tx, model.NormalizeUserName(name))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user by name")
+	}
+
+	if user.Role == model.RoleAdmin {
+		return user, nil
+	}
+
+	if err := a.repo.UpdateUserRole(ctx, tx, user.ID, model.RoleAdmin); err != nil {
+		return nil, errors.Wrap(err, "failed to update role of user")
+	}
+	user.Role = model.RoleAdmin
+
+	return user, nil
+}
+
+// BanUser bans the User.
+func (a *adminService) BanUser(ctx context.Context, id uint32) (*model.User, error) {
+	return a.changeBanned(ctx, id, true)
+}
+
+// UnbanUser unbans the User.
+func (a *adminService) UnbanUser(ctx context.Context, id uint32) (*model.User, error) {
+	return a.changeBanned(ctx, id, false)
+}
+
+// changeBanned changes whether the User is banned or not.
+func (a *adminService) changeBanned(ctx context.Context, id uint32, banned bool) (user *model.User, err error) {
+	if err := a.checkNotSelf(ctx, id); err != nil {
+		return nil, err
+	}
+
+	tx, err := a.m.Begin()
+	if err != nil {
+		return nil, beginTxErrorMsg(err)
+	}
+
+	changed := false
+
+	// publish events after tx has been committed, so that the connections of the banned user are closed.
+	defer func() {
+		if err == nil && changed && banned {
+			publishEvents(ctx, a.events, &event.UserBanned{UserID: id})
+		}
+	}()
+
+	defer func() {
+		if closeErr := a.txCloser(tx, err); closeErr != nil {
+			user = nil
+			err = errors.Wrap(closeErr, "failed to close tx")
+		}
+	}()
+
+	user, err = a.repo.GetUserByID(ctx, tx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user by id")
+	}
+
+	if user.Banned == banned {
+		return user, nil
+	}
+
+	if err := a.repo.UpdateUserBanned(ctx, tx, id, banned); err != nil {
+		return nil, errors.Wrap(err, "failed to update banned of user")
+	}
+	user.Banned = banned
+	changed = true
+
+	return user, nil
+}
+
+// checkNotSelf returns InvalidParamError if the requester tries to change themselves,
+// so that admins can not lock themselves out.
+func (a *adminService) checkNotSelf(ctx context.Context, id uint32) error {
+	requester, err := userFromContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user")
+	}
+
+	if requester.ID == id {
+		err := &model.InvalidParamError{
+			PropertyName:  model.IDProperty,
+			PropertyValue: id,
+			InvalidReason: "can not change own account",
+		}
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,493 @@
+package application
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/event"
+	mock_event "github.com/sekky0905/nuxt-vue-go-chat/server/domain/event/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
+	mock_repository "github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	mock_query "github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/testutil"
+)
+
+func Test_adminService_ListUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testutil.SetFakeTime(time.Now())
+
+	type fields struct {
+		m        query.DBManager
+		repo     repository.UserRepository
+		txCloser CloseTransaction
+	}
+	type args struct {
+		ctx    context.Context
+		limit  int
+		cursor uint32
+	}
+
+	type mockReturns struct {
+		list *model.UserList
+		err  error
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		mockReturns
+		want    *model.UserList
+		wantErr bool
+	}{
+		{
+			name: "When appropriate args given, ListUsers returns UserList and nil",
+			fields: fields{
+				m:    mock_query.NewMockDBManager(ctrl),
+				repo: mock_repository.NewMockUserRepository(ctrl),
+			},
+			args: args{
+				ctx:    context.Background(),
+				limit:  20,
+				cursor: 1,
+			},
+			mockReturns: mockReturns{
+				list: &model.UserList{
+					Users:   testutil.GenerateUserHelper(1, 20),
+					HasNext: true,
+					Cursor:  21,
+				},
+			},
+			want: &model.UserList{
+				Users:   testutil.GenerateUserHelper(1, 20),
+				HasNext: true,
+				Cursor:  21,
+			},
+			wantErr: false,
+		},
+		{
+			name: "When some error occurs at repository layer, ListUsers returns error",
+			fields: fields{
+				m:    mock_query.NewMockDBManager(ctrl),
+				repo: mock_repository.NewMockUserRepository(ctrl),
+			},
+			args: args{
+				ctx:    context.Background(),
+				limit:  20,
+				cursor: 1,
+			},
+			mockReturns: mockReturns{
+				err: errors.New(model.ErrorMessageForTest),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ur, ok := tt.fields.repo.(*mock_repository.MockUserRepository)
+			if !ok {
+				t.Fatal("failed to assert MockUserRepository")
+			}
+			ur.EXPECT().ListUsers(tt.args.ctx, tt.fields.m, tt.args.cursor, tt.args.limit).Return(tt.mockReturns.list, tt.mockReturns.err)
+
+			a := &adminService{
+				m:        tt.fields.m,
+				repo:     tt.fields.repo,
+				txCloser: tt.fields.txCloser,
+			}
+			got, err := a.ListUsers(tt.args.ctx, tt.args.limit, tt.args.cursor)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("adminService.ListUsers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("adminService.ListUsers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_adminService_ChangeRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	admin := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+		Role: model.RoleAdmin,
+	}
+	ctx := model.WithUser(context.Background(), admin)
+
+	type args struct {
+		ctx  context.Context
+		id   uint32
+		role model.Role
+	}
+
+	type mockReturnsGetUserByID struct {
+		user *model.User
+		err  error
+	}
+
+	type mockReturnsUpdateUserRole struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		mockReturnsGetUserByID
+		mockReturnsUpdateUserRole
+		expectUpdate bool
+		want         *model.User
+		wantErr      bool
+	}{
+		{
+			name: "When the role of other user is changed, ChangeRole returns the user and nil",
+			args: args{
+				ctx:  ctx,
+				id:   model.UserInValidIDForTest,
+				role: model.RoleModerator,
+			},
+			mockReturnsGetUserByID: mockReturnsGetUserByID{
+				user: &model.User{ID: model.UserInValidIDForTest, Role: model.RoleUser},
+			},
+			expectUpdate: true,
+			want:         &model.User{ID: model.UserInValidIDForTest, Role: model.RoleModerator},
+			wantErr:      false,
+		},
+		{
+			name: "When the role is not changed, ChangeRole returns the user without updating",
+			args: args{
+				ctx:  ctx,
+				id:   model.UserInValidIDForTest,
+				role: model.RoleModerator,
+			},
+			mockReturnsGetUserByID: mockReturnsGetUserByID{
+				user: &model.User{ID: model.UserInValidIDForTest, Role: model.RoleModerator},
+			},
+			expectUpdate: false,
+			want:         &model.User{ID: model.UserInValidIDForTest, Role: model.RoleModerator},
+			wantErr:      false,
+		},
+		{
+			name: "When undefined role is given, ChangeRole returns error",
+			args: args{
+				ctx:  ctx,
+				id:   model.UserInValidIDForTest,
+				role: model.Role("root"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "When the admin changes own role, ChangeRole returns error",
+			args: args{
+				ctx:  ctx,
+				id:   model.UserValidIDForTest,
+				role: model.RoleUser,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "When given id has not existed, ChangeRole returns error",
+			args: args{
+				ctx:  ctx,
+				id:   model.UserInValidIDForTest,
+				role: model.RoleModerator,
+			},
+			mockReturnsGetUserByID: mockReturnsGetUserByID{
+				err: &model.NoSuchDataError{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "When some error occurs at repository layer, ChangeRole returns error",
+			args: args{
+				ctx:  ctx,
+				id:   model.UserInValidIDForTest,
+				role: model.RoleModerator,
+			},
+			mockReturnsGetUserByID: mockReturnsGetUserByID{
+				user: &model.User{ID: model.UserInValidIDForTest, Role: model.RoleUser},
+			},
+			mockReturnsUpdateUserRole: mockReturnsUpdateUserRole{
+				err: errors.New(model.ErrorMessageForTest),
+			},
+			expectUpdate: true,
+			want:         nil,
+			wantErr:      true,
+		},
+		{
+			name: "When the user who requested is not in context, ChangeRole returns error",
+			args: args{
+				ctx:  context.Background(),
+				id:   model.UserInValidIDForTest,
+				role: model.RoleModerator,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mock_query.NewMockDBManager(ctrl)
+			ur := mock_repository.NewMockUserRepository(ctrl)
+
+			if tt.mockReturnsGetUserByID.user != nil || tt.mockReturnsGetUserByID.err != nil {
+				m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
+				ur.EXPECT().GetUserByID(tt.args.ctx, gomock.Any(), tt.args.id).Return(tt.mockReturnsGetUserByID.user, tt.mockReturnsGetUserByID.err)
+			}
+
+			if tt.expectUpdate {
+				ur.EXPECT().UpdateUserRole(tt.args.ctx, gomock.Any(), tt.args.id, tt.args.role).Return(tt.mockReturnsUpdateUserRole.err)
+			}
+
+			a := &adminService{
+				m:    m,
+				repo: ur,
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+			}
+			got, err := a.ChangeRole(tt.args.ctx, tt.args.id, tt.args.role)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("adminService.ChangeRole() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("adminService.ChangeRole() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_adminService_BanUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	admin := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+		Role: model.RoleAdmin,
+	}
+	ctx := model.WithUser(context.Background(), admin)
+
+	type args struct {
+		ctx context.Context
+		id  uint32
+	}
+
+	type mockReturnsGetUserByID struct {
+		user *model.User
+		err  error
+	}
+
+	type mockReturnsUpdateUserBanned struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		mockReturnsGetUserByID
+		mockReturnsUpdateUserBanned
+		expectUpdate bool
+		wantEvent    bool
+		want         *model.User
+		wantErr      bool
+	}{
+		{
+			name: "When other user is banned, BanUser returns the banned user and nil",
+			args: args{
+				ctx: ctx,
+				id:  model.UserInValidIDForTest,
+			},
+			mockReturnsGetUserByID: mockReturnsGetUserByID{
+				user: &model.User{ID: model.UserInValidIDForTest},
+			},
+			expectUpdate: true,
+			wantEvent:    true,
+			want:         &model.User{ID: model.UserInValidIDForTest, Banned: true},
+			wantErr:      false,
+		},
+		{
+			name: "When the user has already been banned, BanUser returns the user without updating",
+			args: args{
+				ctx: ctx,
+				id:  model.UserInValidIDForTest,
+			},
+			mockReturnsGetUserByID: mockReturnsGetUserByID{
+				user: &model.User{ID: model.UserInValidIDForTest, Banned: true},
+			},
+			expectUpdate: false,
+			want:         &model.User{ID: model.UserInValidIDForTest, Banned: true},
+			wantErr:      false,
+		},
+		{
+			name: "When the admin bans themselves, BanUser returns error",
+			args: args{
+				ctx: ctx,
+				id:  model.UserValidIDForTest,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "When some error occurs at repository layer, BanUser returns error",
+			args: args{
+				ctx: ctx,
+				id:  model.UserInValidIDForTest,
+			},
+			mockReturnsGetUserByID: mockReturnsGetUserByID{
+				user: &model.User{ID: model.UserInValidIDForTest},
+			},
+			mockReturnsUpdateUserBanned: mockReturnsUpdateUserBanned{
+				err: errors.New(model.ErrorMessageForTest),
+			},
+			expectUpdate: true,
+			want:         nil,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mock_query.NewMockDBManager(ctrl)
+			ur := mock_repository.NewMockUserRepository(ctrl)
+
+			if tt.mockReturnsGetUserByID.user != nil || tt.mockReturnsGetUserByID.err != nil {
+				m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
+				ur.EXPECT().GetUserByID(tt.args.ctx, gomock.Any(), tt.args.id).Return(tt.mockReturnsGetUserByID.user, tt.mockReturnsGetUserByID.err)
+			}
+
+			if tt.expectUpdate {
+				ur.EXPECT().UpdateUserBanned(tt.args.ctx, gomock.Any(), tt.args.id, true).Return(tt.mockReturnsUpdateUserBanned.err)
+			}
+
+			p := mock_event.NewMockPublisher(ctrl)
+			if tt.wantEvent {
+				p.EXPECT().Publish(gomock.Any(), &event.UserBanned{UserID: tt.args.id})
+			}
+
+			a := &adminService{
+				m:      m,
+				repo:   ur,
+				events: p,
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+			}
+			got, err := a.BanUser(tt.args.ctx, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("adminService.BanUser() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("adminService.BanUser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_adminService_GrantAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	type mockReturnsGetUserByName struct {
+		user *model.User
+		err  error
+	}
+
+	tests := []struct {
+		name     string
+		userName string
+		mockReturnsGetUserByName
+		expectUpdate bool
+		updateErr    error
+		want         *model.User
+		wantErr      bool
+	}{
+		{
+			name:     "When the user is not an admin, GrantAdmin makes the user an admin without the requester",
+			userName: model.UserNameForTest,
+			mockReturnsGetUserByName: mockReturnsGetUserByName{
+				user: &model.User{ID: model.UserValidIDForTest, Name: model.UserNameForTest, Role: model.RoleUser},
+			},
+			expectUpdate: true,
+			want:         &model.User{ID: model.UserValidIDForTest, Name: model.UserNameForTest, Role: model.RoleAdmin},
+			wantErr:      false,
+		},
+		{
+			name:     "When the user is already an admin, GrantAdmin returns the user without updating",
+			userName: model.UserNameForTest,
+			mockReturnsGetUserByName: mockReturnsGetUserByName{
+				user: &model.User{ID: model.UserValidIDForTest, Name: model.UserNameForTest, Role: model.RoleAdmin},
+			},
+			expectUpdate: false,
+			want:         &model.User{ID: model.UserValidIDForTest, Name: model.UserNameForTest, Role: model.RoleAdmin},
+			wantErr:      false,
+		},
+		{
+			name:     "When the user of given name has not existed, GrantAdmin returns error",
+			userName: model.UserNameForTest,
+			mockReturnsGetUserByName: mockReturnsGetUserByName{
+				err: &model.NoSuchDataError{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:     "When some error occurs at repository layer, GrantAdmin returns error",
+			userName: model.UserNameForTest,
+			mockReturnsGetUserByName: mockReturnsGetUserByName{
+				user: &model.User{ID: model.UserValidIDForTest, Name: model.UserNameForTest, Role: model.RoleUser},
+			},
+			expectUpdate: true,
+			updateErr:    errors.New(model.ErrorMessageForTest),
+			want:         nil,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mock_query.NewMockDBManager(ctrl)
+			ur := mock_repository.NewMockUserRepository(ctrl)
+
+			m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
+			ur.EXPECT().GetUserByName(ctx, gomock.Any(), tt.userName).Return(tt.mockReturnsGetUserByName.user, tt.mockReturnsGetUserByName.err)
+
+			if tt.expectUpdate {
+				ur.EXPECT().UpdateUserRole(ctx, gomock.Any(), model.UserValidIDForTest, model.RoleAdmin).Return(tt.updateErr)
+			}
+
+			a := &adminService{
+				m:    m,
+				repo: ur,
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+			}
+			got, err := a.GrantAdmin(ctx, tt.userName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("adminService.GrantAdmin() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("adminService.GrantAdmin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/util"
+)
+
+// APITokenService is interface of APITokenService.
+type APITokenService interface {
+	CreateAPIToken(ctx context.Context, name string) (*model.APIToken, string, error)
+	ListAPITokens(ctx context.Context) ([]*model.APIToken, error)
+	RevokeAPIToken(ctx context.Context, id uint32) error
+}
+
+// apiTokenService is application service of the API tokens of the user who requested.
+type apiTokenService struct {
+	m    query.DBManager
+	repo repository.APITokenRepository
+	now  func() time.Time
+}
+
+// NewAPITokenService generates and returns APITokenService.
+func NewAPITokenService(m query.DBManager, repo repository.APITokenRepository) APITokenService {
+	return &apiTokenService{
+		m:    m,
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+// CreateAPIToken mints the API token of the user who requested, and returns it with the token itself.
+// The token itself is not stored, so it can not be shown again.
+func (a *apiTokenService) CreateAPIToken(ctx context.Context, name string) (*model.APIToken, string, error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to get user")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > model.APITokenNameMaxLength {
+		err = &model.InvalidParamError{
+			PropertyName:  model.NameProperty,
+			PropertyValue: name,
+			InvalidReason: "name must be 1 to 64 characters",
+		}
+		return nil, "", errors.WithStack(err)
+	}
+
+	random, err := util.RandomToken()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to generate token")
+	}
+	token := model.APITokenPrefix + random
+
+	now := a.now()
+	apiToken := &model.APIToken{
+		UserID:     user.ID,
+		Name:       name,
+		TokenHash:  util.HashToken(token),
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
+
+	id, err := a.repo.InsertAPIToken(ctx, a.m, apiToken)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to insert api token")
+	}
+	apiToken.ID = id
+
+	return apiToken, token, nil
+}
+
+// ListAPITokens lists the API tokens of the user who requested.
+func (a *apiTokenService) ListAPITokens(ctx context.Context) ([]*model.APIToken, error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+
+	tokens, err := a.repo.ListAPITokensByUserID(ctx, a.m, user.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list api tokens")
+	}
+
+	return tokens, nil
+}
+
+// RevokeAPIToken revokes the API token of the user who requested specified by id.
+func (a *apiTokenService) RevokeAPIToken(ctx context.Context, id uint32) error {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user")
+	}
+
+	if err := a.repo.DeleteAPIToken(ctx, a.m, user.ID, id); err != nil {
+		return errors.Wrap(err, "failed to delete api token")
+	}
+
+	return nil
+}
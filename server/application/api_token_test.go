@@ -0,0 +1,165 @@
+package application
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	mock_repository "github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	mock_query "github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/testutil"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/util"
+)
+
+func Test_apiTokenService_CreateAPIToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testutil.SetFakeTime(time.Now())
+
+	user := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+	}
+	ctx := model.WithUser(context.Background(), user)
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		tokenName    string
+		expectInsert bool
+		insertErr    error
+		wantName     string
+		wantErr      error
+	}{
+		{
+			name:         "When appropriate name is given, CreateAPIToken returns the token and nil",
+			ctx:          ctx,
+			tokenName:    " cli ",
+			expectInsert: true,
+			wantName:     "cli",
+		},
+		{
+			name:      "When empty name is given, CreateAPIToken returns InvalidParamError",
+			ctx:       ctx,
+			tokenName: " ",
+			wantErr:   &model.InvalidParamError{},
+		},
+		{
+			name:      "When too long name is given, CreateAPIToken returns InvalidParamError",
+			ctx:       ctx,
+			tokenName: strings.Repeat("a", model.APITokenNameMaxLength+1),
+			wantErr:   &model.InvalidParamError{},
+		},
+		{
+			name:         "When some error occurs at repository layer, CreateAPIToken returns error",
+			ctx:          ctx,
+			tokenName:    "cli",
+			expectInsert: true,
+			insertErr:    &model.RepositoryError{},
+			wantErr:      &model.RepositoryError{},
+		},
+		{
+			name:      "When the user who requested is not in context, CreateAPIToken returns AuthenticationErr",
+			ctx:       context.Background(),
+			tokenName: "cli",
+			wantErr:   &model.AuthenticationErr{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mock_query.NewMockDBManager(ctrl)
+			repo := mock_repository.NewMockAPITokenRepository(ctrl)
+
+			var inserted *model.APIToken
+			if tt.expectInsert {
+				repo.EXPECT().InsertAPIToken(tt.ctx, m, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, m query.SQLManager, token *model.APIToken) (uint32, error) {
+						inserted = token
+						return 1, tt.insertErr
+					})
+			}
+
+			a := &apiTokenService{
+				m:    m,
+				repo: repo,
+				now:  testutil.TimeNow,
+			}
+			got, token, err := a.CreateAPIToken(tt.ctx, tt.tokenName)
+			if tt.wantErr != nil {
+				if reflect.TypeOf(errors.Cause(err)) != reflect.TypeOf(tt.wantErr) {
+					t.Errorf("apiTokenService.CreateAPIToken() error = %#v, wantErr %#v", errors.Cause(err), tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("apiTokenService.CreateAPIToken() error = %v", err)
+			}
+
+			if !strings.HasPrefix(token, model.APITokenPrefix) {
+				t.Errorf("token = %s, want prefix %s", token, model.APITokenPrefix)
+			}
+
+			want := &model.APIToken{
+				ID:         1,
+				UserID:     user.ID,
+				Name:       tt.wantName,
+				TokenHash:  util.HashToken(token),
+				CreatedAt:  testutil.TimeNow(),
+				LastUsedAt: testutil.TimeNow(),
+			}
+			if !reflect.DeepEqual(got, want) || inserted != got {
+				t.Errorf("apiTokenService.CreateAPIToken() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func Test_apiTokenService_RevokeAPIToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+	}
+	ctx := model.WithUser(context.Background(), user)
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{
+			name:    "When the token of the user is given, RevokeAPIToken returns nil",
+			wantErr: false,
+		},
+		{
+			name:    "When the token of the user is not found, RevokeAPIToken returns error",
+			err:     &model.NoSuchDataError{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mock_query.NewMockDBManager(ctrl)
+			repo := mock_repository.NewMockAPITokenRepository(ctrl)
+			repo.EXPECT().DeleteAPIToken(ctx, m, user.ID, uint32(1)).Return(tt.err)
+
+			a := &apiTokenService{
+				m:    m,
+				repo: repo,
+				now:  testutil.TimeNow,
+			}
+			if err := a.RevokeAPIToken(ctx, 1); (err != nil) != tt.wantErr {
+				t.Errorf("apiTokenService.RevokeAPIToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
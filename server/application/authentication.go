@@ -4,10 +4,13 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/event"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/service"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
 )
 
 // AuthenticationService is the interface of AuthenticationService.
@@ -24,16 +27,18 @@ type AuthenticationServiceDIInput struct {
 	userService           service.UserService
 	sessionService        service.SessionService
 	authenticationService service.AuthenticationService
+	loginAttemptService   service.LoginAttemptService
 }
 
 // NewAuthenticationServiceDIInput generates and returns AuthenticationServiceDIInput.
-func NewAuthenticationServiceDIInput(uRepo repository.UserRepository, sRepo repository.SessionRepository, uService service.UserService, sService service.SessionService, aService service.AuthenticationService) *AuthenticationServiceDIInput {
+func NewAuthenticationServiceDIInput(uRepo repository.UserRepository, sRepo repository.SessionRepository, uService service.UserService, sService service.SessionService, aService service.AuthenticationService, laService service.LoginAttemptService) *AuthenticationServiceDIInput {
 	return &AuthenticationServiceDIInput{
 		userRepository:        uRepo,
 		sessionRepository:     sRepo,
 		userService:           uService,
 		sessionService:        sService,
 		authenticationService: aService,
+		loginAttemptService:   laService,
 	}
 }
 
@@ -45,11 +50,13 @@ type authenticationService struct {
 	userService           service.UserService
 	sessionService        service.SessionService
 	authenticationService service.AuthenticationService
+	loginAttemptService   service.LoginAttemptService
+	events                event.Publisher
 	txCloser              CloseTransaction
 }
 
 // NewAuthenticationService generates and returns AuthenticationService.
-func NewAuthenticationService(m query.DBManager, diInput *AuthenticationServiceDIInput, txCloser CloseTransaction) AuthenticationService {
+func NewAuthenticationService(m query.DBManager, diInput *AuthenticationServiceDIInput, events event.Publisher, txCloser CloseTransaction) AuthenticationService {
 	return &authenticationService{
 		m:                     m,
 		userRepository:        diInput.userRepository,
@@ -57,6 +64,8 @@ func NewAuthenticationService(m query.DBManager, diInput *AuthenticationServiceD
 		userService:           diInput.userService,
 		sessionService:        diInput.sessionService,
 		authenticationService: diInput.authenticationService,
+		loginAttemptService:   diInput.loginAttemptService,
+		events:                events,
 		txCloser:              txCloser,
 	}
 }
@@ -90,6 +99,7 @@ func (s *authenticationService) SignUp(ctx context.Context, param *model.User) (
 
 	session := s.sessionService.NewSession(user.ID)
 	session.ID = user.SessionID
+	setClient(ctx, session)
 
 	// create Session
 	if _, err := s.createSession(ctx, tx, session); err != nil {
@@ -149,7 +159,20 @@ func (s *authenticationService) createSession(ctx context.Context, m query.SQLMa
 }
 
 // Login Login an user.
+// The attempts are refused with TooManyAttemptsError after failures of the user name or the client IP.
 func (s *authenticationService) Login(ctx context.Context, param *model.User) (user *model.User, err error) {
+	var ip string
+	if client, ok := model.ClientFromContext(ctx); ok {
+		ip = client.IP
+	}
+
+	// the name is normalized once, so that its variants share the failed attempts with it.
+	name := model.NormalizeUserName(param.Name)
+
+	if err := s.loginAttemptService.Check(ctx, s.m, name, ip); err != nil {
+		return nil, errors.Wrap(err, "failed to check login attempts")
+	}
+
 	tx, err := s.m.Begin()
 	if err != nil {
 		return nil, beginTxErrorMsg(err)
@@ -161,17 +184,32 @@ func (s *authenticationService) Login(ctx context.Context, param *model.User) (u
 		}
 	}()
 
-	ok, user, err := s.authenticationService.Authenticate(ctx, tx, param.Name, param.Password)
+	ok, user, err := s.authenticationService.Authenticate(ctx, tx, name, param.Password)
 	if err != nil {
+		if _, isAuthErr := errors.Cause(err).(*model.AuthenticationErr); isAuthErr {
+			s.recordLoginFailure(ctx, name, ip)
+		}
 		return nil, errors.Wrap(err, "failed to authenticate")
 	} else if !ok {
+		s.recordLoginFailure(ctx, name, ip)
 		return nil, errors.WithStack(&model.AuthenticationErr{
 			BaseErr: errors.New("name or pass is invalid"),
 		})
 	}
 
+	// the ban is checked after the password, so that it does not tell whether the name exists or not.
+	if user.Banned {
+		return nil, errors.WithStack(&model.BannedError{UserID: user.ID})
+	}
+
+	if err := s.loginAttemptService.RecordSuccess(ctx, s.m, name); err != nil {
+		logger.FromContext(ctx).Warn("failed to record login success", zap.String("error message", err.Error()))
+	}
+
+	// a user can have many sessions, so the sessions on other devices are kept.
 	session := s.sessionService.NewSession(user.ID)
 	session.ID = s.sessionService.SessionID()
+	setClient(ctx, session)
 
 	session, err = s.createSession(ctx, tx, session)
 
@@ -181,20 +219,31 @@ func (s *authenticationService) Login(ctx context.Context, param *model.User) (u
 
 	user.SessionID = session.ID
 
-	if err := s.userRepository.UpdateUser(ctx, tx, user.ID, user); err != nil {
-		return nil, errors.Wrap(err, "failed to insert user")
-	}
-
 	return user, nil
 }
 
+// recordLoginFailure records the failed login attempt.
+// It is recorded outside of tx, so that it is not rolled back with the login.
+func (s *authenticationService) recordLoginFailure(ctx context.Context, userName, ip string) {
+	if err := s.loginAttemptService.RecordFailure(ctx, s.m, userName, ip); err != nil {
+		logger.FromContext(ctx).Error("failed to record login failure", zap.String("error message", err.Error()))
+	}
+}
+
 // Logout logout a user.
-func (s *authenticationService) Logout(ctx context.Context, sessionID string) error {
+func (s *authenticationService) Logout(ctx context.Context, sessionID string) (err error) {
 	tx, err := s.m.Begin()
 	if err != nil {
 		return beginTxErrorMsg(err)
 	}
 
+	// publish events after tx has been committed, so that the connections of the session are closed.
+	defer func() {
+		if err == nil {
+			publishEvents(ctx, s.events, &event.LoggedOut{SessionID: sessionID})
+		}
+	}()
+
 	defer func() {
 		if err := s.txCloser(tx, err); err != nil {
 			err = errors.Wrap(err, "failed to close tx")
@@ -10,12 +10,15 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/event"
+	mock_event "github.com/sekky0905/nuxt-vue-go-chat/server/domain/event/mock"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
 	mock_repository "github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository/mock"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/service"
 	mock_service "github.com/sekky0905/nuxt-vue-go-chat/server/domain/service/mock"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/memory"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/testutil"
 )
 
@@ -255,6 +258,7 @@ func Test_authenticationService_Login(t *testing.T) {
 		userService           service.UserService
 		sessionService        service.SessionService
 		authenticationService service.AuthenticationService
+		loginAttemptService   service.LoginAttemptService
 		txCloser              CloseTransaction
 	}
 	type args struct {
@@ -329,6 +333,7 @@ func Test_authenticationService_Login(t *testing.T) {
 				userRepository:        mock_repository.NewMockUserRepository(ctrl),
 				sessionService:        mock_service.NewMockSessionService(ctrl),
 				authenticationService: mock_service.NewMockAuthenticationService(ctrl),
+				loginAttemptService:   mock_service.NewMockLoginAttemptService(ctrl),
 				sessionRepository:     mock_repository.NewMockSessionRepository(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
 					return nil
@@ -417,12 +422,6 @@ func Test_authenticationService_Login(t *testing.T) {
 			}
 			m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
 
-			ur, ok := tt.fields.userRepository.(*mock_repository.MockUserRepository)
-			if !ok {
-				t.Fatal("failed to assert MockUserRepository")
-			}
-			ur.EXPECT().UpdateUser(tt.args.ctx, gomock.Any(), tt.mockUserRepoArgs.user.ID, gomock.Any()).Return(tt.mockUserRepoReturns.err)
-
 			sr, ok := tt.fields.sessionRepository.(*mock_repository.MockSessionRepository)
 			if !ok {
 				t.Fatal("failed to assert MockSessionRepository")
@@ -445,11 +444,19 @@ func Test_authenticationService_Login(t *testing.T) {
 				Authenticate(tt.mockAuthenticationServiceArgs.ctx, gomock.Any(), tt.mockAuthenticationServiceArgs.userName, tt.mockAuthenticationServiceArgs.password).
 				Return(tt.mockAuthenticationServiceReturns.ok, tt.mockAuthenticationServiceReturns.user, tt.mockAuthenticationServiceReturns.err)
 
+			la, ok := tt.fields.loginAttemptService.(*mock_service.MockLoginAttemptService)
+			if !ok {
+				t.Fatal("failed to assert MockLoginAttemptService")
+			}
+			la.EXPECT().Check(tt.args.ctx, gomock.Any(), tt.args.user.Name, "").Return(nil)
+			la.EXPECT().RecordSuccess(tt.args.ctx, gomock.Any(), tt.args.user.Name).Return(nil)
+
 			a := &authenticationService{
 				m:                     tt.fields.m,
 				userRepository:        tt.fields.userRepository,
 				sessionService:        tt.fields.sessionService,
 				authenticationService: tt.fields.authenticationService,
+				loginAttemptService:   tt.fields.loginAttemptService,
 				sessionRepository:     tt.fields.sessionRepository,
 				txCloser:              tt.fields.txCloser,
 			}
@@ -469,6 +476,130 @@ func Test_authenticationService_Login(t *testing.T) {
 	}
 }
 
+func Test_authenticationService_Login_attempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := model.WithClient(context.Background(), &model.Client{IP: model.IPForTest})
+	param := &model.User{
+		Name:     model.UserNameForTest,
+		Password: model.PasswordForTest,
+	}
+
+	tests := []struct {
+		name          string
+		checkErr      error
+		authOK        bool
+		authUser      *model.User
+		authErr       error
+		expectFailure bool
+		wantErr       error
+	}{
+		{
+			name:     "When the attempts are too many, Login returns TooManyAttemptsError without authentication",
+			checkErr: &model.TooManyAttemptsError{RetryAfter: time.Second},
+			wantErr:  &model.TooManyAttemptsError{},
+		},
+		{
+			name:          "When the password is wrong, Login records the failure and returns AuthenticationErr",
+			authOK:        false,
+			expectFailure: true,
+			wantErr:       &model.AuthenticationErr{},
+		},
+		{
+			name:          "When the user does not exist, Login records the failure and returns AuthenticationErr",
+			authErr:       &model.AuthenticationErr{},
+			expectFailure: true,
+			wantErr:       &model.AuthenticationErr{},
+		},
+		{
+			name:          "When some error occurs at repository layer, Login does not record the failure",
+			authErr:       &model.RepositoryError{},
+			expectFailure: false,
+			wantErr:       &model.RepositoryError{},
+		},
+		{
+			name:          "When the user has been banned, Login returns BannedError without a session",
+			authOK:        true,
+			authUser:      &model.User{ID: model.UserValidIDForTest, Name: model.UserNameForTest, Banned: true},
+			expectFailure: false,
+			wantErr:       &model.BannedError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mock_query.NewMockDBManager(ctrl)
+			as := mock_service.NewMockAuthenticationService(ctrl)
+			la := mock_service.NewMockLoginAttemptService(ctrl)
+
+			la.EXPECT().Check(ctx, m, param.Name, model.IPForTest).Return(tt.checkErr)
+			if tt.checkErr == nil {
+				m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
+				as.EXPECT().Authenticate(ctx, gomock.Any(), param.Name, param.Password).Return(tt.authOK, tt.authUser, tt.authErr)
+			}
+			if tt.expectFailure {
+				la.EXPECT().RecordFailure(ctx, m, param.Name, model.IPForTest).Return(nil)
+			}
+
+			a := &authenticationService{
+				m:                     m,
+				authenticationService: as,
+				loginAttemptService:   la,
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+			}
+
+			_, err := a.Login(ctx, param)
+			if reflect.TypeOf(errors.Cause(err)) != reflect.TypeOf(tt.wantErr) {
+				t.Errorf("authenticationService.Login() error = %#v, wantErr %#v", errors.Cause(err), tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_authenticationService_Login_attemptsOfNameVariants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	policy := model.LoginAttemptPolicy{
+		FreeFailures:    2,
+		BaseDelay:       time.Minute,
+		MaxDelay:        time.Minute,
+		LockoutFailures: 3,
+		LockoutDuration: time.Hour,
+	}
+
+	m := mock_query.NewMockDBManager(ctrl)
+	as := mock_service.NewMockAuthenticationService(ctrl)
+
+	a := &authenticationService{
+		m:                     m,
+		authenticationService: as,
+		loginAttemptService:   service.NewLoginAttemptService(memory.NewLoginAttemptRepository(), policy, policy),
+		txCloser: func(tx query.TxManager, err error) error {
+			return nil
+		},
+	}
+
+	// the variants are normalized to the same name, so that they share one counter.
+	for _, name := range []string{" alice", "alice ", "\uff41\uff4c\uff49\uff43\uff45"} {
+		m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
+		as.EXPECT().Authenticate(ctx, gomock.Any(), "alice", model.PasswordForTest).Return(false, nil, nil)
+
+		_, err := a.Login(ctx, &model.User{Name: name, Password: model.PasswordForTest})
+		if _, ok := errors.Cause(err).(*model.AuthenticationErr); !ok {
+			t.Fatalf("authenticationService.Login(%q) error = %#v, want AuthenticationErr", name, errors.Cause(err))
+		}
+	}
+
+	_, err := a.Login(ctx, &model.User{Name: "alice", Password: model.PasswordForTest})
+	if _, ok := errors.Cause(err).(*model.TooManyAttemptsError); !ok {
+		t.Errorf("authenticationService.Login() error = %#v, want TooManyAttemptsError", errors.Cause(err))
+	}
+}
+
 func Test_authenticationService_Logout(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -490,6 +621,7 @@ func Test_authenticationService_Logout(t *testing.T) {
 		userService           service.UserService
 		sessionService        service.SessionService
 		authenticationService service.AuthenticationService
+		events                event.Publisher
 		txCloser              CloseTransaction
 	}
 	type args struct {
@@ -502,16 +634,18 @@ func Test_authenticationService_Logout(t *testing.T) {
 		args   args
 		mockArgs
 		mockReturn
-		wantErr bool
+		wantEvent bool
+		wantErr   bool
 	}{
 		{
-			name: "",
+			name: "When the session is deleted, Logout publishes LoggedOut and returns nil",
 			fields: fields{
 				m:                     mock_query.NewMockDBManager(ctrl),
 				userRepository:        mock_repository.NewMockUserRepository(ctrl),
 				sessionService:        mock_service.NewMockSessionService(ctrl),
 				authenticationService: mock_service.NewMockAuthenticationService(ctrl),
 				sessionRepository:     mock_repository.NewMockSessionRepository(ctrl),
+				events:                mock_event.NewMockPublisher(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
 					return nil
 				},
@@ -528,7 +662,36 @@ func Test_authenticationService_Logout(t *testing.T) {
 			mockReturn: mockReturn{
 				err: nil,
 			},
-			wantErr: false,
+			wantEvent: true,
+			wantErr:   false,
+		},
+		{
+			name: "When some error occurs at repository layer, Logout returns error without publishing LoggedOut",
+			fields: fields{
+				m:                     mock_query.NewMockDBManager(ctrl),
+				userRepository:        mock_repository.NewMockUserRepository(ctrl),
+				sessionService:        mock_service.NewMockSessionService(ctrl),
+				authenticationService: mock_service.NewMockAuthenticationService(ctrl),
+				sessionRepository:     mock_repository.NewMockSessionRepository(ctrl),
+				events:                mock_event.NewMockPublisher(ctrl),
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+			},
+			args: args{
+				ctx:       context.Background(),
+				sessionID: model.SessionValidIDForTest,
+			},
+			mockArgs: mockArgs{
+				ctx:       context.Background(),
+				m:         mock_query.NewMockDBManager(ctrl),
+				sessionID: model.SessionValidIDForTest,
+			},
+			mockReturn: mockReturn{
+				err: errors.New(model.ErrorMessageForTest),
+			},
+			wantEvent: false,
+			wantErr:   true,
 		},
 	}
 	for _, tt := range tests {
@@ -545,6 +708,14 @@ func Test_authenticationService_Logout(t *testing.T) {
 			}
 			sr.EXPECT().DeleteSession(tt.mockArgs.ctx, gomock.Any(), tt.mockArgs.sessionID).Return(tt.mockReturn.err)
 
+			if tt.wantEvent {
+				p, ok := tt.fields.events.(*mock_event.MockPublisher)
+				if !ok {
+					t.Fatal("failed to assert MockPublisher")
+				}
+				p.EXPECT().Publish(tt.args.ctx, &event.LoggedOut{SessionID: tt.args.sessionID})
+			}
+
 			a := &authenticationService{
 				m:                     tt.fields.m,
 				userRepository:        tt.fields.userRepository,
@@ -552,6 +723,7 @@ func Test_authenticationService_Logout(t *testing.T) {
 				userService:           tt.fields.userService,
 				sessionService:        tt.fields.sessionService,
 				authenticationService: tt.fields.authenticationService,
+				events:                tt.fields.events,
 				txCloser:              tt.fields.txCloser,
 			}
 			if err := a.Logout(tt.args.ctx, tt.args.sessionID); (err != nil) != tt.wantErr {
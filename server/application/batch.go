@@ -0,0 +1,26 @@
+package application
+
+import "context"
+
+// deleteInBatches calls deleteBatch with batchSize as the limit until it deletes fewer records than batchSize,
+// and returns the total. Each batch is a separate statement so that the table is not locked for long.
+func deleteInBatches(ctx context.Context, batchSize int, deleteBatch func(ctx context.Context, limit int) (int64, error)) (int64, error) {
+	var total int64
+	for {
+		deleted, err := deleteBatch(ctx, batchSize)
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+
+		if deleted < int64(batchSize) {
+			return total, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return total, ctx.Err()
+		default:
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+)
+
+// batchResult is the result which a mock returns for a batch of deletion.
+type batchResult struct {
+	deleted int64
+	err     error
+}
+
+// expectBatches expects a call for each of results by expect, which returns the result.
+func expectBatches(expect func() *gomock.Call, results ...batchResult) []*gomock.Call {
+	calls := make([]*gomock.Call, len(results))
+	for i, r := range results {
+		calls[i] = expect().Return(r.deleted, r.err)
+	}
+	return calls
+}
+
+func Test_deleteInBatches(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		results []batchResult
+		want    int64
+		wantErr bool
+	}{
+		{
+			name:    "When the records are fewer than batch size, deletes them at once",
+			ctx:     context.Background(),
+			results: []batchResult{{deleted: 3}},
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name:    "When the records are as many as batch size, deletes them until a batch deletes nothing",
+			ctx:     context.Background(),
+			results: []batchResult{{deleted: 10}, {deleted: 0}},
+			want:    10,
+			wantErr: false,
+		},
+		{
+			name:    "When some error occurs, returns the number deleted so far and error",
+			ctx:     context.Background(),
+			results: []batchResult{{deleted: 10}, {err: errors.New(model.ErrorMessageForTest)}},
+			want:    10,
+			wantErr: true,
+		},
+		{
+			name:    "When ctx is done, stops after the batch and returns error",
+			ctx:     canceled,
+			results: []batchResult{{deleted: 10}},
+			want:    10,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			got, err := deleteInBatches(tt.ctx, 10, func(ctx context.Context, limit int) (int64, error) {
+				if limit != 10 {
+					t.Errorf("limit = %d, want 10", limit)
+				}
+				r := tt.results[calls]
+				calls++
+				return r.deleted, r.err
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("deleteInBatches() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("deleteInBatches() = %v, want %v", got, tt.want)
+			}
+			if calls != len(tt.results) {
+				t.Errorf("the number of batches = %d, want %d", calls, len(tt.results))
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package application
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/event"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/service"
@@ -17,23 +19,35 @@ type CommentService interface {
 	CreateComment(ctx context.Context, comment *model.Comment) (*model.Comment, error)
 	UpdateComment(ctx context.Context, id uint32, comment *model.Comment) (*model.Comment, error)
 	DeleteComment(ctx context.Context, id uint32) error
+	RestoreComment(ctx context.Context, id uint32) (*model.Comment, error)
+	ListCommentRevisions(ctx context.Context, id uint32) (*model.CommentRevisionList, error)
 }
 
 // commentService is application service of comment.
 type commentService struct {
-	m        query.DBManager
-	service  service.CommentService
-	repo     repository.CommentRepository
-	txCloser CloseTransaction
+	m         query.DBManager
+	service   service.CommentService
+	repo      repository.CommentRepository
+	threads   repository.ThreadRepository
+	revisions repository.CommentRevisionRepository
+	policy    service.AuthorizationPolicy
+	events    event.Publisher
+	txCloser  CloseTransaction
+	now       func() time.Time
 }
 
 // NewCommentService generates and returns CommentService.
-func NewCommentService(m query.DBManager, service service.CommentService, repo repository.CommentRepository, txCloser CloseTransaction) CommentService {
+func NewCommentService(m query.DBManager, service service.CommentService, repo repository.CommentRepository, threads repository.ThreadRepository, revisions repository.CommentRevisionRepository, policy service.AuthorizationPolicy, events event.Publisher, txCloser CloseTransaction) CommentService {
 	return &commentService{
-		m:        m,
-		service:  service,
-		repo:     repo,
-		txCloser: txCloser,
+		m:         m,
+		service:   service,
+		repo:      repo,
+		threads:   threads,
+		revisions: revisions,
+		policy:    policy,
+		events:    events,
+		txCloser:  txCloser,
+		now:       time.Now,
 	}
 }
 
@@ -59,17 +73,36 @@ func (cs *commentService) GetComment(ctx context.Context, id uint32) (*model.Com
 
 // CreateComment creates Comment.
 func (cs *commentService) CreateComment(ctx context.Context, param *model.Comment) (comment *model.Comment, err error) {
+	author, err := authorFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get author")
+	}
+	param.User = author
+
 	tx, err := cs.m.Begin()
 	if err != nil {
 		return nil, beginTxErrorMsg(err)
 	}
 
+	// publish events after tx has been committed.
 	defer func() {
-		if err := cs.txCloser(tx, err); err != nil {
-			err = errors.Wrap(err, "failed to close tx")
+		if err == nil {
+			publishEvents(ctx, cs.events, &event.CommentCreated{Comment: comment})
 		}
 	}()
 
+	defer func() {
+		if closeErr := cs.txCloser(tx, err); closeErr != nil {
+			comment = nil
+			err = errors.Wrap(closeErr, "failed to close tx")
+		}
+	}()
+
+	// the comments can not be added to the soft-deleted thread.
+	if _, err := cs.threads.GetThreadByID(ctx, tx, param.ThreadID); err != nil {
+		return nil, errors.Wrap(err, "failed to get thread by id")
+	}
+
 	id, err := cs.repo.InsertComment(ctx, tx, param)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to insert comment")
@@ -83,67 +116,165 @@ func (cs *commentService) CreateComment(ctx context.Context, param *model.Commen
 func (cs *commentService) UpdateComment(ctx context.Context, id uint32, param *model.Comment) (comment *model.Comment, err error) {
 	copiedComment := *param
 
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+
 	tx, err := cs.m.Begin()
 	if err != nil {
 		return nil, beginTxErrorMsg(err)
 	}
 
+	// publish events after tx has been committed.
 	defer func() {
-		if err := cs.txCloser(tx, err); err != nil {
-			err = errors.Wrap(err, "failed to close tx")
+		if err == nil {
+			publishEvents(ctx, cs.events, &event.CommentUpdated{Comment: comment})
 		}
 	}()
 
-	yes, err := cs.service.IsAlreadyExistID(ctx, tx, copiedComment.ID)
-	if !yes {
-		err = &model.NoSuchDataError{
-			PropertyName:    model.IDProperty,
-			PropertyValue:   param.ID,
-			DomainModelName: model.DomainModelNameComment,
+	defer func() {
+		if closeErr := cs.txCloser(tx, err); closeErr != nil {
+			comment = nil
+			err = errors.Wrap(closeErr, "failed to close tx")
 		}
-		return nil, errors.Wrap(err, "does not exists ID")
-	}
+	}()
 
+	// the comment is locked, otherwise the concurrent edits would record the same revision and lose one of them.
+	current, err := cs.repo.GetCommentByIDForUpdate(ctx, tx, copiedComment.ID)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to is already exist ID")
+		return nil, errors.Wrap(err, "failed to get comment by id for update")
+	}
+
+	if err := cs.policy.CanModifyComment(user, current); err != nil {
+		return nil, errors.Wrap(err, "failed to authorize")
 	}
 
-	if err := cs.repo.UpdateComment(ctx, tx, param.ID, &copiedComment); err != nil {
+	now := cs.now()
+
+	// the current content is kept as a revision in the same tx, so that the edit is never lost.
+	revision := &model.CommentRevision{
+		CommentID: current.ID,
+		Content:   current.Content,
+		EditedBy:  user,
+		CreatedAt: current.UpdatedAt,
+		EditedAt:  now,
+	}
+	if _, err := cs.revisions.InsertCommentRevision(ctx, tx, revision); err != nil {
+		return nil, errors.Wrap(err, "failed to insert comment revision")
+	}
+
+	// only content is changed, and the author and the thread are kept.
+	updated := *current
+	updated.Content = copiedComment.Content
+	updated.Edited = true
+	updated.EditCount = current.EditCount + 1
+	updated.UpdatedAt = now
+
+	if err := cs.repo.UpdateComment(ctx, tx, updated.ID, &updated); err != nil {
 		return nil, errors.Wrap(err, "failed to update comment")
 	}
 
-	return &copiedComment, nil
+	return &updated, nil
 }
 
 // DeleteComment deletes Comment.
 func (cs *commentService) DeleteComment(ctx context.Context, id uint32) (err error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user")
+	}
+
 	tx, err := cs.m.Begin()
 	if err != nil {
 		return beginTxErrorMsg(err)
 	}
 
+	var comment *model.Comment
+
+	// publish events after tx has been committed.
 	defer func() {
-		if err := cs.txCloser(tx, err); err != nil {
-			err = errors.Wrap(err, "failed to close tx")
+		if err == nil {
+			publishEvents(ctx, cs.events, &event.CommentDeleted{Comment: comment})
 		}
 	}()
 
-	yes, err := cs.service.IsAlreadyExistID(ctx, tx, id)
-	if !yes {
-		err = &model.NoSuchDataError{
-			PropertyName:    model.IDProperty,
-			PropertyValue:   id,
-			DomainModelName: model.DomainModelNameComment,
+	defer func() {
+		if closeErr := cs.txCloser(tx, err); closeErr != nil {
+			err = errors.Wrap(closeErr, "failed to close tx")
 		}
-		return errors.Wrap(err, "does not exists id")
-	}
+	}()
+
+	// get the comment to know who wrote it and which thread it belongs to.
+	comment, err = cs.repo.GetCommentByID(ctx, tx, id)
 	if err != nil {
-		return errors.Wrap(err, "failed to is already exist id")
+		return errors.Wrap(err, "failed to get comment by id")
+	}
+
+	if err := cs.policy.CanModifyComment(user, comment); err != nil {
+		return errors.Wrap(err, "failed to authorize")
 	}
 
-	if err := cs.repo.DeleteComment(ctx, tx, id); err != nil {
+	if err := cs.repo.DeleteComment(ctx, tx, id, user.ID, cs.now()); err != nil {
 		return errors.Wrap(err, "failed to delete comment")
 	}
 
 	return nil
 }
+
+// RestoreComment restores the deleted Comment.
+func (cs *commentService) RestoreComment(ctx context.Context, id uint32) (comment *model.Comment, err error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+
+	tx, err := cs.m.Begin()
+	if err != nil {
+		return nil, beginTxErrorMsg(err)
+	}
+
+	// publish events after tx has been committed.
+	defer func() {
+		if err == nil {
+			publishEvents(ctx, cs.events, &event.CommentRestored{Comment: comment})
+		}
+	}()
+
+	defer func() {
+		if closeErr := cs.txCloser(tx, err); closeErr != nil {
+			comment = nil
+			err = errors.Wrap(closeErr, "failed to close tx")
+		}
+	}()
+
+	comment, err = cs.repo.GetDeletedCommentByID(ctx, tx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get deleted comment by id")
+	}
+
+	if err := cs.policy.CanModifyComment(user, comment); err != nil {
+		return nil, errors.Wrap(err, "failed to authorize")
+	}
+
+	if err := cs.repo.RestoreComment(ctx, tx, id); err != nil {
+		return nil, errors.Wrap(err, "failed to restore comment")
+	}
+
+	return comment, nil
+}
+
+// ListCommentRevisions lists the revisions of Comment from the oldest.
+func (cs *commentService) ListCommentRevisions(ctx context.Context, id uint32) (*model.CommentRevisionList, error) {
+	// the revisions of the deleted comment are hidden with it.
+	if _, err := cs.repo.GetCommentByID(ctx, cs.m, id); err != nil {
+		return nil, errors.Wrap(err, "failed to get comment by id")
+	}
+
+	revisions, err := cs.revisions.ListCommentRevisions(ctx, cs.m, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list comment revisions")
+	}
+
+	return &model.CommentRevisionList{Revisions: revisions}, nil
+}
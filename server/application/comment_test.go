@@ -8,6 +8,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/event"
+	mock_event "github.com/sekky0905/nuxt-vue-go-chat/server/domain/event/mock"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
 	mock_repository "github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository/mock"
@@ -247,10 +249,17 @@ func Test_commentService_CreateComment(t *testing.T) {
 
 	testutil.SetFakeTime(time.Now())
 
+	ctx := model.WithUser(context.Background(), &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+	})
+
 	type fields struct {
 		m        query.DBManager
 		service  service.CommentService
 		repo     repository.CommentRepository
+		threads  repository.ThreadRepository
+		events   event.Publisher
 		txCloser CloseTransaction
 	}
 	type args struct {
@@ -275,21 +284,24 @@ func Test_commentService_CreateComment(t *testing.T) {
 		args   args
 		mockArgsInsertComment
 		mockReturnsInsertComment
-		wantComment *model.Comment
-		wantErr     bool
+		threadDeleted bool
+		wantComment   *model.Comment
+		wantErr       bool
 	}{
 		{
 			name: "When appropriate args given, CreateComment returns id and nil",
 			fields: fields{
 				m:       mock_query.NewMockDBManager(ctrl),
 				repo:    mock_repository.NewMockCommentRepository(ctrl),
+				threads: mock_repository.NewMockThreadRepository(ctrl),
 				service: mock_service.NewMockCommentService(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
 					return nil
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: ctx,
 				param: &model.Comment{
 					ID:       model.CommentInValidIDForTest,
 					ThreadID: model.ThreadValidIDForTest,
@@ -301,7 +313,7 @@ func Test_commentService_CreateComment(t *testing.T) {
 				},
 			},
 			mockArgsInsertComment: mockArgsInsertComment{
-				ctx: context.Background(),
+				ctx: ctx,
 				param: &model.Comment{
 					ID:       model.CommentInValidIDForTest,
 					ThreadID: model.ThreadValidIDForTest,
@@ -332,13 +344,15 @@ func Test_commentService_CreateComment(t *testing.T) {
 			fields: fields{
 				m:       mock_query.NewMockDBManager(ctrl),
 				repo:    mock_repository.NewMockCommentRepository(ctrl),
+				threads: mock_repository.NewMockThreadRepository(ctrl),
 				service: mock_service.NewMockCommentService(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
 					return nil
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: ctx,
 				param: &model.Comment{
 					ID:       model.CommentValidIDForTest,
 					ThreadID: model.ThreadValidIDForTest,
@@ -350,7 +364,7 @@ func Test_commentService_CreateComment(t *testing.T) {
 				},
 			},
 			mockArgsInsertComment: mockArgsInsertComment{
-				ctx: context.Background(),
+				ctx: ctx,
 				tx:  mock_query.NewMockDBManager(ctrl),
 				param: &model.Comment{
 					ID:       model.CommentValidIDForTest,
@@ -369,111 +383,22 @@ func Test_commentService_CreateComment(t *testing.T) {
 			wantComment: nil,
 			wantErr:     true,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			m, ok := tt.fields.m.(*mock_query.MockDBManager)
-			if !ok {
-				t.Fatal("failed to assert MockDBManager")
-			}
-			m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
-
-			if tt.mockArgsInsertComment.param != nil {
-				tr, ok := tt.fields.repo.(*mock_repository.MockCommentRepository)
-				if !ok {
-					t.Fatal("failed to assert MockCommentRepository")
-				}
-
-				txM := mock_query.NewMockTxManager(ctrl)
-
-				tr.EXPECT().InsertComment(tt.mockArgsInsertComment.ctx, txM, tt.args.param).Return(tt.mockReturnsInsertComment.id, tt.mockReturnsInsertComment.err)
-			}
-
-			a := &commentService{
-				m:        tt.fields.m,
-				repo:     tt.fields.repo,
-				service:  tt.fields.service,
-				txCloser: tt.fields.txCloser,
-			}
-			gotComment, err := a.CreateComment(tt.args.ctx, tt.args.param)
-			if gotComment != nil {
-				gotComment.CreatedAt = tt.wantComment.CreatedAt
-				gotComment.UpdatedAt = tt.wantComment.UpdatedAt
-			}
-			if (err != nil) != tt.wantErr {
-				t.Errorf("commentService.CreateComment() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !reflect.DeepEqual(gotComment, tt.wantComment) {
-				t.Errorf("commentService.CreateComment() = %v, want %v", gotComment, tt.wantComment)
-			}
-		})
-	}
-}
-
-func Test_commentService_UpdateComment(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	testutil.SetFakeTime(time.Now())
-
-	type fields struct {
-		m        query.DBManager
-		service  service.CommentService
-		repo     repository.CommentRepository
-		txCloser CloseTransaction
-	}
-	type args struct {
-		ctx   context.Context
-		id    uint32
-		param *model.Comment
-	}
-
-	type mockArgsIsAlreadyExistID struct {
-		ctx context.Context
-		id  uint32
-	}
-
-	type mockReturnsIsAlreadyExistID struct {
-		found bool
-		err   error
-	}
-
-	type mockArgsUpdateComment struct {
-		ctx   context.Context
-		param *model.Comment
-	}
-
-	type mockReturnsUpdateComment struct {
-		err error
-	}
-
-	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		mockArgsIsAlreadyExistID
-		mockReturnsIsAlreadyExistID
-		mockArgsUpdateComment
-		mockReturnsUpdateComment
-		wantComment *model.Comment
-		wantErr     bool
-	}{
 		{
-			name: "When appropriate args given, UpdateComment returns Comment and err",
+			name: "When tx fails to commit, CreateComment returns nil and error and publishes no event",
 			fields: fields{
 				m:       mock_query.NewMockDBManager(ctrl),
-				service: mock_service.NewMockCommentService(ctrl),
 				repo:    mock_repository.NewMockCommentRepository(ctrl),
+				threads: mock_repository.NewMockThreadRepository(ctrl),
+				service: mock_service.NewMockCommentService(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
-					return nil
+					return errors.New(model.ErrorMessageForTest)
 				},
 			},
 			args: args{
-				ctx: context.Background(),
-				id:  model.CommentValidIDForTest,
+				ctx: ctx,
 				param: &model.Comment{
-					ID:       model.CommentValidIDForTest,
+					ID:       model.CommentInValidIDForTest,
 					ThreadID: model.ThreadValidIDForTest,
 					User: &model.User{
 						ID:   model.UserValidIDForTest,
@@ -482,18 +407,10 @@ func Test_commentService_UpdateComment(t *testing.T) {
 					Content: model.CommentContentForTest,
 				},
 			},
-			mockArgsIsAlreadyExistID: mockArgsIsAlreadyExistID{
-				ctx: context.Background(),
-				id:  model.CommentValidIDForTest,
-			},
-			mockReturnsIsAlreadyExistID: mockReturnsIsAlreadyExistID{
-				found: true,
-				err:   nil,
-			},
-			mockArgsUpdateComment: mockArgsUpdateComment{
-				ctx: context.Background(),
+			mockArgsInsertComment: mockArgsInsertComment{
+				ctx: ctx,
 				param: &model.Comment{
-					ID:       model.CommentValidIDForTest,
+					ID:       model.CommentInValidIDForTest,
 					ThreadID: model.ThreadValidIDForTest,
 					User: &model.User{
 						ID:   model.UserValidIDForTest,
@@ -502,35 +419,39 @@ func Test_commentService_UpdateComment(t *testing.T) {
 					Content: model.CommentContentForTest,
 				},
 			},
-			mockReturnsUpdateComment: mockReturnsUpdateComment{
+			mockReturnsInsertComment: mockReturnsInsertComment{
+				id:  model.CommentValidIDForTest,
 				err: nil,
 			},
-			wantComment: &model.Comment{
-				ID:       model.CommentValidIDForTest,
-				ThreadID: model.ThreadValidIDForTest,
-				User: &model.User{
-					ID:   model.UserValidIDForTest,
-					Name: model.UserNameForTest,
-				},
-				Content: model.CommentContentForTest,
-			},
-			wantErr: false,
+			wantComment: nil,
+			wantErr:     true,
 		},
 		{
-			name: "When given id has not existed, UpdateComment returns nil and error",
+			name: "When user ID of the author is forged, CreateComment creates comment by the user who requested",
 			fields: fields{
 				m:       mock_query.NewMockDBManager(ctrl),
-				service: mock_service.NewMockCommentService(ctrl),
 				repo:    mock_repository.NewMockCommentRepository(ctrl),
+				threads: mock_repository.NewMockThreadRepository(ctrl),
+				service: mock_service.NewMockCommentService(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
 					return nil
 				},
 			},
 			args: args{
-				ctx: context.Background(),
-				id:  model.CommentValidIDForTest,
+				ctx: ctx,
+				param: &model.Comment{
+					ThreadID: model.ThreadValidIDForTest,
+					User: &model.User{
+						ID:   model.UserInValidIDForTest,
+						Name: model.UserNameForTest,
+					},
+					Content: model.CommentContentForTest,
+				},
+			},
+			mockArgsInsertComment: mockArgsInsertComment{
+				ctx: ctx,
 				param: &model.Comment{
-					ID:       model.CommentValidIDForTest,
 					ThreadID: model.ThreadValidIDForTest,
 					User: &model.User{
 						ID:   model.UserValidIDForTest,
@@ -539,55 +460,59 @@ func Test_commentService_UpdateComment(t *testing.T) {
 					Content: model.CommentContentForTest,
 				},
 			},
-			mockArgsIsAlreadyExistID: mockArgsIsAlreadyExistID{
-				ctx: context.Background(),
+			mockReturnsInsertComment: mockReturnsInsertComment{
 				id:  model.CommentValidIDForTest,
+				err: nil,
 			},
-			mockReturnsIsAlreadyExistID: mockReturnsIsAlreadyExistID{
-				found: false,
-				err:   nil,
-			},
-			mockArgsUpdateComment: mockArgsUpdateComment{
-				param: nil,
+			wantComment: &model.Comment{
+				ID:       model.CommentValidIDForTest,
+				ThreadID: model.ThreadValidIDForTest,
+				User: &model.User{
+					ID:   model.UserValidIDForTest,
+					Name: model.UserNameForTest,
+				},
+				Content: model.CommentContentForTest,
 			},
-			wantComment: nil,
-			wantErr:     true,
+			wantErr: false,
 		},
 		{
-			name: "When some error occurs at repository layer, UpdateComment returns nil and error",
+			name: "When the thread has been deleted, CreateComment returns nil and NoSuchDataError without inserting",
 			fields: fields{
 				m:       mock_query.NewMockDBManager(ctrl),
-				service: mock_service.NewMockCommentService(ctrl),
 				repo:    mock_repository.NewMockCommentRepository(ctrl),
+				threads: mock_repository.NewMockThreadRepository(ctrl),
+				service: mock_service.NewMockCommentService(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
 					return nil
 				},
 			},
 			args: args{
-				ctx: context.Background(),
-				id:  model.CommentValidIDForTest,
+				ctx: ctx,
 				param: &model.Comment{
-					ID:       model.CommentValidIDForTest,
 					ThreadID: model.ThreadValidIDForTest,
-					User: &model.User{
-						ID:   model.UserValidIDForTest,
-						Name: model.UserNameForTest,
-					},
-					Content: model.CommentContentForTest,
+					Content:  model.CommentContentForTest,
 				},
 			},
-			mockArgsIsAlreadyExistID: mockArgsIsAlreadyExistID{
-				ctx: context.Background(),
-				id:  model.CommentValidIDForTest,
-			},
-			mockReturnsIsAlreadyExistID: mockReturnsIsAlreadyExistID{
-				found: true,
-				err:   nil,
+			threadDeleted: true,
+			wantComment:   nil,
+			wantErr:       true,
+		},
+		{
+			name: "When the user who requested is not in context, CreateComment returns nil and error",
+			fields: fields{
+				m:       mock_query.NewMockDBManager(ctrl),
+				repo:    mock_repository.NewMockCommentRepository(ctrl),
+				threads: mock_repository.NewMockThreadRepository(ctrl),
+				service: mock_service.NewMockCommentService(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
 			},
-			mockArgsUpdateComment: mockArgsUpdateComment{
+			args: args{
 				ctx: context.Background(),
 				param: &model.Comment{
-					ID:       model.CommentValidIDForTest,
 					ThreadID: model.ThreadValidIDForTest,
 					User: &model.User{
 						ID:   model.UserValidIDForTest,
@@ -596,9 +521,6 @@ func Test_commentService_UpdateComment(t *testing.T) {
 					Content: model.CommentContentForTest,
 				},
 			},
-			mockReturnsUpdateComment: mockReturnsUpdateComment{
-				err: errors.New(model.ErrorMessageForTest),
-			},
 			wantComment: nil,
 			wantErr:     true,
 		},
@@ -609,62 +531,107 @@ func Test_commentService_UpdateComment(t *testing.T) {
 			if !ok {
 				t.Fatal("failed to assert MockDBManager")
 			}
-			m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
-
-			ts, ok := tt.fields.service.(*mock_service.MockCommentService)
-			if !ok {
-				t.Fatal("failed to assert MockCommentService")
-			}
 
-			ts.EXPECT().IsAlreadyExistID(tt.mockArgsIsAlreadyExistID.ctx, gomock.Any(), tt.mockArgsIsAlreadyExistID.id).Return(tt.mockReturnsIsAlreadyExistID.found, tt.mockReturnsIsAlreadyExistID.err)
+			if tt.mockArgsInsertComment.param != nil || tt.threadDeleted {
+				m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
 
-			if tt.mockArgsUpdateComment.param != nil {
-
-				tr, ok := tt.fields.repo.(*mock_repository.MockCommentRepository)
+				th, ok := tt.fields.threads.(*mock_repository.MockThreadRepository)
 				if !ok {
-					t.Fatal("failed to assert MockCommentRepository")
+					t.Fatal("failed to assert MockThreadRepository")
 				}
 
 				txM := mock_query.NewMockTxManager(ctrl)
 
-				tr.EXPECT().UpdateComment(tt.mockArgsUpdateComment.ctx, txM, tt.args.id, tt.args.param).Return(tt.mockReturnsUpdateComment.err)
+				if tt.threadDeleted {
+					th.EXPECT().GetThreadByID(tt.args.ctx, txM, tt.args.param.ThreadID).Return(nil, &model.NoSuchDataError{DomainModelName: model.DomainModelNameThread})
+				} else {
+					th.EXPECT().GetThreadByID(tt.args.ctx, txM, tt.args.param.ThreadID).Return(&model.Thread{ID: tt.args.param.ThreadID}, nil)
+
+					tr, ok := tt.fields.repo.(*mock_repository.MockCommentRepository)
+					if !ok {
+						t.Fatal("failed to assert MockCommentRepository")
+					}
+
+					tr.EXPECT().InsertComment(tt.mockArgsInsertComment.ctx, txM, tt.mockArgsInsertComment.param).Return(tt.mockReturnsInsertComment.id, tt.mockReturnsInsertComment.err)
+				}
+			}
+
+			if !tt.wantErr {
+				p, ok := tt.fields.events.(*mock_event.MockPublisher)
+				if !ok {
+					t.Fatal("failed to assert MockPublisher")
+				}
 
+				p.EXPECT().Publish(gomock.Any(), &event.CommentCreated{Comment: tt.wantComment})
 			}
 
 			a := &commentService{
 				m:        tt.fields.m,
-				service:  tt.fields.service,
 				repo:     tt.fields.repo,
+				threads:  tt.fields.threads,
+				service:  tt.fields.service,
+				events:   tt.fields.events,
 				txCloser: tt.fields.txCloser,
 			}
-
-			gotComment, err := a.UpdateComment(tt.args.ctx, tt.args.id, tt.args.param)
+			gotComment, err := a.CreateComment(tt.args.ctx, tt.args.param)
 			if gotComment != nil {
+				gotComment.CreatedAt = tt.wantComment.CreatedAt
 				gotComment.UpdatedAt = tt.wantComment.UpdatedAt
 			}
-
 			if (err != nil) != tt.wantErr {
-				t.Errorf("commentService.UpdateComment() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("commentService.CreateComment() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if _, ok := errors.Cause(err).(*model.NoSuchDataError); tt.threadDeleted && !ok {
+				t.Errorf("commentService.CreateComment() error = %v, want NoSuchDataError", err)
+			}
 			if !reflect.DeepEqual(gotComment, tt.wantComment) {
-				t.Errorf("commentService.UpdateComment() = %+v, want %+v", gotComment, tt.wantComment)
+				t.Errorf("commentService.CreateComment() = %v, want %v", gotComment, tt.wantComment)
 			}
 		})
 	}
 }
 
-func Test_commentService_DeleteComment(t *testing.T) {
+func Test_commentService_UpdateComment(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	testutil.SetFakeTime(time.Now())
 
+	user := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+	}
+	ctx := model.WithUser(context.Background(), user)
+
+	// the comment was posted before, so that the update must change UpdatedAt.
+	postedAt := testutil.TimeNow().Add(-time.Hour)
+
+	stored := &model.Comment{
+		ID:        model.CommentValidIDForTest,
+		Content:   "beforeUpdate",
+		ThreadID:  model.ThreadValidIDForTest,
+		User:      user,
+		CreatedAt: postedAt,
+		UpdatedAt: postedAt,
+	}
+
+	revision := &model.CommentRevision{
+		CommentID: model.CommentValidIDForTest,
+		Content:   "beforeUpdate",
+		EditedBy:  user,
+		CreatedAt: postedAt,
+		EditedAt:  testutil.TimeNow(),
+	}
+
 	type fields struct {
-		m        query.DBManager
-		service  service.CommentService
-		repo     repository.CommentRepository
-		txCloser CloseTransaction
+		m         query.DBManager
+		service   service.CommentService
+		repo      repository.CommentRepository
+		revisions repository.CommentRevisionRepository
+		policy    service.AuthorizationPolicy
+		events    event.Publisher
+		txCloser  CloseTransaction
 	}
 	type args struct {
 		ctx   context.Context
@@ -672,17 +639,28 @@ func Test_commentService_DeleteComment(t *testing.T) {
 		param *model.Comment
 	}
 
-	type mockArgsIsAlreadyExistID struct {
-		ctx context.Context
-		id  uint32
+	type mockReturnsGetCommentByID struct {
+		comment *model.Comment
+		err     error
 	}
 
-	type mockReturnsIsAlreadyExistID struct {
-		found bool
-		err   error
+	type mockReturnsCanModifyComment struct {
+		err error
 	}
 
-	type mockReturnsDeleteComment struct {
+	type mockArgsInsertCommentRevision struct {
+		revision *model.CommentRevision
+	}
+
+	type mockReturnsInsertCommentRevision struct {
+		err error
+	}
+
+	type mockArgsUpdateComment struct {
+		param *model.Comment
+	}
+
+	type mockReturnsUpdateComment struct {
 		err error
 	}
 
@@ -690,128 +668,225 @@ func Test_commentService_DeleteComment(t *testing.T) {
 		name   string
 		fields fields
 		args   args
-		mockArgsIsAlreadyExistID
-		mockReturnsIsAlreadyExistID
-		mockReturnsDeleteComment
+		mockReturnsGetCommentByID
+		mockReturnsCanModifyComment
+		mockArgsInsertCommentRevision
+		mockReturnsInsertCommentRevision
+		mockArgsUpdateComment
+		mockReturnsUpdateComment
 		wantComment *model.Comment
 		wantErr     bool
 	}{
 		{
-			name: "When appropriate args given, DeleteComment returns Comment and err",
+			name: "When the author updates the comment, UpdateComment returns Comment and nil",
 			fields: fields{
-				m:       mock_query.NewMockDBManager(ctrl),
-				service: mock_service.NewMockCommentService(ctrl),
-				repo:    mock_repository.NewMockCommentRepository(ctrl),
+				m:         mock_query.NewMockDBManager(ctrl),
+				service:   mock_service.NewMockCommentService(ctrl),
+				repo:      mock_repository.NewMockCommentRepository(ctrl),
+				revisions: mock_repository.NewMockCommentRevisionRepository(ctrl),
+				policy:    mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:    mock_event.NewMockPublisher(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
 					return nil
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: ctx,
 				id:  model.CommentValidIDForTest,
 				param: &model.Comment{
-					ID:       model.CommentValidIDForTest,
-					ThreadID: model.ThreadValidIDForTest,
-					User: &model.User{
-						ID:   model.UserValidIDForTest,
-						Name: model.UserNameForTest,
-					},
+					ID:      model.CommentValidIDForTest,
 					Content: model.CommentContentForTest,
 				},
 			},
-			mockArgsIsAlreadyExistID: mockArgsIsAlreadyExistID{
-				ctx: context.Background(),
-				id:  model.CommentValidIDForTest,
+			mockReturnsGetCommentByID: mockReturnsGetCommentByID{
+				comment: stored,
 			},
-			mockReturnsIsAlreadyExistID: mockReturnsIsAlreadyExistID{
-				found: true,
-				err:   nil,
+			mockArgsInsertCommentRevision: mockArgsInsertCommentRevision{
+				revision: revision,
 			},
-			mockReturnsDeleteComment: mockReturnsDeleteComment{
-				err: nil,
+			mockArgsUpdateComment: mockArgsUpdateComment{
+				param: &model.Comment{
+					ID:        model.CommentValidIDForTest,
+					Content:   model.CommentContentForTest,
+					ThreadID:  model.ThreadValidIDForTest,
+					User:      user,
+					Edited:    true,
+					EditCount: 1,
+					CreatedAt: postedAt,
+					UpdatedAt: testutil.TimeNow(),
+				},
 			},
 			wantComment: &model.Comment{
-				ID:       model.CommentValidIDForTest,
-				ThreadID: model.ThreadValidIDForTest,
-				User: &model.User{
-					ID:   model.UserValidIDForTest,
-					Name: model.UserNameForTest,
-				},
-				Content: model.CommentContentForTest,
+				ID:        model.CommentValidIDForTest,
+				Content:   model.CommentContentForTest,
+				ThreadID:  model.ThreadValidIDForTest,
+				User:      user,
+				Edited:    true,
+				EditCount: 1,
+				CreatedAt: postedAt,
+				UpdatedAt: testutil.TimeNow(),
 			},
 			wantErr: false,
 		},
 		{
-			name: "When given id has not existed, DeleteComment returns nil and error",
+			name: "When the user who is not the author updates the comment, UpdateComment returns nil and ForbiddenError",
 			fields: fields{
-				m:       mock_query.NewMockDBManager(ctrl),
-				service: mock_service.NewMockCommentService(ctrl),
-				repo:    mock_repository.NewMockCommentRepository(ctrl),
+				m:         mock_query.NewMockDBManager(ctrl),
+				service:   mock_service.NewMockCommentService(ctrl),
+				repo:      mock_repository.NewMockCommentRepository(ctrl),
+				revisions: mock_repository.NewMockCommentRevisionRepository(ctrl),
+				policy:    mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:    mock_event.NewMockPublisher(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
 					return nil
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: ctx,
 				id:  model.CommentValidIDForTest,
 				param: &model.Comment{
-					ID:       model.CommentValidIDForTest,
-					ThreadID: model.ThreadValidIDForTest,
-					User: &model.User{
-						ID:   model.UserValidIDForTest,
-						Name: model.UserNameForTest,
-					},
+					ID:      model.CommentValidIDForTest,
 					Content: model.CommentContentForTest,
 				},
 			},
-			mockArgsIsAlreadyExistID: mockArgsIsAlreadyExistID{
-				ctx: context.Background(),
-				id:  model.CommentValidIDForTest,
+			mockReturnsGetCommentByID: mockReturnsGetCommentByID{
+				comment: stored,
 			},
-			mockReturnsIsAlreadyExistID: mockReturnsIsAlreadyExistID{
-				found: false,
-				err:   nil,
+			mockReturnsCanModifyComment: mockReturnsCanModifyComment{
+				err: &model.ForbiddenError{},
 			},
 			wantComment: nil,
 			wantErr:     true,
 		},
 		{
-			name: "When some error occurs at repository layer, DeleteComment returns nil and error",
+			name: "When given id has not existed, UpdateComment returns nil and error",
 			fields: fields{
-				m:       mock_query.NewMockDBManager(ctrl),
-				service: mock_service.NewMockCommentService(ctrl),
-				repo:    mock_repository.NewMockCommentRepository(ctrl),
+				m:         mock_query.NewMockDBManager(ctrl),
+				service:   mock_service.NewMockCommentService(ctrl),
+				repo:      mock_repository.NewMockCommentRepository(ctrl),
+				revisions: mock_repository.NewMockCommentRevisionRepository(ctrl),
+				policy:    mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:    mock_event.NewMockPublisher(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
 					return nil
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: ctx,
+				id:  model.CommentInValidIDForTest,
+				param: &model.Comment{
+					ID:      model.CommentInValidIDForTest,
+					Content: model.CommentContentForTest,
+				},
+			},
+			mockReturnsGetCommentByID: mockReturnsGetCommentByID{
+				err: &model.NoSuchDataError{},
+			},
+			wantComment: nil,
+			wantErr:     true,
+		},
+		{
+			name: "When some error occurs at repository layer, UpdateComment returns nil and error",
+			fields: fields{
+				m:         mock_query.NewMockDBManager(ctrl),
+				service:   mock_service.NewMockCommentService(ctrl),
+				repo:      mock_repository.NewMockCommentRepository(ctrl),
+				revisions: mock_repository.NewMockCommentRevisionRepository(ctrl),
+				policy:    mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:    mock_event.NewMockPublisher(ctrl),
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+			},
+			args: args{
+				ctx: ctx,
 				id:  model.CommentValidIDForTest,
 				param: &model.Comment{
-					ID:       model.CommentValidIDForTest,
-					ThreadID: model.ThreadValidIDForTest,
-					User: &model.User{
-						ID:   model.UserValidIDForTest,
-						Name: model.UserNameForTest,
-					},
+					ID:      model.CommentValidIDForTest,
 					Content: model.CommentContentForTest,
 				},
 			},
-			mockArgsIsAlreadyExistID: mockArgsIsAlreadyExistID{
-				ctx: context.Background(),
+			mockReturnsGetCommentByID: mockReturnsGetCommentByID{
+				comment: stored,
+			},
+			mockArgsInsertCommentRevision: mockArgsInsertCommentRevision{
+				revision: revision,
+			},
+			mockArgsUpdateComment: mockArgsUpdateComment{
+				param: &model.Comment{
+					ID:        model.CommentValidIDForTest,
+					Content:   model.CommentContentForTest,
+					ThreadID:  model.ThreadValidIDForTest,
+					User:      user,
+					Edited:    true,
+					EditCount: 1,
+					CreatedAt: postedAt,
+					UpdatedAt: testutil.TimeNow(),
+				},
+			},
+			mockReturnsUpdateComment: mockReturnsUpdateComment{
+				err: errors.New(model.ErrorMessageForTest),
+			},
+			wantComment: nil,
+			wantErr:     true,
+		},
+		{
+			name: "When some error occurs at saving the revision, UpdateComment returns nil and error",
+			fields: fields{
+				m:         mock_query.NewMockDBManager(ctrl),
+				service:   mock_service.NewMockCommentService(ctrl),
+				repo:      mock_repository.NewMockCommentRepository(ctrl),
+				revisions: mock_repository.NewMockCommentRevisionRepository(ctrl),
+				policy:    mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:    mock_event.NewMockPublisher(ctrl),
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+			},
+			args: args{
+				ctx: ctx,
 				id:  model.CommentValidIDForTest,
+				param: &model.Comment{
+					ID:      model.CommentValidIDForTest,
+					Content: model.CommentContentForTest,
+				},
 			},
-			mockReturnsIsAlreadyExistID: mockReturnsIsAlreadyExistID{
-				found: true,
-				err:   nil,
+			mockReturnsGetCommentByID: mockReturnsGetCommentByID{
+				comment: stored,
 			},
-			mockReturnsDeleteComment: mockReturnsDeleteComment{
+			mockArgsInsertCommentRevision: mockArgsInsertCommentRevision{
+				revision: revision,
+			},
+			mockReturnsInsertCommentRevision: mockReturnsInsertCommentRevision{
 				err: errors.New(model.ErrorMessageForTest),
 			},
 			wantComment: nil,
 			wantErr:     true,
 		},
+		{
+			name: "When the user who requested is not in context, UpdateComment returns nil and error",
+			fields: fields{
+				m:         mock_query.NewMockDBManager(ctrl),
+				service:   mock_service.NewMockCommentService(ctrl),
+				repo:      mock_repository.NewMockCommentRepository(ctrl),
+				revisions: mock_repository.NewMockCommentRevisionRepository(ctrl),
+				policy:    mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:    mock_event.NewMockPublisher(ctrl),
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  model.CommentValidIDForTest,
+				param: &model.Comment{
+					ID:      model.CommentValidIDForTest,
+					Content: model.CommentContentForTest,
+				},
+			},
+			wantComment: nil,
+			wantErr:     true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -819,31 +894,283 @@ func Test_commentService_DeleteComment(t *testing.T) {
 			if !ok {
 				t.Fatal("failed to assert MockDBManager")
 			}
-			m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
 
-			ts, ok := tt.fields.service.(*mock_service.MockCommentService)
+			tr, ok := tt.fields.repo.(*mock_repository.MockCommentRepository)
+			if !ok {
+				t.Fatal("failed to assert MockCommentRepository")
+			}
+
+			ap, ok := tt.fields.policy.(*mock_service.MockAuthorizationPolicy)
+			if !ok {
+				t.Fatal("failed to assert MockAuthorizationPolicy")
+			}
+
+			if _, ok := model.UserFromContext(tt.args.ctx); ok {
+				m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
+				tr.EXPECT().GetCommentByIDForUpdate(tt.args.ctx, gomock.Any(), tt.args.param.ID).Return(tt.mockReturnsGetCommentByID.comment, tt.mockReturnsGetCommentByID.err)
+			}
+
+			if tt.mockReturnsGetCommentByID.comment != nil {
+				ap.EXPECT().CanModifyComment(user, tt.mockReturnsGetCommentByID.comment).Return(tt.mockReturnsCanModifyComment.err)
+			}
+
+			rr, ok := tt.fields.revisions.(*mock_repository.MockCommentRevisionRepository)
 			if !ok {
-				t.Fatal("failed to assert MockCommentService")
+				t.Fatal("failed to assert MockCommentRevisionRepository")
 			}
 
-			ts.EXPECT().IsAlreadyExistID(tt.mockArgsIsAlreadyExistID.ctx, gomock.Any(), tt.mockArgsIsAlreadyExistID.id).Return(tt.mockReturnsIsAlreadyExistID.found, tt.mockReturnsIsAlreadyExistID.err)
+			if tt.mockArgsInsertCommentRevision.revision != nil {
+				rr.EXPECT().InsertCommentRevision(tt.args.ctx, gomock.Any(), tt.mockArgsInsertCommentRevision.revision).Return(model.CommentRevisionValidIDForTest, tt.mockReturnsInsertCommentRevision.err)
+			}
 
-			if tt.mockReturnsIsAlreadyExistID.found {
-				tr, ok := tt.fields.repo.(*mock_repository.MockCommentRepository)
+			if tt.mockArgsUpdateComment.param != nil {
+				tr.EXPECT().UpdateComment(tt.args.ctx, gomock.Any(), tt.args.id, tt.mockArgsUpdateComment.param).Return(tt.mockReturnsUpdateComment.err)
+			}
+
+			if !tt.wantErr {
+				p, ok := tt.fields.events.(*mock_event.MockPublisher)
 				if !ok {
-					t.Fatal("failed to assert MockCommentRepository")
+					t.Fatal("failed to assert MockPublisher")
 				}
 
-				txM := mock_query.NewMockTxManager(ctrl)
+				p.EXPECT().Publish(gomock.Any(), &event.CommentUpdated{Comment: tt.wantComment})
+			}
+
+			a := &commentService{
+				m:         tt.fields.m,
+				service:   tt.fields.service,
+				repo:      tt.fields.repo,
+				revisions: tt.fields.revisions,
+				policy:    tt.fields.policy,
+				events:    tt.fields.events,
+				txCloser:  tt.fields.txCloser,
+				now:       testutil.TimeNow,
+			}
+
+			gotComment, err := a.UpdateComment(tt.args.ctx, tt.args.id, tt.args.param)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("commentService.UpdateComment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotComment, tt.wantComment) {
+				t.Errorf("commentService.UpdateComment() = %+v, want %+v", gotComment, tt.wantComment)
+			}
+		})
+	}
+}
+
+func Test_commentService_DeleteComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testutil.SetFakeTime(time.Now())
+
+	user := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+	}
+	ctx := model.WithUser(context.Background(), user)
+
+	stored := &model.Comment{
+		ID:        model.CommentValidIDForTest,
+		Content:   model.CommentContentForTest,
+		ThreadID:  model.ThreadValidIDForTest,
+		User:      user,
+		CreatedAt: testutil.TimeNow(),
+		UpdatedAt: testutil.TimeNow(),
+	}
+
+	type fields struct {
+		m        query.DBManager
+		service  service.CommentService
+		repo     repository.CommentRepository
+		policy   service.AuthorizationPolicy
+		events   event.Publisher
+		txCloser CloseTransaction
+	}
+	type args struct {
+		ctx context.Context
+		id  uint32
+	}
+
+	type mockReturnsGetCommentByID struct {
+		comment *model.Comment
+		err     error
+	}
+
+	type mockReturnsCanModifyComment struct {
+		err error
+	}
+
+	type mockReturnsDeleteComment struct {
+		err error
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		mockReturnsGetCommentByID
+		mockReturnsCanModifyComment
+		mockReturnsDeleteComment
+		wantErr bool
+	}{
+		{
+			name: "When the author deletes the comment, DeleteComment returns nil",
+			fields: fields{
+				m:       mock_query.NewMockDBManager(ctrl),
+				service: mock_service.NewMockCommentService(ctrl),
+				repo:    mock_repository.NewMockCommentRepository(ctrl),
+				policy:  mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+			},
+			args: args{
+				ctx: ctx,
+				id:  model.CommentValidIDForTest,
+			},
+			mockReturnsGetCommentByID: mockReturnsGetCommentByID{
+				comment: stored,
+			},
+			wantErr: false,
+		},
+		{
+			name: "When the user who is not the author deletes the comment, DeleteComment returns ForbiddenError",
+			fields: fields{
+				m:       mock_query.NewMockDBManager(ctrl),
+				service: mock_service.NewMockCommentService(ctrl),
+				repo:    mock_repository.NewMockCommentRepository(ctrl),
+				policy:  mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+			},
+			args: args{
+				ctx: ctx,
+				id:  model.CommentValidIDForTest,
+			},
+			mockReturnsGetCommentByID: mockReturnsGetCommentByID{
+				comment: stored,
+			},
+			mockReturnsCanModifyComment: mockReturnsCanModifyComment{
+				err: &model.ForbiddenError{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "When given id has not existed, DeleteComment returns error",
+			fields: fields{
+				m:       mock_query.NewMockDBManager(ctrl),
+				service: mock_service.NewMockCommentService(ctrl),
+				repo:    mock_repository.NewMockCommentRepository(ctrl),
+				policy:  mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+			},
+			args: args{
+				ctx: ctx,
+				id:  model.CommentInValidIDForTest,
+			},
+			mockReturnsGetCommentByID: mockReturnsGetCommentByID{
+				err: &model.NoSuchDataError{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "When some error occurs at repository layer, DeleteComment returns error",
+			fields: fields{
+				m:       mock_query.NewMockDBManager(ctrl),
+				service: mock_service.NewMockCommentService(ctrl),
+				repo:    mock_repository.NewMockCommentRepository(ctrl),
+				policy:  mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+			},
+			args: args{
+				ctx: ctx,
+				id:  model.CommentValidIDForTest,
+			},
+			mockReturnsGetCommentByID: mockReturnsGetCommentByID{
+				comment: stored,
+			},
+			mockReturnsDeleteComment: mockReturnsDeleteComment{
+				err: errors.New(model.ErrorMessageForTest),
+			},
+			wantErr: true,
+		},
+		{
+			name: "When the user who requested is not in context, DeleteComment returns error",
+			fields: fields{
+				m:       mock_query.NewMockDBManager(ctrl),
+				service: mock_service.NewMockCommentService(ctrl),
+				repo:    mock_repository.NewMockCommentRepository(ctrl),
+				policy:  mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  model.CommentValidIDForTest,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ok := tt.fields.m.(*mock_query.MockDBManager)
+			if !ok {
+				t.Fatal("failed to assert MockDBManager")
+			}
+
+			tr, ok := tt.fields.repo.(*mock_repository.MockCommentRepository)
+			if !ok {
+				t.Fatal("failed to assert MockCommentRepository")
+			}
+
+			ap, ok := tt.fields.policy.(*mock_service.MockAuthorizationPolicy)
+			if !ok {
+				t.Fatal("failed to assert MockAuthorizationPolicy")
+			}
+
+			if _, ok := model.UserFromContext(tt.args.ctx); ok {
+				m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
+				tr.EXPECT().GetCommentByID(tt.args.ctx, gomock.Any(), tt.args.id).Return(tt.mockReturnsGetCommentByID.comment, tt.mockReturnsGetCommentByID.err)
+			}
 
-				tr.EXPECT().DeleteComment(tt.args.ctx, txM, tt.args.id).Return(tt.mockReturnsDeleteComment.err)
+			if tt.mockReturnsGetCommentByID.comment != nil {
+				ap.EXPECT().CanModifyComment(user, tt.mockReturnsGetCommentByID.comment).Return(tt.mockReturnsCanModifyComment.err)
+
+				if tt.mockReturnsCanModifyComment.err == nil {
+					tr.EXPECT().DeleteComment(tt.args.ctx, gomock.Any(), tt.args.id, user.ID, testutil.TimeNow()).Return(tt.mockReturnsDeleteComment.err)
+				}
+			}
+
+			if !tt.wantErr {
+				p, ok := tt.fields.events.(*mock_event.MockPublisher)
+				if !ok {
+					t.Fatal("failed to assert MockPublisher")
+				}
+
+				p.EXPECT().Publish(gomock.Any(), &event.CommentDeleted{Comment: tt.mockReturnsGetCommentByID.comment})
 			}
 
 			a := &commentService{
 				m:        tt.fields.m,
 				service:  tt.fields.service,
 				repo:     tt.fields.repo,
+				policy:   tt.fields.policy,
+				events:   tt.fields.events,
 				txCloser: tt.fields.txCloser,
+				now:      testutil.TimeNow,
 			}
 
 			err := a.DeleteComment(tt.args.ctx, tt.args.id)
@@ -854,3 +1181,232 @@ func Test_commentService_DeleteComment(t *testing.T) {
 		})
 	}
 }
+
+func Test_commentService_RestoreComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testutil.SetFakeTime(time.Now())
+
+	user := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+	}
+	ctx := model.WithUser(context.Background(), user)
+
+	deleted := &model.Comment{
+		ID:        model.CommentValidIDForTest,
+		Content:   model.CommentContentForTest,
+		ThreadID:  model.ThreadValidIDForTest,
+		User:      user,
+		CreatedAt: testutil.TimeNow(),
+		UpdatedAt: testutil.TimeNow(),
+	}
+
+	type mockReturnsGetDeletedCommentByID struct {
+		comment *model.Comment
+		err     error
+	}
+
+	type mockReturnsCanModifyComment struct {
+		err error
+	}
+
+	type mockReturnsRestoreComment struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		mockReturnsGetDeletedCommentByID
+		mockReturnsCanModifyComment
+		mockReturnsRestoreComment
+		want    *model.Comment
+		wantErr bool
+	}{
+		{
+			name: "When the author restores the deleted comment, RestoreComment returns it",
+			ctx:  ctx,
+			mockReturnsGetDeletedCommentByID: mockReturnsGetDeletedCommentByID{
+				comment: deleted,
+			},
+			want:    deleted,
+			wantErr: false,
+		},
+		{
+			name: "When the comment has not been deleted, RestoreComment returns error",
+			ctx:  ctx,
+			mockReturnsGetDeletedCommentByID: mockReturnsGetDeletedCommentByID{
+				err: &model.NoSuchDataError{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "When the user who is not the author restores the comment, RestoreComment returns ForbiddenError",
+			ctx:  ctx,
+			mockReturnsGetDeletedCommentByID: mockReturnsGetDeletedCommentByID{
+				comment: deleted,
+			},
+			mockReturnsCanModifyComment: mockReturnsCanModifyComment{
+				err: &model.ForbiddenError{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "When some error occurs at repository layer, RestoreComment returns error",
+			ctx:  ctx,
+			mockReturnsGetDeletedCommentByID: mockReturnsGetDeletedCommentByID{
+				comment: deleted,
+			},
+			mockReturnsRestoreComment: mockReturnsRestoreComment{
+				err: errors.New(model.ErrorMessageForTest),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "When the user who requested is not in context, RestoreComment returns error",
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mock_query.NewMockDBManager(ctrl)
+			cr := mock_repository.NewMockCommentRepository(ctrl)
+			ap := mock_service.NewMockAuthorizationPolicy(ctrl)
+			p := mock_event.NewMockPublisher(ctrl)
+			tx := mock_query.NewMockTxManager(ctrl)
+
+			if _, ok := model.UserFromContext(tt.ctx); ok {
+				m.EXPECT().Begin().Return(tx, nil)
+				cr.EXPECT().GetDeletedCommentByID(tt.ctx, tx, model.CommentValidIDForTest).Return(tt.mockReturnsGetDeletedCommentByID.comment, tt.mockReturnsGetDeletedCommentByID.err)
+			}
+
+			if tt.mockReturnsGetDeletedCommentByID.comment != nil {
+				ap.EXPECT().CanModifyComment(user, tt.mockReturnsGetDeletedCommentByID.comment).Return(tt.mockReturnsCanModifyComment.err)
+
+				if tt.mockReturnsCanModifyComment.err == nil {
+					cr.EXPECT().RestoreComment(tt.ctx, tx, model.CommentValidIDForTest).Return(tt.mockReturnsRestoreComment.err)
+				}
+			}
+
+			if !tt.wantErr {
+				p.EXPECT().Publish(gomock.Any(), &event.CommentRestored{Comment: tt.want})
+			}
+
+			a := &commentService{
+				m:       m,
+				service: mock_service.NewMockCommentService(ctrl),
+				repo:    cr,
+				policy:  ap,
+				events:  p,
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+				now: testutil.TimeNow,
+			}
+
+			got, err := a.RestoreComment(tt.ctx, model.CommentValidIDForTest)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("commentService.RestoreComment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("commentService.RestoreComment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_commentService_ListCommentRevisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testutil.SetFakeTime(time.Now())
+
+	user := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+	}
+
+	revisions := []*model.CommentRevision{
+		{
+			ID:        model.CommentRevisionValidIDForTest,
+			CommentID: model.CommentValidIDForTest,
+			Content:   "beforeUpdate",
+			EditedBy:  user,
+			CreatedAt: testutil.TimeNow(),
+			EditedAt:  testutil.TimeNow(),
+		},
+	}
+
+	type mockReturnsGetCommentByID struct {
+		err error
+	}
+
+	type mockReturnsListCommentRevisions struct {
+		revisions []*model.CommentRevision
+		err       error
+	}
+
+	tests := []struct {
+		name string
+		mockReturnsGetCommentByID
+		mockReturnsListCommentRevisions
+		want    *model.CommentRevisionList
+		wantErr bool
+	}{
+		{
+			name: "When the comment has been edited, ListCommentRevisions returns its revisions",
+			mockReturnsListCommentRevisions: mockReturnsListCommentRevisions{
+				revisions: revisions,
+			},
+			want:    &model.CommentRevisionList{Revisions: revisions},
+			wantErr: false,
+		},
+		{
+			name: "When the comment does not exist, ListCommentRevisions returns error",
+			mockReturnsGetCommentByID: mockReturnsGetCommentByID{
+				err: &model.NoSuchDataError{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "When some error occurs at repository layer, ListCommentRevisions returns error",
+			mockReturnsListCommentRevisions: mockReturnsListCommentRevisions{
+				err: errors.New(model.ErrorMessageForTest),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			m := mock_query.NewMockDBManager(ctrl)
+			cr := mock_repository.NewMockCommentRepository(ctrl)
+			rr := mock_repository.NewMockCommentRevisionRepository(ctrl)
+
+			cr.EXPECT().GetCommentByID(ctx, m, model.CommentValidIDForTest).Return(&model.Comment{ID: model.CommentValidIDForTest}, tt.mockReturnsGetCommentByID.err)
+
+			if tt.mockReturnsGetCommentByID.err == nil {
+				rr.EXPECT().ListCommentRevisions(ctx, m, model.CommentValidIDForTest).Return(tt.mockReturnsListCommentRevisions.revisions, tt.mockReturnsListCommentRevisions.err)
+			}
+
+			a := &commentService{
+				m:         m,
+				repo:      cr,
+				revisions: rr,
+			}
+
+			got, err := a.ListCommentRevisions(ctx, model.CommentValidIDForTest)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("commentService.ListCommentRevisions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("commentService.ListCommentRevisions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+)
+
+// ContentPurger hard-deletes the soft-deleted threads and comments after the retention period in the background.
+type ContentPurger interface {
+	Run(ctx context.Context)
+	Purge(ctx context.Context) (int64, error)
+}
+
+// contentPurger is the implementation of ContentPurger.
+type contentPurger struct {
+	m         query.DBManager
+	threads   repository.ThreadRepository
+	comments  repository.CommentRepository
+	retention time.Duration
+	interval  time.Duration
+	batchSize int
+	now       func() time.Time
+}
+
+// NewContentPurger generates and returns ContentPurger.
+func NewContentPurger(m query.DBManager, threads repository.ThreadRepository, comments repository.CommentRepository, retention, interval time.Duration, batchSize int) ContentPurger {
+	return &contentPurger{
+		m:         m,
+		threads:   threads,
+		comments:  comments,
+		retention: retention,
+		interval:  interval,
+		batchSize: batchSize,
+		now:       time.Now,
+	}
+}
+
+// Run purges the deleted contents at every interval until ctx is done.
+func (p *contentPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := p.Purge(ctx)
+			if err != nil {
+				logger.Logger.Error("failed to purge deleted contents", zap.String("error message", err.Error()))
+				continue
+			}
+			if purged > 0 {
+				logger.Logger.Info("purged deleted contents", zap.Int64("purged", purged))
+			}
+		}
+	}
+}
+
+// Purge hard-deletes the comments and the threads which have been deleted before the retention period in batches,
+// and returns the number of purged records. The comments of the purged threads are deleted by the foreign key,
+// and they are not counted.
+func (p *contentPurger) Purge(ctx context.Context) (int64, error) {
+	deletedBefore := p.now().Add(-p.retention)
+
+	comments, err := deleteInBatches(ctx, p.batchSize, func(ctx context.Context, limit int) (int64, error) {
+		return p.comments.PurgeDeletedComments(ctx, p.m, deletedBefore, limit)
+	})
+	if err != nil {
+		return comments, errors.Wrap(err, "failed to purge deleted comments")
+	}
+
+	threads, err := deleteInBatches(ctx, p.batchSize, func(ctx context.Context, limit int) (int64, error) {
+		return p.threads.PurgeDeletedThreads(ctx, p.m, deletedBefore, limit)
+	})
+	if err != nil {
+		return comments + threads, errors.Wrap(err, "failed to purge deleted threads")
+	}
+
+	return comments + threads, nil
+}
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	mock_repository "github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository/mock"
+	mock_query "github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/testutil"
+)
+
+func Test_contentPurger_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testutil.SetFakeTime(time.Now())
+
+	retention := 30 * 24 * time.Hour
+	deletedBefore := testutil.TimeNow().Add(-retention)
+
+	tests := []struct {
+		name     string
+		comments []batchResult
+		threads  []batchResult
+		want     int64
+		wantErr  bool
+	}{
+		{
+			name:     "When deleted contents are fewer than batch size, Purge purges them at once",
+			comments: []batchResult{{deleted: 3}},
+			threads:  []batchResult{{deleted: 2}},
+			want:     5,
+			wantErr:  false,
+		},
+		{
+			name:     "When deleted contents are more than batch size, Purge purges them in batches",
+			comments: []batchResult{{deleted: 10}, {deleted: 10}, {deleted: 1}},
+			threads:  []batchResult{{deleted: 10}, {deleted: 0}},
+			want:     31,
+			wantErr:  false,
+		},
+		{
+			name:     "When some error occurs at purging comments, Purge returns error without purging threads",
+			comments: []batchResult{{deleted: 10}, {err: errors.New(model.ErrorMessageForTest)}},
+			want:     10,
+			wantErr:  true,
+		},
+		{
+			name:     "When some error occurs at purging threads, Purge returns the number purged so far and error",
+			comments: []batchResult{{deleted: 4}},
+			threads:  []batchResult{{err: errors.New(model.ErrorMessageForTest)}},
+			want:     4,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mock_query.NewMockDBManager(ctrl)
+			tr := mock_repository.NewMockThreadRepository(ctrl)
+			cr := mock_repository.NewMockCommentRepository(ctrl)
+
+			calls := expectBatches(func() *gomock.Call {
+				return cr.EXPECT().PurgeDeletedComments(gomock.Any(), m, deletedBefore, 10)
+			}, tt.comments...)
+			calls = append(calls, expectBatches(func() *gomock.Call {
+				return tr.EXPECT().PurgeDeletedThreads(gomock.Any(), m, deletedBefore, 10)
+			}, tt.threads...)...)
+			gomock.InOrder(calls...)
+
+			p := &contentPurger{
+				m:         m,
+				threads:   tr,
+				comments:  cr,
+				retention: retention,
+				interval:  time.Minute,
+				batchSize: 10,
+				now:       testutil.TimeNow,
+			}
+
+			got, err := p.Purge(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("contentPurger.Purge() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("contentPurger.Purge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
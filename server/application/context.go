@@ -0,0 +1,41 @@
+package application
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+)
+
+// userFromContext returns the user who requested.
+func userFromContext(ctx context.Context) (*model.User, error) {
+	user, ok := model.UserFromContext(ctx)
+	if !ok {
+		return nil, errors.WithStack(&model.AuthenticationErr{})
+	}
+
+	return user, nil
+}
+
+// authorFromContext returns the user who requested as the author of threads and comments.
+// The author is never taken from the request body, so that users can not post as others.
+func authorFromContext(ctx context.Context) (*model.User, error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.User{
+		ID:        user.ID,
+		Name:      user.Name,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}, nil
+}
+
+// setClient records the client which requested into the session.
+func setClient(ctx context.Context, session *model.Session) {
+	if client, ok := model.ClientFromContext(ctx); ok {
+		session.SetClient(client)
+	}
+}
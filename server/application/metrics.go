@@ -0,0 +1,41 @@
+package application
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/metrics"
+)
+
+// authenticationServiceMetrics is the decorator of AuthenticationService which counts sign ups and logins.
+type authenticationServiceMetrics struct {
+	AuthenticationService
+}
+
+// NewAuthenticationServiceWithMetrics generates and returns AuthenticationService which counts sign ups and logins of s.
+func NewAuthenticationServiceWithMetrics(s AuthenticationService) AuthenticationService {
+	return &authenticationServiceMetrics{
+		AuthenticationService: s,
+	}
+}
+
+// SignUp sign up an user, and counts it when it has succeeded.
+func (s *authenticationServiceMetrics) SignUp(ctx context.Context, param *model.User) (*model.User, error) {
+	user, err := s.AuthenticationService.SignUp(ctx, param)
+	if err == nil {
+		metrics.SignUps.Inc()
+	}
+	return user, err
+}
+
+// Login Login an user, and counts it when it has succeeded or failed because of the invalid name or password.
+func (s *authenticationServiceMetrics) Login(ctx context.Context, param *model.User) (*model.User, error) {
+	user, err := s.AuthenticationService.Login(ctx, param)
+	if err == nil {
+		metrics.Logins.Inc()
+	} else if _, ok := errors.Cause(err).(*model.AuthenticationErr); ok {
+		metrics.LoginFailures.Inc()
+	}
+	return user, err
+}
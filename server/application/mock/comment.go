@@ -107,3 +107,33 @@ func (mr *MockCommentServiceMockRecorder) DeleteComment(ctx, id interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockCommentService)(nil).DeleteComment), ctx, id)
 }
+
+// RestoreComment mocks base method
+func (m *MockCommentService) RestoreComment(ctx context.Context, id uint32) (*model.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreComment", ctx, id)
+	ret0, _ := ret[0].(*model.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreComment indicates an expected call of RestoreComment
+func (mr *MockCommentServiceMockRecorder) RestoreComment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreComment", reflect.TypeOf((*MockCommentService)(nil).RestoreComment), ctx, id)
+}
+
+// ListCommentRevisions mocks base method
+func (m *MockCommentService) ListCommentRevisions(ctx context.Context, id uint32) (*model.CommentRevisionList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCommentRevisions", ctx, id)
+	ret0, _ := ret[0].(*model.CommentRevisionList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCommentRevisions indicates an expected call of ListCommentRevisions
+func (mr *MockCommentServiceMockRecorder) ListCommentRevisions(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCommentRevisions", reflect.TypeOf((*MockCommentService)(nil).ListCommentRevisions), ctx, id)
+}
@@ -107,3 +107,18 @@ func (mr *MockThreadServiceMockRecorder) DeleteThread(ctx, id interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteThread", reflect.TypeOf((*MockThreadService)(nil).DeleteThread), ctx, id)
 }
+
+// RestoreThread mocks base method
+func (m *MockThreadService) RestoreThread(ctx context.Context, id uint32) (*model.Thread, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreThread", ctx, id)
+	ret0, _ := ret[0].(*model.Thread)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreThread indicates an expected call of RestoreThread
+func (mr *MockThreadServiceMockRecorder) RestoreThread(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreThread", reflect.TypeOf((*MockThreadService)(nil).RestoreThread), ctx, id)
+}
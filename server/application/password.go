@@ -0,0 +1,285 @@
+package application
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/service"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/util"
+	"go.uber.org/zap"
+)
+
+// PasswordService is interface of PasswordService.
+type PasswordService interface {
+	ChangePassword(ctx context.Context, oldPassword, newPassword string) error
+	RequestPasswordReset(ctx context.Context, userID uint32) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+}
+
+// PasswordServiceDIInput is DI Input of PasswordService.
+type PasswordServiceDIInput struct {
+	userRepository      repository.UserRepository
+	sessionRepository   repository.SessionRepository
+	tokenRepository     repository.PasswordResetTokenRepository
+	apiTokenRepository  repository.APITokenRepository
+	userService         service.UserService
+	loginAttemptService service.LoginAttemptService
+	notifier            service.Notifier
+	tokenTTL            time.Duration
+}
+
+// NewPasswordServiceDIInput generates and returns PasswordServiceDIInput.
+func NewPasswordServiceDIInput(uRepo repository.UserRepository, sRepo repository.SessionRepository, tRepo repository.PasswordResetTokenRepository, atRepo repository.APITokenRepository, uService service.UserService, laService service.LoginAttemptService, notifier service.Notifier, tokenTTL time.Duration) *PasswordServiceDIInput {
+	return &PasswordServiceDIInput{
+		userRepository:      uRepo,
+		sessionRepository:   sRepo,
+		tokenRepository:     tRepo,
+		apiTokenRepository:  atRepo,
+		userService:         uService,
+		loginAttemptService: laService,
+		notifier:            notifier,
+		tokenTTL:            tokenTTL,
+	}
+}
+
+// passwordService is application service of password.
+type passwordService struct {
+	m                   query.DBManager
+	userRepository      repository.UserRepository
+	sessionRepository   repository.SessionRepository
+	tokenRepository     repository.PasswordResetTokenRepository
+	apiTokenRepository  repository.APITokenRepository
+	userService         service.UserService
+	loginAttemptService service.LoginAttemptService
+	notifier            service.Notifier
+	tokenTTL            time.Duration
+	txCloser            CloseTransaction
+	now                 func() time.Time
+}
+
+// NewPasswordService generates and returns PasswordService.
+func NewPasswordService(m query.DBManager, diInput *PasswordServiceDIInput, txCloser CloseTransaction) PasswordService {
+	return &passwordService{
+		m:                   m,
+		userRepository:      diInput.userRepository,
+		sessionRepository:   diInput.sessionRepository,
+		tokenRepository:     diInput.tokenRepository,
+		apiTokenRepository:  diInput.apiTokenRepository,
+		userService:         diInput.userService,
+		loginAttemptService: diInput.loginAttemptService,
+		notifier:            diInput.notifier,
+		tokenTTL:            diInput.tokenTTL,
+		txCloser:            txCloser,
+		now:                 time.Now,
+	}
+}
+
+// ChangePassword changes the password of the user who requested,
+// and revokes the other sessions of the user.
+// When the user is authenticated by API token, all sessions are revoked.
+// The attempts are refused with TooManyAttemptsError after failures of the old password, as Login is.
+func (a *passwordService) ChangePassword(ctx context.Context, oldPassword, newPassword string) (err error) {
+	requester, err := userFromContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user")
+	}
+
+	var ip string
+	if client, ok := model.ClientFromContext(ctx); ok {
+		ip = client.IP
+	}
+
+	key := passwordAttemptKey(requester.ID)
+	if err := a.loginAttemptService.Check(ctx, a.m, key, ip); err != nil {
+		return errors.Wrap(err, "failed to check password attempts")
+	}
+
+	tx, err := a.m.Begin()
+	if err != nil {
+		return beginTxErrorMsg(err)
+	}
+
+	defer func() {
+		if closeErr := a.txCloser(tx, err); closeErr != nil {
+			err = errors.Wrap(closeErr, "failed to close tx")
+		}
+	}()
+
+	user, err := a.userRepository.GetUserByID(ctx, tx, requester.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user by id")
+	}
+
+	ok, err := a.userService.VerifyPassword(user, oldPassword)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify password")
+	}
+
+	if !ok {
+		// it is recorded outside of tx, so that it is not rolled back with the change.
+		if err := a.loginAttemptService.RecordFailure(ctx, a.m, key, ip); err != nil {
+			logger.FromContext(ctx).Error("failed to record password failure", zap.String("error message", err.Error()))
+		}
+
+		err = &model.InvalidParamError{
+			PropertyName:  model.PassWordProperty,
+			InvalidReason: "old password is wrong",
+		}
+		return errors.WithStack(err)
+	}
+
+	if err := a.loginAttemptService.RecordSuccess(ctx, a.m, key); err != nil {
+		logger.FromContext(ctx).Warn("failed to record password success", zap.String("error message", err.Error()))
+	}
+
+	if err := a.updatePassword(ctx, tx, user, newPassword); err != nil {
+		return err
+	}
+
+	if session, ok := model.SessionFromContext(ctx); ok {
+		if _, err := a.sessionRepository.DeleteOtherSessions(ctx, tx, user.ID, session.ID); err != nil {
+			return errors.Wrap(err, "failed to delete other sessions")
+		}
+		return nil
+	}
+
+	if _, err := a.sessionRepository.DeleteSessionsByUserID(ctx, tx, user.ID); err != nil {
+		return errors.Wrap(err, "failed to delete sessions")
+	}
+
+	return nil
+}
+
+// RequestPasswordReset issues the password reset token of the user, and delivers it through the notifier.
+func (a *passwordService) RequestPasswordReset(ctx context.Context, userID uint32) (err error) {
+	tx, err := a.m.Begin()
+	if err != nil {
+		return beginTxErrorMsg(err)
+	}
+
+	defer func() {
+		if closeErr := a.txCloser(tx, err); closeErr != nil {
+			err = errors.Wrap(closeErr, "failed to close tx")
+		}
+	}()
+
+	user, err := a.userRepository.GetUserByID(ctx, tx, userID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user by id")
+	}
+
+	token, err := util.RandomToken()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate token")
+	}
+
+	now := a.now()
+	resetToken := &model.PasswordResetToken{
+		TokenHash: util.HashToken(token),
+		UserID:    user.ID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(a.tokenTTL),
+	}
+
+	if err := a.tokenRepository.InsertPasswordResetToken(ctx, tx, resetToken); err != nil {
+		return errors.Wrap(err, "failed to insert password reset token")
+	}
+
+	// notify in tx, so that the token is not stored if it can not be delivered.
+	if err := a.notifier.NotifyPasswordReset(ctx, user, token, resetToken.ExpiresAt); err != nil {
+		return errors.Wrap(err, "failed to notify password reset")
+	}
+
+	return nil
+}
+
+// ResetPassword redeems the password reset token, changes the password,
+// and revokes all sessions and API tokens of the user.
+func (a *passwordService) ResetPassword(ctx context.Context, token, newPassword string) (err error) {
+	tx, err := a.m.Begin()
+	if err != nil {
+		return beginTxErrorMsg(err)
+	}
+
+	defer func() {
+		if closeErr := a.txCloser(tx, err); closeErr != nil {
+			err = errors.Wrap(closeErr, "failed to close tx")
+		}
+	}()
+
+	tokenHash := util.HashToken(token)
+	now := a.now()
+
+	resetToken, err := a.tokenRepository.GetPasswordResetTokenByHash(ctx, tx, tokenHash)
+	if err != nil {
+		if _, ok := errors.Cause(err).(*model.NoSuchDataError); ok {
+			return invalidTokenError()
+		}
+		return errors.Wrap(err, "failed to get password reset token")
+	}
+
+	if !resetToken.IsRedeemable(now) {
+		return invalidTokenError()
+	}
+
+	if err := a.tokenRepository.UsePasswordResetToken(ctx, tx, tokenHash, now); err != nil {
+		if _, ok := errors.Cause(err).(*model.NoSuchDataError); ok {
+			return invalidTokenError()
+		}
+		return errors.Wrap(err, "failed to use password reset token")
+	}
+
+	user, err := a.userRepository.GetUserByID(ctx, tx, resetToken.UserID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user by id")
+	}
+
+	if err := a.updatePassword(ctx, tx, user, newPassword); err != nil {
+		return err
+	}
+
+	if _, err := a.sessionRepository.DeleteSessionsByUserID(ctx, tx, user.ID); err != nil {
+		return errors.Wrap(err, "failed to delete sessions")
+	}
+
+	// the reset is used when the password may have leaked, so the tokens minted by others are revoked too.
+	if _, err := a.apiTokenRepository.DeleteAPITokensByUserID(ctx, tx, user.ID); err != nil {
+		return errors.Wrap(err, "failed to delete api tokens")
+	}
+
+	return nil
+}
+
+// updatePassword validates, hashes and updates the password of the user.
+func (a *passwordService) updatePassword(ctx context.Context, m query.SQLManager, user *model.User, password string) error {
+	if err := a.userService.SetPassword(user, password); err != nil {
+		return errors.Wrap(err, "failed to set password")
+	}
+
+	if err := a.userRepository.UpdateUser(ctx, m, user.ID, user); err != nil {
+		return errors.Wrap(err, "failed to update user")
+	}
+
+	return nil
+}
+
+// passwordAttemptKey returns the key of the attempts to change the password of the user.
+// It is keyed by the user ID, which can not be a user name because the names can not contain ':'.
+func passwordAttemptKey(userID uint32) string {
+	return "id:" + strconv.FormatUint(uint64(userID), 10)
+}
+
+// invalidTokenError returns the error of the token which can not be redeemed.
+// The reason is not distinguished, so that the state of tokens is not revealed.
+func invalidTokenError() error {
+	return errors.WithStack(&model.InvalidParamError{
+		PropertyName:  model.TokenProperty,
+		InvalidReason: "token is invalid or has expired",
+	})
+}
@@ -0,0 +1,388 @@
+package application
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	mock_repository "github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/service"
+	mock_service "github.com/sekky0905/nuxt-vue-go-chat/server/domain/service/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	mock_query "github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/testutil"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/util"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// passwordHasherForTest is PasswordHasher which is fast for test.
+var passwordHasherForTest = util.NewBcryptHasher(bcrypt.MinCost)
+
+// passwordServiceMocks is the mocks which passwordService depends on.
+type passwordServiceMocks struct {
+	m        *mock_query.MockDBManager
+	uRepo    *mock_repository.MockUserRepository
+	sRepo    *mock_repository.MockSessionRepository
+	tRepo    *mock_repository.MockPasswordResetTokenRepository
+	atRepo   *mock_repository.MockAPITokenRepository
+	la       *mock_service.MockLoginAttemptService
+	notifier *mock_service.MockNotifier
+}
+
+// newPasswordServiceForTest generates passwordService which depends on mocks.
+func newPasswordServiceForTest(ctrl *gomock.Controller) (*passwordService, *passwordServiceMocks) {
+	mocks := &passwordServiceMocks{
+		m:        mock_query.NewMockDBManager(ctrl),
+		uRepo:    mock_repository.NewMockUserRepository(ctrl),
+		sRepo:    mock_repository.NewMockSessionRepository(ctrl),
+		tRepo:    mock_repository.NewMockPasswordResetTokenRepository(ctrl),
+		atRepo:   mock_repository.NewMockAPITokenRepository(ctrl),
+		la:       mock_service.NewMockLoginAttemptService(ctrl),
+		notifier: mock_service.NewMockNotifier(ctrl),
+	}
+
+	a := &passwordService{
+		m:                   mocks.m,
+		userRepository:      mocks.uRepo,
+		sessionRepository:   mocks.sRepo,
+		tokenRepository:     mocks.tRepo,
+		apiTokenRepository:  mocks.atRepo,
+		userService:         service.NewUserService(mocks.m, mocks.uRepo, model.DefaultUserNamePolicy(), model.DefaultPasswordPolicy(), passwordHasherForTest),
+		loginAttemptService: mocks.la,
+		notifier:            mocks.notifier,
+		tokenTTL:            model.DefaultPasswordResetTokenTTL,
+		txCloser: func(tx query.TxManager, err error) error {
+			return nil
+		},
+		now: testutil.TimeNow,
+	}
+
+	return a, mocks
+}
+
+func Test_passwordService_ChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hashed, err := passwordHasherForTest.Hash(model.PasswordForTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := &model.User{
+		ID:       model.UserValidIDForTest,
+		Name:     model.UserNameForTest,
+		Password: hashed,
+	}
+	session := &model.Session{
+		ID:     model.SessionValidIDForTest,
+		UserID: model.UserValidIDForTest,
+	}
+	ctx := model.WithSession(model.WithUser(model.WithClient(context.Background(), &model.Client{IP: model.IPForTest}), user), session)
+	// the user who is authenticated by API token has no session.
+	ctxWithoutSession := model.WithUser(model.WithClient(context.Background(), &model.Client{IP: model.IPForTest}), user)
+	key := passwordAttemptKey(user.ID)
+
+	type args struct {
+		ctx         context.Context
+		oldPassword string
+		newPassword string
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		checkErr      error
+		expectFailure bool
+		expectSuccess bool
+		expectUpdate  bool
+		wantErr       bool
+	}{
+		{
+			name: "When the old password is right, ChangePassword updates the password and revokes other sessions",
+			args: args{
+				ctx:         ctx,
+				oldPassword: model.PasswordForTest,
+				newPassword: "newPassword",
+			},
+			expectSuccess: true,
+			expectUpdate:  true,
+			wantErr:       false,
+		},
+		{
+			name: "When the user is authenticated by API token, ChangePassword revokes all sessions",
+			args: args{
+				ctx:         ctxWithoutSession,
+				oldPassword: model.PasswordForTest,
+				newPassword: "newPassword",
+			},
+			expectSuccess: true,
+			expectUpdate:  true,
+			wantErr:       false,
+		},
+		{
+			name: "When the new password violates the policy, ChangePassword returns error",
+			args: args{
+				ctx:         ctx,
+				oldPassword: model.PasswordForTest,
+				newPassword: "short",
+			},
+			expectSuccess: true,
+			expectUpdate:  false,
+			wantErr:       true,
+		},
+		{
+			name: "When the old password is wrong, ChangePassword records the failure and returns error",
+			args: args{
+				ctx:         ctx,
+				oldPassword: "wrongPassword",
+				newPassword: "newPassword",
+			},
+			expectFailure: true,
+			expectUpdate:  false,
+			wantErr:       true,
+		},
+		{
+			name: "When the attempts are too many, ChangePassword returns error without verifying the old password",
+			args: args{
+				ctx:         ctx,
+				oldPassword: model.PasswordForTest,
+				newPassword: "newPassword",
+			},
+			checkErr:     &model.TooManyAttemptsError{RetryAfter: time.Second},
+			expectUpdate: false,
+			wantErr:      true,
+		},
+		{
+			name: "When the user who requested is not in context, ChangePassword returns error",
+			args: args{
+				ctx:         context.Background(),
+				oldPassword: model.PasswordForTest,
+				newPassword: "newPassword",
+			},
+			expectUpdate: false,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, mocks := newPasswordServiceForTest(ctrl)
+
+			if _, ok := model.UserFromContext(tt.args.ctx); ok {
+				mocks.la.EXPECT().Check(tt.args.ctx, mocks.m, key, model.IPForTest).Return(tt.checkErr)
+			}
+
+			if _, ok := model.UserFromContext(tt.args.ctx); ok && tt.checkErr == nil {
+				mocks.m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
+				stored := *user
+				mocks.uRepo.EXPECT().GetUserByID(tt.args.ctx, gomock.Any(), user.ID).Return(&stored, nil)
+			}
+
+			if tt.expectFailure {
+				mocks.la.EXPECT().RecordFailure(tt.args.ctx, mocks.m, key, model.IPForTest).Return(nil)
+			}
+
+			if tt.expectSuccess {
+				mocks.la.EXPECT().RecordSuccess(tt.args.ctx, mocks.m, key).Return(nil)
+			}
+
+			if tt.expectUpdate {
+				mocks.uRepo.EXPECT().UpdateUser(tt.args.ctx, gomock.Any(), user.ID, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, m query.SQLManager, id uint32, u *model.User) error {
+						if ok, _, _ := passwordHasherForTest.Verify(tt.args.newPassword, u.Password); !ok {
+							t.Errorf("password = %s, want hash of %s", u.Password, tt.args.newPassword)
+						}
+						return nil
+					})
+				if _, ok := model.SessionFromContext(tt.args.ctx); ok {
+					mocks.sRepo.EXPECT().DeleteOtherSessions(tt.args.ctx, gomock.Any(), user.ID, session.ID).Return(int64(1), nil)
+				} else {
+					mocks.sRepo.EXPECT().DeleteSessionsByUserID(tt.args.ctx, gomock.Any(), user.ID).Return(int64(1), nil)
+				}
+			}
+
+			err := a.ChangePassword(tt.args.ctx, tt.args.oldPassword, tt.args.newPassword)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("passwordService.ChangePassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_passwordService_RequestPasswordReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testutil.SetFakeTime(time.Now())
+
+	user := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+	}
+	ctx := context.Background()
+
+	a, mocks := newPasswordServiceForTest(ctrl)
+
+	var inserted *model.PasswordResetToken
+	mocks.m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
+	mocks.uRepo.EXPECT().GetUserByID(ctx, gomock.Any(), user.ID).Return(user, nil)
+	mocks.tRepo.EXPECT().InsertPasswordResetToken(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, m query.SQLManager, token *model.PasswordResetToken) error {
+			inserted = token
+			return nil
+		})
+	mocks.notifier.EXPECT().NotifyPasswordReset(ctx, user, gomock.Any(), testutil.TimeNow().Add(model.DefaultPasswordResetTokenTTL)).DoAndReturn(
+		func(ctx context.Context, u *model.User, token string, expiresAt time.Time) error {
+			if inserted == nil {
+				t.Fatal("token has not been inserted before notified")
+			}
+			if inserted.TokenHash == token {
+				t.Error("token is stored without hashed")
+			}
+			if inserted.TokenHash != util.HashToken(token) {
+				t.Errorf("token hash = %s, want %s", inserted.TokenHash, util.HashToken(token))
+			}
+			return nil
+		})
+
+	if err := a.RequestPasswordReset(ctx, user.ID); err != nil {
+		t.Errorf("passwordService.RequestPasswordReset() error = %v", err)
+	}
+
+	want := &model.PasswordResetToken{
+		TokenHash: inserted.TokenHash,
+		UserID:    user.ID,
+		CreatedAt: testutil.TimeNow(),
+		ExpiresAt: testutil.TimeNow().Add(model.DefaultPasswordResetTokenTTL),
+	}
+	if !reflect.DeepEqual(inserted, want) {
+		t.Errorf("inserted = %v, want %v", inserted, want)
+	}
+}
+
+func Test_passwordService_ResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testutil.SetFakeTime(time.Now())
+
+	const token = "resetTokenForTest"
+	tokenHash := util.HashToken(token)
+	ctx := context.Background()
+
+	user := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+	}
+
+	type mockReturnsGetToken struct {
+		token *model.PasswordResetToken
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		mockReturnsGetToken
+		useErr    error
+		expectUse bool
+		wantErr   error
+	}{
+		{
+			name: "When the token is redeemable, ResetPassword updates the password and revokes all sessions",
+			mockReturnsGetToken: mockReturnsGetToken{
+				token: &model.PasswordResetToken{
+					TokenHash: tokenHash,
+					UserID:    user.ID,
+					ExpiresAt: testutil.TimeNow().Add(time.Minute),
+				},
+			},
+			expectUse: true,
+			wantErr:   nil,
+		},
+		{
+			name: "When the token does not exist, ResetPassword returns InvalidParamError",
+			mockReturnsGetToken: mockReturnsGetToken{
+				err: &model.NoSuchDataError{},
+			},
+			wantErr: &model.InvalidParamError{},
+		},
+		{
+			name: "When the token has expired, ResetPassword returns InvalidParamError",
+			mockReturnsGetToken: mockReturnsGetToken{
+				token: &model.PasswordResetToken{
+					TokenHash: tokenHash,
+					UserID:    user.ID,
+					ExpiresAt: testutil.TimeNow().Add(-time.Minute),
+				},
+			},
+			wantErr: &model.InvalidParamError{},
+		},
+		{
+			name: "When the token has been used, ResetPassword returns InvalidParamError",
+			mockReturnsGetToken: mockReturnsGetToken{
+				token: &model.PasswordResetToken{
+					TokenHash: tokenHash,
+					UserID:    user.ID,
+					Used:      true,
+					ExpiresAt: testutil.TimeNow().Add(time.Minute),
+				},
+			},
+			wantErr: &model.InvalidParamError{},
+		},
+		{
+			name: "When the token is used by other request at the same time, ResetPassword returns InvalidParamError",
+			mockReturnsGetToken: mockReturnsGetToken{
+				token: &model.PasswordResetToken{
+					TokenHash: tokenHash,
+					UserID:    user.ID,
+					ExpiresAt: testutil.TimeNow().Add(time.Minute),
+				},
+			},
+			expectUse: true,
+			useErr:    &model.NoSuchDataError{},
+			wantErr:   &model.InvalidParamError{},
+		},
+		{
+			name: "When some error occurs at repository layer, ResetPassword returns error",
+			mockReturnsGetToken: mockReturnsGetToken{
+				err: errors.New(model.ErrorMessageForTest),
+			},
+			wantErr: errors.New(model.ErrorMessageForTest),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, mocks := newPasswordServiceForTest(ctrl)
+
+			mocks.m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
+			mocks.tRepo.EXPECT().GetPasswordResetTokenByHash(ctx, gomock.Any(), tokenHash).Return(tt.mockReturnsGetToken.token, tt.mockReturnsGetToken.err)
+
+			if tt.expectUse {
+				mocks.tRepo.EXPECT().UsePasswordResetToken(ctx, gomock.Any(), tokenHash, testutil.TimeNow()).Return(tt.useErr)
+			}
+
+			if tt.wantErr == nil {
+				mocks.uRepo.EXPECT().GetUserByID(ctx, gomock.Any(), user.ID).Return(user, nil)
+				mocks.uRepo.EXPECT().UpdateUser(ctx, gomock.Any(), user.ID, gomock.Any()).Return(nil)
+				mocks.sRepo.EXPECT().DeleteSessionsByUserID(ctx, gomock.Any(), user.ID).Return(int64(2), nil)
+				mocks.atRepo.EXPECT().DeleteAPITokensByUserID(ctx, gomock.Any(), user.ID).Return(int64(1), nil)
+			}
+
+			err := a.ResetPassword(ctx, token, "newPassword")
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("passwordService.ResetPassword() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if reflect.TypeOf(errors.Cause(err)) != reflect.TypeOf(tt.wantErr) {
+				t.Errorf("passwordService.ResetPassword() error = %#v, wantErr %#v", errors.Cause(err), tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+)
+
+// SessionService is interface of SessionService.
+type SessionService interface {
+	ListSessions(ctx context.Context) ([]*model.Session, error)
+	RevokeSession(ctx context.Context, publicID string) error
+	RevokeAllSessions(ctx context.Context) error
+}
+
+// sessionService is application service of the sessions of the user who requested.
+type sessionService struct {
+	m        query.DBManager
+	repo     repository.SessionRepository
+	expiry   model.SessionExpiry
+	txCloser CloseTransaction
+	now      func() time.Time
+}
+
+// NewSessionService generates and returns SessionService.
+func NewSessionService(m query.DBManager, repo repository.SessionRepository, expiry model.SessionExpiry, txCloser CloseTransaction) SessionService {
+	return &sessionService{
+		m:        m,
+		repo:     repo,
+		expiry:   expiry,
+		txCloser: txCloser,
+		now:      time.Now,
+	}
+}
+
+// ListSessions lists the active sessions of the user who requested.
+func (a *sessionService) ListSessions(ctx context.Context) ([]*model.Session, error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+
+	sessions, err := a.repo.ListSessionsByUserID(ctx, a.m, user.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list sessions")
+	}
+
+	// expired sessions remain until they are reaped, so they are hidden here.
+	now := a.now()
+	active := make([]*model.Session, 0, len(sessions))
+	for _, session := range sessions {
+		if !session.IsExpired(now, a.expiry) {
+			active = append(active, session)
+		}
+	}
+
+	return active, nil
+}
+
+// RevokeSession revokes the session of the user who requested specified by the public id.
+func (a *sessionService) RevokeSession(ctx context.Context, publicID string) (err error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user")
+	}
+
+	tx, err := a.m.Begin()
+	if err != nil {
+		return beginTxErrorMsg(err)
+	}
+
+	defer func() {
+		if closeErr := a.txCloser(tx, err); closeErr != nil {
+			err = errors.Wrap(closeErr, "failed to close tx")
+		}
+	}()
+
+	sessions, err := a.repo.ListSessionsByUserID(ctx, tx, user.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to list sessions")
+	}
+
+	// the sessions of others are not found, so that it is not revealed whether they exist.
+	for _, session := range sessions {
+		if session.PublicID() != publicID {
+			continue
+		}
+
+		if err := a.repo.DeleteSession(ctx, tx, session.ID); err != nil {
+			return errors.Wrap(err, "failed to delete session")
+		}
+		return nil
+	}
+
+	err = &model.NoSuchDataError{
+		PropertyName:    model.IDProperty,
+		PropertyValue:   publicID,
+		DomainModelName: model.DomainModelNameSession,
+	}
+	return errors.WithStack(err)
+}
+
+// RevokeAllSessions revokes all sessions of the user who requested, including the current one.
+func (a *sessionService) RevokeAllSessions(ctx context.Context) error {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user")
+	}
+
+	if _, err := a.repo.DeleteSessionsByUserID(ctx, a.m, user.ID); err != nil {
+		return errors.Wrap(err, "failed to delete sessions")
+	}
+
+	return nil
+}
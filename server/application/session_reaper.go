@@ -0,0 +1,83 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+)
+
+// default settings of SessionReaper.
+const (
+	DefaultSessionReapInterval  = 10 * time.Minute
+	DefaultSessionReapBatchSize = 500
+)
+
+// SessionReaper deletes expired sessions in the background.
+type SessionReaper interface {
+	Run(ctx context.Context)
+	Reap(ctx context.Context) (int64, error)
+}
+
+// sessionReaper is the implementation of SessionReaper.
+type sessionReaper struct {
+	m         query.DBManager
+	repo      repository.SessionRepository
+	expiry    model.SessionExpiry
+	interval  time.Duration
+	batchSize int
+	now       func() time.Time
+}
+
+// NewSessionReaper generates and returns SessionReaper.
+func NewSessionReaper(m query.DBManager, repo repository.SessionRepository, expiry model.SessionExpiry, interval time.Duration, batchSize int) SessionReaper {
+	return &sessionReaper{
+		m:         m,
+		repo:      repo,
+		expiry:    expiry,
+		interval:  interval,
+		batchSize: batchSize,
+		now:       time.Now,
+	}
+}
+
+// Run reaps expired sessions at every interval until ctx is done.
+func (r *sessionReaper) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := r.Reap(ctx)
+			if err != nil {
+				logger.Logger.Error("failed to reap sessions", zap.String("error message", err.Error()))
+				continue
+			}
+			if deleted > 0 {
+				logger.Logger.Info("reaped expired sessions", zap.Int64("deleted", deleted))
+			}
+		}
+	}
+}
+
+// Reap deletes expired sessions in batches, and returns the number of deleted sessions.
+func (r *sessionReaper) Reap(ctx context.Context) (int64, error) {
+	now := r.now()
+
+	deleted, err := deleteInBatches(ctx, r.batchSize, func(ctx context.Context, limit int) (int64, error) {
+		return r.repo.DeleteExpiredSessions(ctx, r.m, now, r.expiry, limit)
+	})
+	if err != nil {
+		return deleted, errors.Wrap(err, "failed to delete expired sessions")
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	mock_repository "github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository/mock"
+	mock_query "github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/testutil"
+)
+
+func Test_sessionReaper_Reap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testutil.SetFakeTime(time.Now())
+
+	tests := []struct {
+		name      string
+		batchSize int
+		results   []batchResult
+		want      int64
+		wantErr   bool
+	}{
+		{
+			name:      "When expired sessions are fewer than batch size, Reap deletes them at once",
+			batchSize: 10,
+			results: []batchResult{
+				{deleted: 3},
+			},
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name:      "When expired sessions are more than batch size, Reap deletes them in batches",
+			batchSize: 10,
+			results: []batchResult{
+				{deleted: 10},
+				{deleted: 10},
+				{deleted: 4},
+			},
+			want:    24,
+			wantErr: false,
+		},
+		{
+			name:      "When some error occurs at repository layer, Reap returns the number deleted so far and error",
+			batchSize: 10,
+			results: []batchResult{
+				{deleted: 10},
+				{err: errors.New(model.ErrorMessageForTest)},
+			},
+			want:    10,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mock_query.NewMockDBManager(ctrl)
+			sr := mock_repository.NewMockSessionRepository(ctrl)
+			expiry := model.DefaultSessionExpiry()
+
+			gomock.InOrder(expectBatches(func() *gomock.Call {
+				return sr.EXPECT().DeleteExpiredSessions(gomock.Any(), m, testutil.TimeNow(), expiry, tt.batchSize)
+			}, tt.results...)...)
+
+			r := &sessionReaper{
+				m:         m,
+				repo:      sr,
+				expiry:    expiry,
+				interval:  time.Minute,
+				batchSize: tt.batchSize,
+				now:       testutil.TimeNow,
+			}
+
+			got, err := r.Reap(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("sessionReaper.Reap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("sessionReaper.Reap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
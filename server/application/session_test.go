@@ -0,0 +1,245 @@
+package application
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	mock_repository "github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	mock_query "github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/testutil"
+)
+
+func Test_sessionService_ListSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testutil.SetFakeTime(time.Now())
+
+	user := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+	}
+	ctx := model.WithUser(context.Background(), user)
+
+	expiry := model.DefaultSessionExpiry()
+	active := &model.Session{
+		ID:             model.SessionValidIDForTest,
+		UserID:         model.UserValidIDForTest,
+		ExpiresAt:      testutil.TimeNow().Add(time.Hour),
+		LastAccessedAt: testutil.TimeNow(),
+	}
+	expired := &model.Session{
+		ID:             model.SessionInValidIDForTest,
+		UserID:         model.UserValidIDForTest,
+		ExpiresAt:      testutil.TimeNow().Add(-time.Hour),
+		LastAccessedAt: testutil.TimeNow(),
+	}
+
+	type mockReturns struct {
+		sessions []*model.Session
+		err      error
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		mockReturns
+		want    []*model.Session
+		wantErr bool
+	}{
+		{
+			name: "When the user has active and expired sessions, ListSessions returns only active sessions",
+			ctx:  ctx,
+			mockReturns: mockReturns{
+				sessions: []*model.Session{active, expired},
+			},
+			want:    []*model.Session{active},
+			wantErr: false,
+		},
+		{
+			name: "When some error occurs at repository layer, ListSessions returns error",
+			ctx:  ctx,
+			mockReturns: mockReturns{
+				err: errors.New(model.ErrorMessageForTest),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "When the user who requested is not in context, ListSessions returns error",
+			ctx:     context.Background(),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mock_query.NewMockDBManager(ctrl)
+			sr := mock_repository.NewMockSessionRepository(ctrl)
+
+			if _, ok := model.UserFromContext(tt.ctx); ok {
+				sr.EXPECT().ListSessionsByUserID(tt.ctx, m, user.ID).Return(tt.mockReturns.sessions, tt.mockReturns.err)
+			}
+
+			a := &sessionService{
+				m:      m,
+				repo:   sr,
+				expiry: expiry,
+				now:    testutil.TimeNow,
+			}
+
+			got, err := a.ListSessions(tt.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("sessionService.ListSessions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sessionService.ListSessions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sessionService_RevokeSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+	}
+	ctx := model.WithUser(context.Background(), user)
+
+	session := &model.Session{
+		ID:     model.SessionValidIDForTest,
+		UserID: model.UserValidIDForTest,
+	}
+
+	type mockReturnsDeleteSession struct {
+		err error
+	}
+
+	tests := []struct {
+		name     string
+		publicID string
+		mockReturnsDeleteSession
+		expectDelete bool
+		wantErr      error
+	}{
+		{
+			name:         "When the public id of own session is given, RevokeSession deletes it",
+			publicID:     session.PublicID(),
+			expectDelete: true,
+			wantErr:      nil,
+		},
+		{
+			name:         "When the public id which is not own session is given, RevokeSession returns NoSuchDataError",
+			publicID:     (&model.Session{ID: model.SessionInValidIDForTest}).PublicID(),
+			expectDelete: false,
+			wantErr:      &model.NoSuchDataError{},
+		},
+		{
+			name:     "When some error occurs at repository layer, RevokeSession returns error",
+			publicID: session.PublicID(),
+			mockReturnsDeleteSession: mockReturnsDeleteSession{
+				err: errors.New(model.ErrorMessageForTest),
+			},
+			expectDelete: true,
+			wantErr:      errors.New(model.ErrorMessageForTest),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mock_query.NewMockDBManager(ctrl)
+			sr := mock_repository.NewMockSessionRepository(ctrl)
+
+			m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
+			sr.EXPECT().ListSessionsByUserID(ctx, gomock.Any(), user.ID).Return([]*model.Session{session}, nil)
+			if tt.expectDelete {
+				sr.EXPECT().DeleteSession(ctx, gomock.Any(), session.ID).Return(tt.mockReturnsDeleteSession.err)
+			}
+
+			a := &sessionService{
+				m:      m,
+				repo:   sr,
+				expiry: model.DefaultSessionExpiry(),
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+				now: testutil.TimeNow,
+			}
+
+			err := a.RevokeSession(ctx, tt.publicID)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("sessionService.RevokeSession() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if reflect.TypeOf(errors.Cause(err)) != reflect.TypeOf(tt.wantErr) {
+				t.Errorf("sessionService.RevokeSession() error = %#v, wantErr %#v", errors.Cause(err), tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_sessionService_RevokeAllSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+	}
+	ctx := model.WithUser(context.Background(), user)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		err     error
+		wantErr bool
+	}{
+		{
+			name:    "When the user is in context, RevokeAllSessions deletes all sessions of the user",
+			ctx:     ctx,
+			wantErr: false,
+		},
+		{
+			name:    "When some error occurs at repository layer, RevokeAllSessions returns error",
+			ctx:     ctx,
+			err:     errors.New(model.ErrorMessageForTest),
+			wantErr: true,
+		},
+		{
+			name:    "When the user who requested is not in context, RevokeAllSessions returns error",
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mock_query.NewMockDBManager(ctrl)
+			sr := mock_repository.NewMockSessionRepository(ctrl)
+
+			if _, ok := model.UserFromContext(tt.ctx); ok {
+				sr.EXPECT().DeleteSessionsByUserID(tt.ctx, m, user.ID).Return(int64(2), tt.err)
+			}
+
+			a := &sessionService{
+				m:    m,
+				repo: sr,
+			}
+
+			if err := a.RevokeAllSessions(tt.ctx); (err != nil) != tt.wantErr {
+				t.Errorf("sessionService.RevokeAllSessions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package application
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/event"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/service"
@@ -17,6 +19,7 @@ type ThreadService interface {
 	CreateThread(ctx context.Context, thread *model.Thread) (*model.Thread, error)
 	UpdateThread(ctx context.Context, id uint32, thread *model.Thread) (*model.Thread, error)
 	DeleteThread(ctx context.Context, id uint32) error
+	RestoreThread(ctx context.Context, id uint32) (*model.Thread, error)
 }
 
 // threadService is application service of thread.
@@ -24,16 +27,24 @@ type threadService struct {
 	m        query.DBManager
 	service  service.ThreadService
 	repo     repository.ThreadRepository
+	comments repository.CommentRepository
+	policy   service.AuthorizationPolicy
+	events   event.Publisher
 	txCloser CloseTransaction
+	now      func() time.Time
 }
 
 // NewThreadService generates and returns ThreadService.
-func NewThreadService(m query.DBManager, service service.ThreadService, repo repository.ThreadRepository, txCloser CloseTransaction) ThreadService {
+func NewThreadService(m query.DBManager, service service.ThreadService, repo repository.ThreadRepository, comments repository.CommentRepository, policy service.AuthorizationPolicy, events event.Publisher, txCloser CloseTransaction) ThreadService {
 	return &threadService{
 		m:        m,
 		service:  service,
 		repo:     repo,
+		comments: comments,
+		policy:   policy,
+		events:   events,
 		txCloser: txCloser,
+		now:      time.Now,
 	}
 }
 
@@ -59,14 +70,28 @@ func (a *threadService) GetThread(ctx context.Context, id uint32) (*model.Thread
 
 // CreateThread creates Thread.
 func (a *threadService) CreateThread(ctx context.Context, param *model.Thread) (thread *model.Thread, err error) {
+	author, err := authorFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get author")
+	}
+	param.User = author
+
 	tx, err := a.m.Begin()
 	if err != nil {
 		return nil, beginTxErrorMsg(err)
 	}
 
+	// publish events after tx has been committed.
+	defer func() {
+		if err == nil {
+			publishEvents(ctx, a.events, &event.ThreadCreated{Thread: thread})
+		}
+	}()
+
 	defer func() {
-		if err := a.txCloser(tx, err); err != nil {
-			err = errors.Wrap(err, "failed to close tx")
+		if closeErr := a.txCloser(tx, err); closeErr != nil {
+			thread = nil
+			err = errors.Wrap(closeErr, "failed to close tx")
 		}
 	}()
 
@@ -95,67 +120,142 @@ func (a *threadService) CreateThread(ctx context.Context, param *model.Thread) (
 // UpdateThread updates Thread.
 func (a *threadService) UpdateThread(ctx context.Context, id uint32, param *model.Thread) (thread *model.Thread, err error) {
 	copiedThread := *param
+
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+
 	tx, err := a.m.Begin()
 	if err != nil {
 		return nil, beginTxErrorMsg(err)
 	}
 
+	// publish events after tx has been committed.
 	defer func() {
-		if err := a.txCloser(tx, err); err != nil {
-			err = errors.Wrap(err, "failed to close tx")
+		if err == nil {
+			publishEvents(ctx, a.events, &event.ThreadUpdated{Thread: thread})
 		}
 	}()
 
-	yes, err := a.service.IsAlreadyExistID(ctx, tx, copiedThread.ID)
-	if !yes {
-		err = &model.NoSuchDataError{
-			PropertyName:    model.IDProperty,
-			PropertyValue:   param.ID,
-			DomainModelName: model.DomainModelNameThread,
+	defer func() {
+		if closeErr := a.txCloser(tx, err); closeErr != nil {
+			thread = nil
+			err = errors.Wrap(closeErr, "failed to close tx")
 		}
-		return nil, errors.Wrap(err, "does not exists ID")
-	}
+	}()
 
+	current, err := a.repo.GetThreadByID(ctx, tx, copiedThread.ID)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to is already exist ID")
+		return nil, errors.Wrap(err, "failed to get thread by id")
+	}
+
+	if err := a.policy.CanModifyThread(user, current); err != nil {
+		return nil, errors.Wrap(err, "failed to authorize")
 	}
 
-	if err := a.repo.UpdateThread(ctx, tx, copiedThread.ID, &copiedThread); err != nil {
+	// only title is changed, and the author is kept.
+	updated := *current
+	updated.Title = copiedThread.Title
+
+	if err := a.repo.UpdateThread(ctx, tx, updated.ID, &updated); err != nil {
 		return nil, errors.Wrap(err, "failed to update thread")
 	}
 
-	return &copiedThread, nil
+	return &updated, nil
 }
 
 // DeleteThread deletes Thread.
 func (a *threadService) DeleteThread(ctx context.Context, id uint32) (err error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user")
+	}
+
 	tx, err := a.m.Begin()
 	if err != nil {
 		return beginTxErrorMsg(err)
 	}
 
+	// publish events after tx has been committed.
 	defer func() {
-		if err := a.txCloser(tx, err); err != nil {
-			err = errors.Wrap(err, "failed to close tx")
+		if err == nil {
+			publishEvents(ctx, a.events, &event.ThreadDeleted{ThreadID: id})
 		}
 	}()
 
-	yes, err := a.service.IsAlreadyExistID(ctx, tx, id)
-	if !yes {
-		err = &model.NoSuchDataError{
-			PropertyName:    model.IDProperty,
-			PropertyValue:   id,
-			DomainModelName: model.DomainModelNameThread,
+	defer func() {
+		if closeErr := a.txCloser(tx, err); closeErr != nil {
+			err = errors.Wrap(closeErr, "failed to close tx")
 		}
-		return errors.Wrap(err, "does not exists id")
-	}
+	}()
+
+	thread, err := a.repo.GetThreadByID(ctx, tx, id)
 	if err != nil {
-		return errors.Wrap(err, "failed to is already exist id")
+		return errors.Wrap(err, "failed to get thread by id")
 	}
 
-	if err := a.repo.DeleteThread(ctx, tx, id); err != nil {
+	if err := a.policy.CanModifyThread(user, thread); err != nil {
+		return errors.Wrap(err, "failed to authorize")
+	}
+
+	// comments are deleted in the same tx at the same time as the thread,
+	// so that no comment is left without its thread, and they are restored with it.
+	deletedAt := a.now()
+	if _, err := a.comments.DeleteCommentsByThreadID(ctx, tx, id, user.ID, deletedAt); err != nil {
+		return errors.Wrap(err, "failed to delete comments of thread")
+	}
+
+	if err := a.repo.DeleteThread(ctx, tx, id, user.ID, deletedAt); err != nil {
 		return errors.Wrap(err, "failed to delete thread")
 	}
 
 	return nil
 }
+
+// RestoreThread restores the deleted Thread with the comments which have been deleted with it.
+func (a *threadService) RestoreThread(ctx context.Context, id uint32) (thread *model.Thread, err error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+
+	tx, err := a.m.Begin()
+	if err != nil {
+		return nil, beginTxErrorMsg(err)
+	}
+
+	// publish events after tx has been committed.
+	defer func() {
+		if err == nil {
+			publishEvents(ctx, a.events, &event.ThreadRestored{Thread: thread})
+		}
+	}()
+
+	defer func() {
+		if closeErr := a.txCloser(tx, err); closeErr != nil {
+			thread = nil
+			err = errors.Wrap(closeErr, "failed to close tx")
+		}
+	}()
+
+	thread, err = a.repo.GetDeletedThreadByID(ctx, tx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get deleted thread by id")
+	}
+
+	if err := a.policy.CanModifyThread(user, thread); err != nil {
+		return nil, errors.Wrap(err, "failed to authorize")
+	}
+
+	// comments are restored first, because they are found by the deletion of the thread.
+	if _, err := a.comments.RestoreCommentsByThreadID(ctx, tx, id); err != nil {
+		return nil, errors.Wrap(err, "failed to restore comments of thread")
+	}
+
+	if err := a.repo.RestoreThread(ctx, tx, id); err != nil {
+		return nil, errors.Wrap(err, "failed to restore thread")
+	}
+
+	return thread, nil
+}
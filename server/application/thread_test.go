@@ -8,6 +8,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/event"
+	mock_event "github.com/sekky0905/nuxt-vue-go-chat/server/domain/event/mock"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
 	mock_repository "github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository/mock"
@@ -243,10 +245,18 @@ func Test_threadService_CreateThread(t *testing.T) {
 
 	testutil.SetFakeTime(time.Now())
 
+	ctx := model.WithUser(context.Background(), &model.User{
+		ID:        model.UserValidIDForTest,
+		Name:      model.UserNameForTest,
+		CreatedAt: testutil.TimeNow(),
+		UpdatedAt: testutil.TimeNow(),
+	})
+
 	type fields struct {
 		m        query.DBManager
 		service  service.ThreadService
 		repo     repository.ThreadRepository
+		events   event.Publisher
 		txCloser CloseTransaction
 	}
 	type args struct {
@@ -292,12 +302,13 @@ func Test_threadService_CreateThread(t *testing.T) {
 				m:       mock_query.NewMockDBManager(ctrl),
 				repo:    mock_repository.NewMockThreadRepository(ctrl),
 				service: mock_service.NewMockThreadService(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
 					return nil
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: ctx,
 				param: &model.Thread{
 					Title: model.TitleForTest,
 					User: &model.User{
@@ -311,7 +322,7 @@ func Test_threadService_CreateThread(t *testing.T) {
 				},
 			},
 			mockArgsIsAlreadyExistTitle: mockArgsIsAlreadyExistTitle{
-				ctx:   context.Background(),
+				ctx:   ctx,
 				title: model.TitleForTest,
 			},
 			mockReturnsIsAlreadyExistTitle: mockReturnsIsAlreadyExistTitle{
@@ -319,7 +330,7 @@ func Test_threadService_CreateThread(t *testing.T) {
 				err:   &model.NoSuchDataError{},
 			},
 			mockArgsInsertThread: mockArgsInsertThread{
-				ctx: context.Background(),
+				ctx: ctx,
 				param: &model.Thread{
 					Title: model.TitleForTest,
 					User: &model.User{
@@ -356,12 +367,13 @@ func Test_threadService_CreateThread(t *testing.T) {
 				m:       mock_query.NewMockDBManager(ctrl),
 				repo:    mock_repository.NewMockThreadRepository(ctrl),
 				service: mock_service.NewMockThreadService(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
 					return nil
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: ctx,
 				param: &model.Thread{
 					Title: model.TitleForTest,
 					User: &model.User{
@@ -375,7 +387,7 @@ func Test_threadService_CreateThread(t *testing.T) {
 				},
 			},
 			mockArgsIsAlreadyExistTitle: mockArgsIsAlreadyExistTitle{
-				ctx:   context.Background(),
+				ctx:   ctx,
 				title: model.TitleForTest,
 			},
 			mockReturnsIsAlreadyExistTitle: mockReturnsIsAlreadyExistTitle{
@@ -383,7 +395,7 @@ func Test_threadService_CreateThread(t *testing.T) {
 				err:   nil,
 			},
 			mockArgsInsertThread: mockArgsInsertThread{
-				ctx:   context.Background(),
+				ctx:   ctx,
 				param: nil,
 			},
 			wantThread: nil,
@@ -395,12 +407,13 @@ func Test_threadService_CreateThread(t *testing.T) {
 				m:       mock_query.NewMockDBManager(ctrl),
 				repo:    mock_repository.NewMockThreadRepository(ctrl),
 				service: mock_service.NewMockThreadService(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
 					return nil
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: ctx,
 				param: &model.Thread{
 					ID:    uint32(model.ThreadValidIDForTest),
 					Title: model.TitleForTest,
@@ -415,7 +428,7 @@ func Test_threadService_CreateThread(t *testing.T) {
 				},
 			},
 			mockArgsIsAlreadyExistTitle: mockArgsIsAlreadyExistTitle{
-				ctx:   context.Background(),
+				ctx:   ctx,
 				title: model.TitleForTest,
 			},
 			mockReturnsIsAlreadyExistTitle: mockReturnsIsAlreadyExistTitle{
@@ -423,7 +436,7 @@ func Test_threadService_CreateThread(t *testing.T) {
 				err:   &model.NoSuchDataError{},
 			},
 			mockArgsInsertThread: mockArgsInsertThread{
-				ctx: context.Background(),
+				ctx: ctx,
 				tx:  mock_query.NewMockDBManager(ctrl),
 				param: &model.Thread{
 					ID:    uint32(model.ThreadValidIDForTest),
@@ -445,6 +458,147 @@ func Test_threadService_CreateThread(t *testing.T) {
 			wantThread: nil,
 			wantErr:    true,
 		},
+		{
+			name: "When tx fails to commit, CreateThread returns nil and error and publishes no event",
+			fields: fields{
+				m:       mock_query.NewMockDBManager(ctrl),
+				repo:    mock_repository.NewMockThreadRepository(ctrl),
+				service: mock_service.NewMockThreadService(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
+				txCloser: func(tx query.TxManager, err error) error {
+					return errors.New(model.ErrorMessageForTest)
+				},
+			},
+			args: args{
+				ctx: ctx,
+				param: &model.Thread{
+					Title: model.TitleForTest,
+					User: &model.User{
+						ID:        model.UserValidIDForTest,
+						Name:      model.UserNameForTest,
+						CreatedAt: testutil.TimeNow(),
+						UpdatedAt: testutil.TimeNow(),
+					},
+					CreatedAt: testutil.TimeNow(),
+					UpdatedAt: testutil.TimeNow(),
+				},
+			},
+			mockArgsIsAlreadyExistTitle: mockArgsIsAlreadyExistTitle{
+				ctx:   ctx,
+				title: model.TitleForTest,
+			},
+			mockReturnsIsAlreadyExistTitle: mockReturnsIsAlreadyExistTitle{
+				found: false,
+				err:   &model.NoSuchDataError{},
+			},
+			mockArgsInsertThread: mockArgsInsertThread{
+				ctx: ctx,
+				param: &model.Thread{
+					Title: model.TitleForTest,
+					User: &model.User{
+						ID:        model.UserValidIDForTest,
+						Name:      model.UserNameForTest,
+						CreatedAt: testutil.TimeNow(),
+						UpdatedAt: testutil.TimeNow(),
+					},
+					CreatedAt: testutil.TimeNow(),
+					UpdatedAt: testutil.TimeNow(),
+				},
+			},
+			mockReturnsInsertThread: mockReturnsInsertThread{
+				id:  model.ThreadValidIDForTest,
+				err: nil,
+			},
+			wantThread: nil,
+			wantErr:    true,
+		},
+		{
+			name: "When user ID of the author is forged, CreateThread creates thread by the user who requested",
+			fields: fields{
+				m:       mock_query.NewMockDBManager(ctrl),
+				repo:    mock_repository.NewMockThreadRepository(ctrl),
+				service: mock_service.NewMockThreadService(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+			},
+			args: args{
+				ctx: ctx,
+				param: &model.Thread{
+					Title: model.TitleForTest,
+					User: &model.User{
+						ID:   model.UserInValidIDForTest,
+						Name: model.UserNameForTest,
+					},
+					CreatedAt: testutil.TimeNow(),
+					UpdatedAt: testutil.TimeNow(),
+				},
+			},
+			mockArgsIsAlreadyExistTitle: mockArgsIsAlreadyExistTitle{
+				ctx:   ctx,
+				title: model.TitleForTest,
+			},
+			mockReturnsIsAlreadyExistTitle: mockReturnsIsAlreadyExistTitle{
+				found: false,
+				err:   &model.NoSuchDataError{},
+			},
+			mockArgsInsertThread: mockArgsInsertThread{
+				ctx: ctx,
+				param: &model.Thread{
+					Title: model.TitleForTest,
+					User: &model.User{
+						ID:        model.UserValidIDForTest,
+						Name:      model.UserNameForTest,
+						CreatedAt: testutil.TimeNow(),
+						UpdatedAt: testutil.TimeNow(),
+					},
+					CreatedAt: testutil.TimeNow(),
+					UpdatedAt: testutil.TimeNow(),
+				},
+			},
+			mockReturnsInsertThread: mockReturnsInsertThread{
+				id:  model.ThreadValidIDForTest,
+				err: nil,
+			},
+			wantThread: &model.Thread{
+				ID:    model.ThreadValidIDForTest,
+				Title: model.TitleForTest,
+				User: &model.User{
+					ID:        model.UserValidIDForTest,
+					Name:      model.UserNameForTest,
+					CreatedAt: testutil.TimeNow(),
+					UpdatedAt: testutil.TimeNow(),
+				},
+				CreatedAt: testutil.TimeNow(),
+				UpdatedAt: testutil.TimeNow(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "When the user who requested is not in context, CreateThread returns nil and error",
+			fields: fields{
+				m:       mock_query.NewMockDBManager(ctrl),
+				repo:    mock_repository.NewMockThreadRepository(ctrl),
+				service: mock_service.NewMockThreadService(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				param: &model.Thread{
+					Title: model.TitleForTest,
+					User: &model.User{
+						ID:   model.UserValidIDForTest,
+						Name: model.UserNameForTest,
+					},
+				},
+			},
+			wantThread: nil,
+			wantErr:    true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -452,14 +606,17 @@ func Test_threadService_CreateThread(t *testing.T) {
 			if !ok {
 				t.Fatal("failed to assert MockDBManager")
 			}
-			m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
 
-			ts, ok := tt.fields.service.(*mock_service.MockThreadService)
-			if !ok {
-				t.Fatal("failed to assert MockThreadService")
-			}
+			if tt.mockArgsIsAlreadyExistTitle.ctx != nil {
+				m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
+
+				ts, ok := tt.fields.service.(*mock_service.MockThreadService)
+				if !ok {
+					t.Fatal("failed to assert MockThreadService")
+				}
 
-			ts.EXPECT().IsAlreadyExistTitle(tt.mockArgsIsAlreadyExistTitle.ctx, gomock.Any(), tt.mockArgsIsAlreadyExistTitle.title).Return(tt.mockReturnsIsAlreadyExistTitle.found, tt.mockReturnsIsAlreadyExistTitle.err)
+				ts.EXPECT().IsAlreadyExistTitle(tt.mockArgsIsAlreadyExistTitle.ctx, gomock.Any(), tt.mockArgsIsAlreadyExistTitle.title).Return(tt.mockReturnsIsAlreadyExistTitle.found, tt.mockReturnsIsAlreadyExistTitle.err)
+			}
 
 			if tt.mockArgsInsertThread.param != nil {
 				tr, ok := tt.fields.repo.(*mock_repository.MockThreadRepository)
@@ -469,13 +626,23 @@ func Test_threadService_CreateThread(t *testing.T) {
 
 				txM := mock_query.NewMockTxManager(ctrl)
 
-				tr.EXPECT().InsertThread(tt.mockArgsInsertThread.ctx, txM, tt.args.param).Return(tt.mockReturnsInsertThread.id, tt.mockReturnsInsertThread.err)
+				tr.EXPECT().InsertThread(tt.mockArgsInsertThread.ctx, txM, tt.mockArgsInsertThread.param).Return(tt.mockReturnsInsertThread.id, tt.mockReturnsInsertThread.err)
+			}
+
+			if !tt.wantErr {
+				p, ok := tt.fields.events.(*mock_event.MockPublisher)
+				if !ok {
+					t.Fatal("failed to assert MockPublisher")
+				}
+
+				p.EXPECT().Publish(gomock.Any(), &event.ThreadCreated{Thread: tt.wantThread})
 			}
 
 			a := &threadService{
 				m:        tt.fields.m,
 				repo:     tt.fields.repo,
 				service:  tt.fields.service,
+				events:   tt.fields.events,
 				txCloser: tt.fields.txCloser,
 			}
 			gotThread, err := a.CreateThread(tt.args.ctx, tt.args.param)
@@ -500,10 +667,26 @@ func Test_threadService_UpdateThread(t *testing.T) {
 
 	testutil.SetFakeTime(time.Now())
 
+	user := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+	}
+	ctx := model.WithUser(context.Background(), user)
+
+	stored := &model.Thread{
+		ID:        model.ThreadValidIDForTest,
+		Title:     "beforeUpdate",
+		User:      user,
+		CreatedAt: testutil.TimeNow(),
+		UpdatedAt: testutil.TimeNow(),
+	}
+
 	type fields struct {
 		m        query.DBManager
 		service  service.ThreadService
 		repo     repository.ThreadRepository
+		policy   service.AuthorizationPolicy
+		events   event.Publisher
 		txCloser CloseTransaction
 	}
 	type args struct {
@@ -512,18 +695,16 @@ func Test_threadService_UpdateThread(t *testing.T) {
 		param *model.Thread
 	}
 
-	type mockArgsIsAlreadyExistID struct {
-		ctx context.Context
-		id  uint32
+	type mockReturnsGetThreadByID struct {
+		thread *model.Thread
+		err    error
 	}
 
-	type mockReturnsIsAlreadyExistID struct {
-		found bool
-		err   error
+	type mockReturnsCanModifyThread struct {
+		err error
 	}
 
 	type mockArgsUpdateThread struct {
-		ctx   context.Context
 		param *model.Thread
 	}
 
@@ -535,115 +716,105 @@ func Test_threadService_UpdateThread(t *testing.T) {
 		name   string
 		fields fields
 		args   args
-		mockArgsIsAlreadyExistID
-		mockReturnsIsAlreadyExistID
+		mockReturnsGetThreadByID
+		mockReturnsCanModifyThread
 		mockArgsUpdateThread
 		mockReturnsUpdateThread
 		wantThread *model.Thread
 		wantErr    bool
 	}{
 		{
-			name: "When appropriate args given, UpdateThread returns Thread and err",
+			name: "When the author updates the thread, UpdateThread returns Thread and nil",
 			fields: fields{
 				m:       mock_query.NewMockDBManager(ctrl),
 				service: mock_service.NewMockThreadService(ctrl),
 				repo:    mock_repository.NewMockThreadRepository(ctrl),
+				policy:  mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
 					return nil
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: ctx,
 				id:  model.ThreadValidIDForTest,
 				param: &model.Thread{
 					ID:    model.ThreadValidIDForTest,
 					Title: model.TitleForTest,
-					User: &model.User{
-						ID:        model.UserValidIDForTest,
-						Name:      model.UserNameForTest,
-						CreatedAt: testutil.TimeNow(),
-						UpdatedAt: testutil.TimeNow(),
-					},
-					CreatedAt: testutil.TimeNow(),
-					UpdatedAt: testutil.TimeNow(),
 				},
 			},
-			mockArgsIsAlreadyExistID: mockArgsIsAlreadyExistID{
-				ctx: context.Background(),
-				id:  model.ThreadValidIDForTest,
-			},
-			mockReturnsIsAlreadyExistID: mockReturnsIsAlreadyExistID{
-				found: true,
-				err:   nil,
+			mockReturnsGetThreadByID: mockReturnsGetThreadByID{
+				thread: stored,
 			},
 			mockArgsUpdateThread: mockArgsUpdateThread{
-				ctx: context.Background(),
 				param: &model.Thread{
-					ID:    model.ThreadValidIDForTest,
-					Title: model.TitleForTest,
-					User: &model.User{
-						ID:        model.UserValidIDForTest,
-						Name:      model.UserNameForTest,
-						CreatedAt: testutil.TimeNow(),
-						UpdatedAt: testutil.TimeNow(),
-					},
+					ID:        model.ThreadValidIDForTest,
+					Title:     model.TitleForTest,
+					User:      user,
 					CreatedAt: testutil.TimeNow(),
 					UpdatedAt: testutil.TimeNow(),
 				},
 			},
-			mockReturnsUpdateThread: mockReturnsUpdateThread{
-				err: nil,
-			},
 			wantThread: &model.Thread{
-				ID:    model.ThreadValidIDForTest,
-				Title: model.TitleForTest,
-				User: &model.User{
-					ID:        model.UserValidIDForTest,
-					Name:      model.UserNameForTest,
-					CreatedAt: testutil.TimeNow(),
-					UpdatedAt: testutil.TimeNow(),
-				},
+				ID:        model.ThreadValidIDForTest,
+				Title:     model.TitleForTest,
+				User:      user,
 				CreatedAt: testutil.TimeNow(),
 				UpdatedAt: testutil.TimeNow(),
 			},
 			wantErr: false,
 		},
 		{
-			name: "When given id has not existed, UpdateThread returns nil and error",
+			name: "When the user who is not the author updates the thread, UpdateThread returns nil and ForbiddenError",
 			fields: fields{
 				m:       mock_query.NewMockDBManager(ctrl),
 				service: mock_service.NewMockThreadService(ctrl),
 				repo:    mock_repository.NewMockThreadRepository(ctrl),
+				policy:  mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
 					return nil
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: ctx,
 				id:  model.ThreadValidIDForTest,
 				param: &model.Thread{
 					ID:    model.ThreadValidIDForTest,
 					Title: model.TitleForTest,
-					User: &model.User{
-						ID:        model.UserValidIDForTest,
-						Name:      model.UserNameForTest,
-						CreatedAt: testutil.TimeNow(),
-						UpdatedAt: testutil.TimeNow(),
-					},
-					CreatedAt: testutil.TimeNow(),
-					UpdatedAt: testutil.TimeNow(),
 				},
 			},
-			mockArgsIsAlreadyExistID: mockArgsIsAlreadyExistID{
-				ctx: context.Background(),
-				id:  model.ThreadValidIDForTest,
+			mockReturnsGetThreadByID: mockReturnsGetThreadByID{
+				thread: stored,
 			},
-			mockReturnsIsAlreadyExistID: mockReturnsIsAlreadyExistID{
-				found: false,
-				err:   nil,
+			mockReturnsCanModifyThread: mockReturnsCanModifyThread{
+				err: &model.ForbiddenError{},
 			},
-			mockArgsUpdateThread: mockArgsUpdateThread{
-				param: nil,
+			wantThread: nil,
+			wantErr:    true,
+		},
+		{
+			name: "When given id has not existed, UpdateThread returns nil and error",
+			fields: fields{
+				m:       mock_query.NewMockDBManager(ctrl),
+				service: mock_service.NewMockThreadService(ctrl),
+				repo:    mock_repository.NewMockThreadRepository(ctrl),
+				policy:  mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+			},
+			args: args{
+				ctx: ctx,
+				id:  model.ThreadInValidIDForTest,
+				param: &model.Thread{
+					ID:    model.ThreadInValidIDForTest,
+					Title: model.TitleForTest,
+				},
+			},
+			mockReturnsGetThreadByID: mockReturnsGetThreadByID{
+				err: &model.NoSuchDataError{},
 			},
 			wantThread: nil,
 			wantErr:    true,
@@ -654,45 +825,28 @@ func Test_threadService_UpdateThread(t *testing.T) {
 				m:       mock_query.NewMockDBManager(ctrl),
 				service: mock_service.NewMockThreadService(ctrl),
 				repo:    mock_repository.NewMockThreadRepository(ctrl),
+				policy:  mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
 					return nil
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: ctx,
 				id:  model.ThreadValidIDForTest,
 				param: &model.Thread{
 					ID:    model.ThreadValidIDForTest,
 					Title: model.TitleForTest,
-					User: &model.User{
-						ID:        model.UserValidIDForTest,
-						Name:      model.UserNameForTest,
-						CreatedAt: testutil.TimeNow(),
-						UpdatedAt: testutil.TimeNow(),
-					},
-					CreatedAt: testutil.TimeNow(),
-					UpdatedAt: testutil.TimeNow(),
 				},
 			},
-			mockArgsIsAlreadyExistID: mockArgsIsAlreadyExistID{
-				ctx: context.Background(),
-				id:  model.ThreadValidIDForTest,
-			},
-			mockReturnsIsAlreadyExistID: mockReturnsIsAlreadyExistID{
-				found: true,
-				err:   nil,
+			mockReturnsGetThreadByID: mockReturnsGetThreadByID{
+				thread: stored,
 			},
 			mockArgsUpdateThread: mockArgsUpdateThread{
-				ctx: context.Background(),
 				param: &model.Thread{
-					ID:    model.ThreadValidIDForTest,
-					Title: model.TitleForTest,
-					User: &model.User{
-						ID:        model.UserValidIDForTest,
-						Name:      model.UserNameForTest,
-						CreatedAt: testutil.TimeNow(),
-						UpdatedAt: testutil.TimeNow(),
-					},
+					ID:        model.ThreadValidIDForTest,
+					Title:     model.TitleForTest,
+					User:      user,
 					CreatedAt: testutil.TimeNow(),
 					UpdatedAt: testutil.TimeNow(),
 				},
@@ -703,46 +857,79 @@ func Test_threadService_UpdateThread(t *testing.T) {
 			wantThread: nil,
 			wantErr:    true,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			m, ok := tt.fields.m.(*mock_query.MockDBManager)
-			if !ok {
-				t.Fatal("failed to assert MockDBManager")
-			}
-			m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
-
-			ts, ok := tt.fields.service.(*mock_service.MockThreadService)
+		{
+			name: "When the user who requested is not in context, UpdateThread returns nil and error",
+			fields: fields{
+				m:       mock_query.NewMockDBManager(ctrl),
+				service: mock_service.NewMockThreadService(ctrl),
+				repo:    mock_repository.NewMockThreadRepository(ctrl),
+				policy:  mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:  mock_event.NewMockPublisher(ctrl),
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  model.ThreadValidIDForTest,
+				param: &model.Thread{
+					ID:    model.ThreadValidIDForTest,
+					Title: model.TitleForTest,
+				},
+			},
+			wantThread: nil,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ok := tt.fields.m.(*mock_query.MockDBManager)
 			if !ok {
-				t.Fatal("failed to assert MockThreadService")
+				t.Fatal("failed to assert MockDBManager")
+			}
+
+			tr, ok := tt.fields.repo.(*mock_repository.MockThreadRepository)
+			if !ok {
+				t.Fatal("failed to assert MockThreadRepository")
+			}
+
+			ap, ok := tt.fields.policy.(*mock_service.MockAuthorizationPolicy)
+			if !ok {
+				t.Fatal("failed to assert MockAuthorizationPolicy")
+			}
+
+			if _, ok := model.UserFromContext(tt.args.ctx); ok {
+				m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
+				tr.EXPECT().GetThreadByID(tt.args.ctx, gomock.Any(), tt.args.param.ID).Return(tt.mockReturnsGetThreadByID.thread, tt.mockReturnsGetThreadByID.err)
 			}
 
-			ts.EXPECT().IsAlreadyExistID(tt.mockArgsIsAlreadyExistID.ctx, gomock.Any(), tt.mockArgsIsAlreadyExistID.id).Return(tt.mockReturnsIsAlreadyExistID.found, tt.mockReturnsIsAlreadyExistID.err)
+			if tt.mockReturnsGetThreadByID.thread != nil {
+				ap.EXPECT().CanModifyThread(user, tt.mockReturnsGetThreadByID.thread).Return(tt.mockReturnsCanModifyThread.err)
+			}
 
 			if tt.mockArgsUpdateThread.param != nil {
+				tr.EXPECT().UpdateThread(tt.args.ctx, gomock.Any(), tt.args.id, tt.mockArgsUpdateThread.param).Return(tt.mockReturnsUpdateThread.err)
+			}
 
-				tr, ok := tt.fields.repo.(*mock_repository.MockThreadRepository)
+			if !tt.wantErr {
+				p, ok := tt.fields.events.(*mock_event.MockPublisher)
 				if !ok {
-					t.Fatal("failed to assert MockThreadRepository")
+					t.Fatal("failed to assert MockPublisher")
 				}
 
-				txM := mock_query.NewMockTxManager(ctrl)
-
-				tr.EXPECT().UpdateThread(tt.mockArgsUpdateThread.ctx, txM, tt.args.id, tt.args.param).Return(tt.mockReturnsUpdateThread.err)
+				p.EXPECT().Publish(gomock.Any(), &event.ThreadUpdated{Thread: tt.wantThread})
 			}
 
 			a := &threadService{
 				m:        tt.fields.m,
 				service:  tt.fields.service,
 				repo:     tt.fields.repo,
+				policy:   tt.fields.policy,
+				events:   tt.fields.events,
 				txCloser: tt.fields.txCloser,
 			}
 
 			gotThread, err := a.UpdateThread(tt.args.ctx, tt.args.id, tt.args.param)
-			if gotThread != nil {
-				gotThread.UpdatedAt = tt.wantThread.UpdatedAt
-			}
-
 			if (err != nil) != tt.wantErr {
 				t.Errorf("threadService.UpdateThread() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -760,26 +947,45 @@ func Test_threadService_DeleteThread(t *testing.T) {
 
 	testutil.SetFakeTime(time.Now())
 
+	user := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+	}
+	ctx := model.WithUser(context.Background(), user)
+
+	stored := &model.Thread{
+		ID:        model.ThreadValidIDForTest,
+		Title:     model.TitleForTest,
+		User:      user,
+		CreatedAt: testutil.TimeNow(),
+		UpdatedAt: testutil.TimeNow(),
+	}
+
 	type fields struct {
 		m        query.DBManager
 		service  service.ThreadService
 		repo     repository.ThreadRepository
+		comments repository.CommentRepository
+		policy   service.AuthorizationPolicy
+		events   event.Publisher
 		txCloser CloseTransaction
 	}
 	type args struct {
-		ctx   context.Context
-		id    uint32
-		param *model.Thread
-	}
-
-	type mockArgsIsAlreadyExistID struct {
 		ctx context.Context
 		id  uint32
 	}
 
-	type mockReturnsIsAlreadyExistID struct {
-		found bool
-		err   error
+	type mockReturnsGetThreadByID struct {
+		thread *model.Thread
+		err    error
+	}
+
+	type mockReturnsCanModifyThread struct {
+		err error
+	}
+
+	type mockReturnsDeleteCommentsByThreadID struct {
+		err error
 	}
 
 	type mockReturnsDeleteThread struct {
@@ -790,139 +996,149 @@ func Test_threadService_DeleteThread(t *testing.T) {
 		name   string
 		fields fields
 		args   args
-		mockArgsIsAlreadyExistID
-		mockReturnsIsAlreadyExistID
+		mockReturnsGetThreadByID
+		mockReturnsCanModifyThread
+		mockReturnsDeleteCommentsByThreadID
 		mockReturnsDeleteThread
-		wantThread *model.Thread
-		wantErr    bool
+		wantErr bool
 	}{
 		{
-			name: "When appropriate args given, DeleteThread returns Thread and err",
+			name: "When the author deletes the thread, DeleteThread returns nil",
 			fields: fields{
-				m:       mock_query.NewMockDBManager(ctrl),
-				service: mock_service.NewMockThreadService(ctrl),
-				repo:    mock_repository.NewMockThreadRepository(ctrl),
+				m:        mock_query.NewMockDBManager(ctrl),
+				service:  mock_service.NewMockThreadService(ctrl),
+				repo:     mock_repository.NewMockThreadRepository(ctrl),
+				comments: mock_repository.NewMockCommentRepository(ctrl),
+				policy:   mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:   mock_event.NewMockPublisher(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
 					return nil
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: ctx,
 				id:  model.ThreadValidIDForTest,
-				param: &model.Thread{
-					ID:    model.ThreadValidIDForTest,
-					Title: model.TitleForTest,
-					User: &model.User{
-						ID:        model.UserValidIDForTest,
-						Name:      model.UserNameForTest,
-						CreatedAt: testutil.TimeNow(),
-						UpdatedAt: testutil.TimeNow(),
-					},
-					CreatedAt: testutil.TimeNow(),
-					UpdatedAt: testutil.TimeNow(),
+			},
+			mockReturnsGetThreadByID: mockReturnsGetThreadByID{
+				thread: stored,
+			},
+			wantErr: false,
+		},
+		{
+			name: "When the user who is not the author deletes the thread, DeleteThread returns ForbiddenError",
+			fields: fields{
+				m:        mock_query.NewMockDBManager(ctrl),
+				service:  mock_service.NewMockThreadService(ctrl),
+				repo:     mock_repository.NewMockThreadRepository(ctrl),
+				comments: mock_repository.NewMockCommentRepository(ctrl),
+				policy:   mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:   mock_event.NewMockPublisher(ctrl),
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
 				},
 			},
-			mockArgsIsAlreadyExistID: mockArgsIsAlreadyExistID{
-				ctx: context.Background(),
+			args: args{
+				ctx: ctx,
 				id:  model.ThreadValidIDForTest,
 			},
-			mockReturnsIsAlreadyExistID: mockReturnsIsAlreadyExistID{
-				found: true,
-				err:   nil,
+			mockReturnsGetThreadByID: mockReturnsGetThreadByID{
+				thread: stored,
 			},
-			mockReturnsDeleteThread: mockReturnsDeleteThread{
-				err: nil,
+			mockReturnsCanModifyThread: mockReturnsCanModifyThread{
+				err: &model.ForbiddenError{},
 			},
-			wantThread: &model.Thread{
-				ID:    model.ThreadValidIDForTest,
-				Title: model.TitleForTest,
-				User: &model.User{
-					ID:        model.UserValidIDForTest,
-					Name:      model.UserNameForTest,
-					CreatedAt: testutil.TimeNow(),
-					UpdatedAt: testutil.TimeNow(),
+			wantErr: true,
+		},
+		{
+			name: "When given id has not existed, DeleteThread returns error",
+			fields: fields{
+				m:        mock_query.NewMockDBManager(ctrl),
+				service:  mock_service.NewMockThreadService(ctrl),
+				repo:     mock_repository.NewMockThreadRepository(ctrl),
+				comments: mock_repository.NewMockCommentRepository(ctrl),
+				policy:   mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:   mock_event.NewMockPublisher(ctrl),
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
 				},
-				CreatedAt: testutil.TimeNow(),
-				UpdatedAt: testutil.TimeNow(),
 			},
-			wantErr: false,
+			args: args{
+				ctx: ctx,
+				id:  model.ThreadInValidIDForTest,
+			},
+			mockReturnsGetThreadByID: mockReturnsGetThreadByID{
+				err: &model.NoSuchDataError{},
+			},
+			wantErr: true,
 		},
 		{
-			name: "When given id has not existed, DeleteThread returns nil and error",
+			name: "When some error occurs at repository layer, DeleteThread returns error",
 			fields: fields{
-				m:       mock_query.NewMockDBManager(ctrl),
-				service: mock_service.NewMockThreadService(ctrl),
-				repo:    mock_repository.NewMockThreadRepository(ctrl),
+				m:        mock_query.NewMockDBManager(ctrl),
+				service:  mock_service.NewMockThreadService(ctrl),
+				repo:     mock_repository.NewMockThreadRepository(ctrl),
+				comments: mock_repository.NewMockCommentRepository(ctrl),
+				policy:   mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:   mock_event.NewMockPublisher(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
 					return nil
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: ctx,
 				id:  model.ThreadValidIDForTest,
-				param: &model.Thread{
-					ID:    model.ThreadValidIDForTest,
-					Title: model.TitleForTest,
-					User: &model.User{
-						ID:        model.UserValidIDForTest,
-						Name:      model.UserNameForTest,
-						CreatedAt: testutil.TimeNow(),
-						UpdatedAt: testutil.TimeNow(),
-					},
-					CreatedAt: testutil.TimeNow(),
-					UpdatedAt: testutil.TimeNow(),
-				},
 			},
-			mockArgsIsAlreadyExistID: mockArgsIsAlreadyExistID{
-				ctx: context.Background(),
-				id:  model.ThreadValidIDForTest,
+			mockReturnsGetThreadByID: mockReturnsGetThreadByID{
+				thread: stored,
 			},
-			mockReturnsIsAlreadyExistID: mockReturnsIsAlreadyExistID{
-				found: false,
-				err:   nil,
+			mockReturnsDeleteThread: mockReturnsDeleteThread{
+				err: errors.New(model.ErrorMessageForTest),
 			},
-			wantThread: nil,
-			wantErr:    true,
+			wantErr: true,
 		},
 		{
-			name: "When some error occurs at repository layer, DeleteThread returns nil and error",
+			name: "When some error occurs at deleting comments, DeleteThread returns error without deleting the thread",
 			fields: fields{
-				m:       mock_query.NewMockDBManager(ctrl),
-				service: mock_service.NewMockThreadService(ctrl),
-				repo:    mock_repository.NewMockThreadRepository(ctrl),
+				m:        mock_query.NewMockDBManager(ctrl),
+				service:  mock_service.NewMockThreadService(ctrl),
+				repo:     mock_repository.NewMockThreadRepository(ctrl),
+				comments: mock_repository.NewMockCommentRepository(ctrl),
+				policy:   mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:   mock_event.NewMockPublisher(ctrl),
 				txCloser: func(tx query.TxManager, err error) error {
 					return nil
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: ctx,
 				id:  model.ThreadValidIDForTest,
-				param: &model.Thread{
-					ID:    model.ThreadValidIDForTest,
-					Title: model.TitleForTest,
-					User: &model.User{
-						ID:        model.UserValidIDForTest,
-						Name:      model.UserNameForTest,
-						CreatedAt: testutil.TimeNow(),
-						UpdatedAt: testutil.TimeNow(),
-					},
-					CreatedAt: testutil.TimeNow(),
-					UpdatedAt: testutil.TimeNow(),
+			},
+			mockReturnsGetThreadByID: mockReturnsGetThreadByID{
+				thread: stored,
+			},
+			mockReturnsDeleteCommentsByThreadID: mockReturnsDeleteCommentsByThreadID{
+				err: errors.New(model.ErrorMessageForTest),
+			},
+			wantErr: true,
+		},
+		{
+			name: "When the user who requested is not in context, DeleteThread returns error",
+			fields: fields{
+				m:        mock_query.NewMockDBManager(ctrl),
+				service:  mock_service.NewMockThreadService(ctrl),
+				repo:     mock_repository.NewMockThreadRepository(ctrl),
+				comments: mock_repository.NewMockCommentRepository(ctrl),
+				policy:   mock_service.NewMockAuthorizationPolicy(ctrl),
+				events:   mock_event.NewMockPublisher(ctrl),
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
 				},
 			},
-			mockArgsIsAlreadyExistID: mockArgsIsAlreadyExistID{
+			args: args{
 				ctx: context.Background(),
 				id:  model.ThreadValidIDForTest,
 			},
-			mockReturnsIsAlreadyExistID: mockReturnsIsAlreadyExistID{
-				found: true,
-				err:   nil,
-			},
-			mockReturnsDeleteThread: mockReturnsDeleteThread{
-				err: errors.New(model.ErrorMessageForTest),
-			},
-			wantThread: nil,
-			wantErr:    true,
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
@@ -931,31 +1147,60 @@ func Test_threadService_DeleteThread(t *testing.T) {
 			if !ok {
 				t.Fatal("failed to assert MockDBManager")
 			}
-			m.EXPECT().Begin().Return(mock_query.NewMockTxManager(ctrl), nil)
 
-			ts, ok := tt.fields.service.(*mock_service.MockThreadService)
+			tr, ok := tt.fields.repo.(*mock_repository.MockThreadRepository)
 			if !ok {
-				t.Fatal("failed to assert MockThreadService")
+				t.Fatal("failed to assert MockThreadRepository")
 			}
 
-			ts.EXPECT().IsAlreadyExistID(tt.mockArgsIsAlreadyExistID.ctx, gomock.Any(), tt.mockArgsIsAlreadyExistID.id).Return(tt.mockReturnsIsAlreadyExistID.found, tt.mockReturnsIsAlreadyExistID.err)
+			cr, ok := tt.fields.comments.(*mock_repository.MockCommentRepository)
+			if !ok {
+				t.Fatal("failed to assert MockCommentRepository")
+			}
 
-			if tt.mockReturnsIsAlreadyExistID.found {
-				tr, ok := tt.fields.repo.(*mock_repository.MockThreadRepository)
-				if !ok {
-					t.Fatal("failed to assert MockThreadRepository")
+			ap, ok := tt.fields.policy.(*mock_service.MockAuthorizationPolicy)
+			if !ok {
+				t.Fatal("failed to assert MockAuthorizationPolicy")
+			}
+
+			tx := mock_query.NewMockTxManager(ctrl)
+
+			if _, ok := model.UserFromContext(tt.args.ctx); ok {
+				m.EXPECT().Begin().Return(tx, nil)
+				tr.EXPECT().GetThreadByID(tt.args.ctx, tx, tt.args.id).Return(tt.mockReturnsGetThreadByID.thread, tt.mockReturnsGetThreadByID.err)
+			}
+
+			if tt.mockReturnsGetThreadByID.thread != nil {
+				ap.EXPECT().CanModifyThread(user, tt.mockReturnsGetThreadByID.thread).Return(tt.mockReturnsCanModifyThread.err)
+
+				if tt.mockReturnsCanModifyThread.err == nil {
+					// comments must be deleted before the thread in the same tx, so that none of them is orphaned.
+					deleteComments := cr.EXPECT().DeleteCommentsByThreadID(tt.args.ctx, tx, tt.args.id, user.ID, testutil.TimeNow()).Return(int64(2), tt.mockReturnsDeleteCommentsByThreadID.err)
+
+					if tt.mockReturnsDeleteCommentsByThreadID.err == nil {
+						tr.EXPECT().DeleteThread(tt.args.ctx, tx, tt.args.id, user.ID, testutil.TimeNow()).Return(tt.mockReturnsDeleteThread.err).After(deleteComments)
+					}
 				}
+			}
 
-				txM := mock_query.NewMockTxManager(ctrl)
+			if !tt.wantErr {
+				p, ok := tt.fields.events.(*mock_event.MockPublisher)
+				if !ok {
+					t.Fatal("failed to assert MockPublisher")
+				}
 
-				tr.EXPECT().DeleteThread(tt.args.ctx, txM, tt.args.id).Return(tt.mockReturnsDeleteThread.err)
+				p.EXPECT().Publish(gomock.Any(), &event.ThreadDeleted{ThreadID: tt.args.id})
 			}
 
 			a := &threadService{
 				m:        tt.fields.m,
 				service:  tt.fields.service,
 				repo:     tt.fields.repo,
+				comments: tt.fields.comments,
+				policy:   tt.fields.policy,
+				events:   tt.fields.events,
 				txCloser: tt.fields.txCloser,
+				now:      testutil.TimeNow,
 			}
 
 			err := a.DeleteThread(tt.args.ctx, tt.args.id)
@@ -966,3 +1211,162 @@ func Test_threadService_DeleteThread(t *testing.T) {
 		})
 	}
 }
+
+func Test_threadService_RestoreThread(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testutil.SetFakeTime(time.Now())
+
+	user := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+	}
+	ctx := model.WithUser(context.Background(), user)
+
+	deleted := &model.Thread{
+		ID:        model.ThreadValidIDForTest,
+		Title:     model.TitleForTest,
+		User:      user,
+		CreatedAt: testutil.TimeNow(),
+		UpdatedAt: testutil.TimeNow(),
+	}
+
+	type mockReturnsGetDeletedThreadByID struct {
+		thread *model.Thread
+		err    error
+	}
+
+	type mockReturnsCanModifyThread struct {
+		err error
+	}
+
+	type mockReturnsRestoreCommentsByThreadID struct {
+		err error
+	}
+
+	type mockReturnsRestoreThread struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		mockReturnsGetDeletedThreadByID
+		mockReturnsCanModifyThread
+		mockReturnsRestoreCommentsByThreadID
+		mockReturnsRestoreThread
+		want    *model.Thread
+		wantErr bool
+	}{
+		{
+			name: "When the author restores the deleted thread, RestoreThread restores it with its comments",
+			ctx:  ctx,
+			mockReturnsGetDeletedThreadByID: mockReturnsGetDeletedThreadByID{
+				thread: deleted,
+			},
+			want:    deleted,
+			wantErr: false,
+		},
+		{
+			name: "When the thread has not been deleted, RestoreThread returns error",
+			ctx:  ctx,
+			mockReturnsGetDeletedThreadByID: mockReturnsGetDeletedThreadByID{
+				err: &model.NoSuchDataError{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "When the user who is not the author restores the thread, RestoreThread returns ForbiddenError",
+			ctx:  ctx,
+			mockReturnsGetDeletedThreadByID: mockReturnsGetDeletedThreadByID{
+				thread: deleted,
+			},
+			mockReturnsCanModifyThread: mockReturnsCanModifyThread{
+				err: &model.ForbiddenError{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "When some error occurs at restoring comments, RestoreThread returns error without restoring the thread",
+			ctx:  ctx,
+			mockReturnsGetDeletedThreadByID: mockReturnsGetDeletedThreadByID{
+				thread: deleted,
+			},
+			mockReturnsRestoreCommentsByThreadID: mockReturnsRestoreCommentsByThreadID{
+				err: errors.New(model.ErrorMessageForTest),
+			},
+			wantErr: true,
+		},
+		{
+			name: "When some error occurs at restoring the thread, RestoreThread returns error",
+			ctx:  ctx,
+			mockReturnsGetDeletedThreadByID: mockReturnsGetDeletedThreadByID{
+				thread: deleted,
+			},
+			mockReturnsRestoreThread: mockReturnsRestoreThread{
+				err: errors.New(model.ErrorMessageForTest),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "When the user who requested is not in context, RestoreThread returns error",
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mock_query.NewMockDBManager(ctrl)
+			tr := mock_repository.NewMockThreadRepository(ctrl)
+			cr := mock_repository.NewMockCommentRepository(ctrl)
+			ap := mock_service.NewMockAuthorizationPolicy(ctrl)
+			p := mock_event.NewMockPublisher(ctrl)
+			tx := mock_query.NewMockTxManager(ctrl)
+
+			if _, ok := model.UserFromContext(tt.ctx); ok {
+				m.EXPECT().Begin().Return(tx, nil)
+				tr.EXPECT().GetDeletedThreadByID(tt.ctx, tx, model.ThreadValidIDForTest).Return(tt.mockReturnsGetDeletedThreadByID.thread, tt.mockReturnsGetDeletedThreadByID.err)
+			}
+
+			if tt.mockReturnsGetDeletedThreadByID.thread != nil {
+				ap.EXPECT().CanModifyThread(user, tt.mockReturnsGetDeletedThreadByID.thread).Return(tt.mockReturnsCanModifyThread.err)
+
+				if tt.mockReturnsCanModifyThread.err == nil {
+					// comments are found by the deletion of the thread, so that they must be restored before it.
+					restoreComments := cr.EXPECT().RestoreCommentsByThreadID(tt.ctx, tx, model.ThreadValidIDForTest).Return(int64(2), tt.mockReturnsRestoreCommentsByThreadID.err)
+
+					if tt.mockReturnsRestoreCommentsByThreadID.err == nil {
+						tr.EXPECT().RestoreThread(tt.ctx, tx, model.ThreadValidIDForTest).Return(tt.mockReturnsRestoreThread.err).After(restoreComments)
+					}
+				}
+			}
+
+			if !tt.wantErr {
+				p.EXPECT().Publish(gomock.Any(), &event.ThreadRestored{Thread: tt.want})
+			}
+
+			a := &threadService{
+				m:        m,
+				service:  mock_service.NewMockThreadService(ctrl),
+				repo:     tr,
+				comments: cr,
+				policy:   ap,
+				events:   p,
+				txCloser: func(tx query.TxManager, err error) error {
+					return nil
+				},
+				now: testutil.TimeNow,
+			}
+
+			got, err := a.RestoreThread(tt.ctx, model.ThreadValidIDForTest)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("threadService.RestoreThread() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("threadService.RestoreThread() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
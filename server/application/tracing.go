@@ -0,0 +1,302 @@
+package application
+
+import (
+	"context"
+
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/tracing"
+)
+
+// adminServiceTracing is the decorator of AdminService which records a span per method.
+type adminServiceTracing struct {
+	AdminService
+}
+
+// NewAdminServiceWithTracing generates and returns AdminService which records the spans of the methods of s.
+func NewAdminServiceWithTracing(s AdminService) AdminService {
+	return &adminServiceTracing{
+		AdminService: s,
+	}
+}
+
+// ListUsers records the span of AdminService.ListUsers.
+func (s *adminServiceTracing) ListUsers(ctx context.Context, limit int, cursor uint32) (list *model.UserList, err error) {
+	ctx, span := tracing.Start(ctx, "AdminService.ListUsers")
+	defer func() { tracing.End(span, err) }()
+	return s.AdminService.ListUsers(ctx, limit, cursor)
+}
+
+// ChangeRole records the span of AdminService.ChangeRole.
+func (s *adminServiceTracing) ChangeRole(ctx context.Context, id uint32, role model.Role) (user *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "AdminService.ChangeRole")
+	defer func() { tracing.End(span, err) }()
+	return s.AdminService.ChangeRole(ctx, id, role)
+}
+
+// BanUser records the span of AdminService.BanUser.
+func (s *adminServiceTracing) BanUser(ctx context.Context, id uint32) (user *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "AdminService.BanUser")
+	defer func() { tracing.End(span, err) }()
+	return s.AdminService.BanUser(ctx, id)
+}
+
+// UnbanUser records the span of AdminService.UnbanUser.
+func (s *adminServiceTracing) UnbanUser(ctx context.Context, id uint32) (user *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "AdminService.UnbanUser")
+	defer func() { tracing.End(span, err) }()
+	return s.AdminService.UnbanUser(ctx, id)
+}
+
+// GrantAdmin records the span of AdminService.GrantAdmin.
+func (s *adminServiceTracing) GrantAdmin(ctx context.Context, name string) (user *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "AdminService.GrantAdmin")
+	defer func() { tracing.End(span, err) }()
+	return s.AdminService.GrantAdmin(ctx, name)
+}
+
+// apiTokenServiceTracing is the decorator of APITokenService which records a span per method.
+type apiTokenServiceTracing struct {
+	APITokenService
+}
+
+// NewAPITokenServiceWithTracing generates and returns APITokenService which records the spans of the methods of s.
+func NewAPITokenServiceWithTracing(s APITokenService) APITokenService {
+	return &apiTokenServiceTracing{
+		APITokenService: s,
+	}
+}
+
+// CreateAPIToken records the span of APITokenService.CreateAPIToken.
+func (s *apiTokenServiceTracing) CreateAPIToken(ctx context.Context, name string) (token *model.APIToken, raw string, err error) {
+	ctx, span := tracing.Start(ctx, "APITokenService.CreateAPIToken")
+	defer func() { tracing.End(span, err) }()
+	return s.APITokenService.CreateAPIToken(ctx, name)
+}
+
+// ListAPITokens records the span of APITokenService.ListAPITokens.
+func (s *apiTokenServiceTracing) ListAPITokens(ctx context.Context) (tokens []*model.APIToken, err error) {
+	ctx, span := tracing.Start(ctx, "APITokenService.ListAPITokens")
+	defer func() { tracing.End(span, err) }()
+	return s.APITokenService.ListAPITokens(ctx)
+}
+
+// RevokeAPIToken records the span of APITokenService.RevokeAPIToken.
+func (s *apiTokenServiceTracing) RevokeAPIToken(ctx context.Context, id uint32) (err error) {
+	ctx, span := tracing.Start(ctx, "APITokenService.RevokeAPIToken")
+	defer func() { tracing.End(span, err) }()
+	return s.APITokenService.RevokeAPIToken(ctx, id)
+}
+
+// authenticationServiceTracing is the decorator of AuthenticationService which records a span per method.
+type authenticationServiceTracing struct {
+	AuthenticationService
+}
+
+// NewAuthenticationServiceWithTracing generates and returns AuthenticationService which records the spans of the methods of s.
+func NewAuthenticationServiceWithTracing(s AuthenticationService) AuthenticationService {
+	return &authenticationServiceTracing{
+		AuthenticationService: s,
+	}
+}
+
+// SignUp records the span of AuthenticationService.SignUp.
+func (s *authenticationServiceTracing) SignUp(ctx context.Context, param *model.User) (user *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "AuthenticationService.SignUp")
+	defer func() { tracing.End(span, err) }()
+	return s.AuthenticationService.SignUp(ctx, param)
+}
+
+// Login records the span of AuthenticationService.Login.
+func (s *authenticationServiceTracing) Login(ctx context.Context, param *model.User) (user *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "AuthenticationService.Login")
+	defer func() { tracing.End(span, err) }()
+	return s.AuthenticationService.Login(ctx, param)
+}
+
+// Logout records the span of AuthenticationService.Logout.
+func (s *authenticationServiceTracing) Logout(ctx context.Context, sessionID string) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthenticationService.Logout")
+	defer func() { tracing.End(span, err) }()
+	return s.AuthenticationService.Logout(ctx, sessionID)
+}
+
+// commentServiceTracing is the decorator of CommentService which records a span per method.
+type commentServiceTracing struct {
+	CommentService
+}
+
+// NewCommentServiceWithTracing generates and returns CommentService which records the spans of the methods of s.
+func NewCommentServiceWithTracing(s CommentService) CommentService {
+	return &commentServiceTracing{
+		CommentService: s,
+	}
+}
+
+// ListComments records the span of CommentService.ListComments.
+func (s *commentServiceTracing) ListComments(ctx context.Context, threadID uint32, limit int, cursor uint32) (list *model.CommentList, err error) {
+	ctx, span := tracing.Start(ctx, "CommentService.ListComments")
+	defer func() { tracing.End(span, err) }()
+	return s.CommentService.ListComments(ctx, threadID, limit, cursor)
+}
+
+// GetComment records the span of CommentService.GetComment.
+func (s *commentServiceTracing) GetComment(ctx context.Context, id uint32) (comment *model.Comment, err error) {
+	ctx, span := tracing.Start(ctx, "CommentService.GetComment")
+	defer func() { tracing.End(span, err) }()
+	return s.CommentService.GetComment(ctx, id)
+}
+
+// CreateComment records the span of CommentService.CreateComment.
+func (s *commentServiceTracing) CreateComment(ctx context.Context, comment *model.Comment) (created *model.Comment, err error) {
+	ctx, span := tracing.Start(ctx, "CommentService.CreateComment")
+	defer func() { tracing.End(span, err) }()
+	return s.CommentService.CreateComment(ctx, comment)
+}
+
+// UpdateComment records the span of CommentService.UpdateComment.
+func (s *commentServiceTracing) UpdateComment(ctx context.Context, id uint32, comment *model.Comment) (updated *model.Comment, err error) {
+	ctx, span := tracing.Start(ctx, "CommentService.UpdateComment")
+	defer func() { tracing.End(span, err) }()
+	return s.CommentService.UpdateComment(ctx, id, comment)
+}
+
+// DeleteComment records the span of CommentService.DeleteComment.
+func (s *commentServiceTracing) DeleteComment(ctx context.Context, id uint32) (err error) {
+	ctx, span := tracing.Start(ctx, "CommentService.DeleteComment")
+	defer func() { tracing.End(span, err) }()
+	return s.CommentService.DeleteComment(ctx, id)
+}
+
+// RestoreComment records the span of CommentService.RestoreComment.
+func (s *commentServiceTracing) RestoreComment(ctx context.Context, id uint32) (restored *model.Comment, err error) {
+	ctx, span := tracing.Start(ctx, "CommentService.RestoreComment")
+	defer func() { tracing.End(span, err) }()
+	return s.CommentService.RestoreComment(ctx, id)
+}
+
+// ListCommentRevisions records the span of CommentService.ListCommentRevisions.
+func (s *commentServiceTracing) ListCommentRevisions(ctx context.Context, id uint32) (revisions *model.CommentRevisionList, err error) {
+	ctx, span := tracing.Start(ctx, "CommentService.ListCommentRevisions")
+	defer func() { tracing.End(span, err) }()
+	return s.CommentService.ListCommentRevisions(ctx, id)
+}
+
+// passwordServiceTracing is the decorator of PasswordService which records a span per method.
+type passwordServiceTracing struct {
+	PasswordService
+}
+
+// NewPasswordServiceWithTracing generates and returns PasswordService which records the spans of the methods of s.
+func NewPasswordServiceWithTracing(s PasswordService) PasswordService {
+	return &passwordServiceTracing{
+		PasswordService: s,
+	}
+}
+
+// ChangePassword records the span of PasswordService.ChangePassword.
+func (s *passwordServiceTracing) ChangePassword(ctx context.Context, oldPassword, newPassword string) (err error) {
+	ctx, span := tracing.Start(ctx, "PasswordService.ChangePassword")
+	defer func() { tracing.End(span, err) }()
+	return s.PasswordService.ChangePassword(ctx, oldPassword, newPassword)
+}
+
+// RequestPasswordReset records the span of PasswordService.RequestPasswordReset.
+func (s *passwordServiceTracing) RequestPasswordReset(ctx context.Context, userID uint32) (err error) {
+	ctx, span := tracing.Start(ctx, "PasswordService.RequestPasswordReset")
+	defer func() { tracing.End(span, err) }()
+	return s.PasswordService.RequestPasswordReset(ctx, userID)
+}
+
+// ResetPassword records the span of PasswordService.ResetPassword.
+func (s *passwordServiceTracing) ResetPassword(ctx context.Context, token, newPassword string) (err error) {
+	ctx, span := tracing.Start(ctx, "PasswordService.ResetPassword")
+	defer func() { tracing.End(span, err) }()
+	return s.PasswordService.ResetPassword(ctx, token, newPassword)
+}
+
+// sessionServiceTracing is the decorator of SessionService which records a span per method.
+type sessionServiceTracing struct {
+	SessionService
+}
+
+// NewSessionServiceWithTracing generates and returns SessionService which records the spans of the methods of s.
+func NewSessionServiceWithTracing(s SessionService) SessionService {
+	return &sessionServiceTracing{
+		SessionService: s,
+	}
+}
+
+// ListSessions records the span of SessionService.ListSessions.
+func (s *sessionServiceTracing) ListSessions(ctx context.Context) (sessions []*model.Session, err error) {
+	ctx, span := tracing.Start(ctx, "SessionService.ListSessions")
+	defer func() { tracing.End(span, err) }()
+	return s.SessionService.ListSessions(ctx)
+}
+
+// RevokeSession records the span of SessionService.RevokeSession.
+func (s *sessionServiceTracing) RevokeSession(ctx context.Context, publicID string) (err error) {
+	ctx, span := tracing.Start(ctx, "SessionService.RevokeSession")
+	defer func() { tracing.End(span, err) }()
+	return s.SessionService.RevokeSession(ctx, publicID)
+}
+
+// RevokeAllSessions records the span of SessionService.RevokeAllSessions.
+func (s *sessionServiceTracing) RevokeAllSessions(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "SessionService.RevokeAllSessions")
+	defer func() { tracing.End(span, err) }()
+	return s.SessionService.RevokeAllSessions(ctx)
+}
+
+// threadServiceTracing is the decorator of ThreadService which records a span per method.
+type threadServiceTracing struct {
+	ThreadService
+}
+
+// NewThreadServiceWithTracing generates and returns ThreadService which records the spans of the methods of s.
+func NewThreadServiceWithTracing(s ThreadService) ThreadService {
+	return &threadServiceTracing{
+		ThreadService: s,
+	}
+}
+
+// ListThreads records the span of ThreadService.ListThreads.
+func (s *threadServiceTracing) ListThreads(ctx context.Context, limit int, cursor uint32) (list *model.ThreadList, err error) {
+	ctx, span := tracing.Start(ctx, "ThreadService.ListThreads")
+	defer func() { tracing.End(span, err) }()
+	return s.ThreadService.ListThreads(ctx, limit, cursor)
+}
+
+// GetThread records the span of ThreadService.GetThread.
+func (s *threadServiceTracing) GetThread(ctx context.Context, id uint32) (thread *model.Thread, err error) {
+	ctx, span := tracing.Start(ctx, "ThreadService.GetThread")
+	defer func() { tracing.End(span, err) }()
+	return s.ThreadService.GetThread(ctx, id)
+}
+
+// CreateThread records the span of ThreadService.CreateThread.
+func (s *threadServiceTracing) CreateThread(ctx context.Context, thread *model.Thread) (created *model.Thread, err error) {
+	ctx, span := tracing.Start(ctx, "ThreadService.CreateThread")
+	defer func() { tracing.End(span, err) }()
+	return s.ThreadService.CreateThread(ctx, thread)
+}
+
+// UpdateThread records the span of ThreadService.UpdateThread.
+func (s *threadServiceTracing) UpdateThread(ctx context.Context, id uint32, thread *model.Thread) (updated *model.Thread, err error) {
+	ctx, span := tracing.Start(ctx, "ThreadService.UpdateThread")
+	defer func() { tracing.End(span, err) }()
+	return s.ThreadService.UpdateThread(ctx, id, thread)
+}
+
+// DeleteThread records the span of ThreadService.DeleteThread.
+func (s *threadServiceTracing) DeleteThread(ctx context.Context, id uint32) (err error) {
+	ctx, span := tracing.Start(ctx, "ThreadService.DeleteThread")
+	defer func() { tracing.End(span, err) }()
+	return s.ThreadService.DeleteThread(ctx, id)
+}
+
+// RestoreThread records the span of ThreadService.RestoreThread.
+func (s *threadServiceTracing) RestoreThread(ctx context.Context, id uint32) (restored *model.Thread, err error) {
+	ctx, span := tracing.Start(ctx, "ThreadService.RestoreThread")
+	defer func() { tracing.End(span, err) }()
+	return s.ThreadService.RestoreThread(ctx, id)
+}
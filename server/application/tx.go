@@ -1,9 +1,14 @@
 package application
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/event"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
 )
 
 // CloseTransaction executes after process of tx.
@@ -16,3 +21,11 @@ func beginTxErrorMsg(err error) error {
 		InvalidReasonForDeveloper: model.FailedToBeginTx,
 	})
 }
+
+// publishEvents publishes domain events after tx has been committed.
+// Failure to publish is only logged, since the changes have been already committed.
+func publishEvents(ctx context.Context, publisher event.Publisher, events ...event.Event) {
+	if err := publisher.Publish(ctx, events...); err != nil {
+		logger.FromContext(ctx).Error("failed to publish events", zap.String("error message", err.Error()))
+	}
+}
@@ -0,0 +1,559 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/util"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v2"
+)
+
+// EnvPrefix is the prefix of the environment variables which override Config.
+// The name of the variable is the path of the key in upper snake case, e.g. NVGC_DB_MAX_OPEN_CONNS for db.maxOpenConns.
+const EnvPrefix = "NVGC"
+
+// Store names.
+const (
+	StoreMemory = "memory"
+	StoreMySQL  = "mysql"
+)
+
+// Hasher names.
+const (
+	HasherBcrypt   = "bcrypt"
+	HasherArgon2id = "argon2id"
+)
+
+// Exporter names of the tracing.
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+)
+
+// Config is the configuration of the server.
+type Config struct {
+	Server       Server       `yaml:"server"`
+	DB           DB           `yaml:"db"`
+	Log          Log          `yaml:"log"`
+	Health       Health       `yaml:"health"`
+	Metrics      Metrics      `yaml:"metrics"`
+	Tracing      Tracing      `yaml:"tracing"`
+	Cookie       Cookie       `yaml:"cookie"`
+	Session      Session      `yaml:"session"`
+	Password     Password     `yaml:"password"`
+	RateLimit    RateLimit    `yaml:"rateLimit"`
+	LoginAttempt LoginAttempt `yaml:"loginAttempt"`
+	SoftDelete   SoftDelete   `yaml:"softDelete"`
+}
+
+// Server is the configuration of HTTP server.
+// ReadTimeout and WriteTimeout are 0, i.e. no timeout, by default,
+// because they would cut the event streams which last longer than them.
+type Server struct {
+	Addr string `yaml:"addr"`
+	TLS  TLS    `yaml:"tls"`
+	// ClientDir is the directory of the built client, which has index.html and _nuxt.
+	ClientDir         string        `yaml:"clientDir"`
+	ReadTimeout       time.Duration `yaml:"readTimeout"`
+	ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout"`
+	WriteTimeout      time.Duration `yaml:"writeTimeout"`
+	IdleTimeout       time.Duration `yaml:"idleTimeout"`
+	MaxHeaderBytes    int           `yaml:"maxHeaderBytes"`
+	// ShutdownDelay is the time to keep serving after the readiness has failed on shutdown,
+	// so that the load balancer stops sending requests before the server stops accepting them.
+	ShutdownDelay time.Duration `yaml:"shutdownDelay"`
+	// ShutdownTimeout is the deadline to drain the connections on shutdown.
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+	// TrustedProxies are the IPs or CIDRs of the reverse proxies whose X-Forwarded-For and X-Real-Ip are trusted.
+	// The headers are ignored when it is empty, so that the client IP is the remote address.
+	TrustedProxies []string `yaml:"trustedProxies"`
+}
+
+// TrustedProxyNets returns TrustedProxies as networks. An IP is the network which has only it.
+func (s Server) TrustedProxyNets() ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(s.TrustedProxies))
+	for _, proxy := range s.TrustedProxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, errors.Errorf("%s is neither IP nor CIDR", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, n, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, errors.Errorf("%s is neither IP nor CIDR", proxy)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+// TLS is the configuration of TLS. TLS is disabled when the files are not given.
+type TLS struct {
+	CertFile string `yaml:"certFile"`
+	KeyFile  string `yaml:"keyFile"`
+}
+
+// Enabled returns whether TLS is enabled.
+func (t TLS) Enabled() bool {
+	return t.CertFile != "" && t.KeyFile != ""
+}
+
+// DB is the configuration of the database and its connection pool.
+type DB struct {
+	DSN             string        `yaml:"dsn"`
+	MaxOpenConns    int           `yaml:"maxOpenConns"`
+	MaxIdleConns    int           `yaml:"maxIdleConns"`
+	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime"`
+	// AutoMigrate applies the pending migrations when the server starts.
+	AutoMigrate bool `yaml:"autoMigrate"`
+	// MigrationLockTimeout is the time to wait for the other instances which are migrating.
+	MigrationLockTimeout time.Duration `yaml:"migrationLockTimeout"`
+}
+
+// Health is the configuration of the readiness checks.
+type Health struct {
+	// Timeout is the timeout of each check of the dependencies.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// Metrics is the configuration of the metrics of Prometheus.
+type Metrics struct {
+	Enabled bool `yaml:"enabled"`
+	// Path is the path which exposes the metrics.
+	Path string `yaml:"path"`
+	// Token is the bearer token which the scraper must send in Authorization header.
+	// The metrics are public when it is empty, so that they must be blocked in front of the server.
+	Token string `yaml:"token"`
+}
+
+// Tracing is the configuration of the tracing of OpenTelemetry.
+type Tracing struct {
+	// Exporter is the destination of the spans, i.e. none, stdout or file.
+	Exporter string `yaml:"exporter"`
+	// FilePath is the file which the spans are appended to when Exporter is file.
+	FilePath    string `yaml:"filePath"`
+	ServiceName string `yaml:"serviceName"`
+	// SampleRatio is the ratio of the traces which are sampled, from 0 to 1.
+	// The traces whose parents have been sampled are always sampled.
+	SampleRatio float64 `yaml:"sampleRatio"`
+}
+
+// Enabled returns whether the tracing is enabled.
+func (t Tracing) Enabled() bool {
+	return t.Exporter != ExporterNone
+}
+
+// Log is the configuration of the logger.
+type Log struct {
+	Level string `yaml:"level"`
+}
+
+// ZapLevel returns the level of zap.
+func (l Log) ZapLevel() (zapcore.Level, error) {
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(l.Level)); err != nil {
+		return level, err
+	}
+	return level, nil
+}
+
+// Cookie is the configuration of the attributes of cookies.
+type Cookie struct {
+	Domain string `yaml:"domain"`
+	Secure bool   `yaml:"secure"`
+	// SameSite is one of "default", "lax" and "strict".
+	SameSite string `yaml:"sameSite"`
+}
+
+// SameSiteMode returns SameSite as http.SameSite.
+func (c Cookie) SameSiteMode() http.SameSite {
+	switch strings.ToLower(c.SameSite) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "lax":
+		return http.SameSiteLaxMode
+	default:
+		return http.SameSiteDefaultMode
+	}
+}
+
+// Session is the configuration of the expiry of sessions.
+type Session struct {
+	AbsoluteTimeout time.Duration `yaml:"absoluteTimeout"`
+	IdleTimeout     time.Duration `yaml:"idleTimeout"`
+	RenewInterval   time.Duration `yaml:"renewInterval"`
+}
+
+// Expiry returns model.SessionExpiry.
+func (s Session) Expiry() model.SessionExpiry {
+	return model.SessionExpiry{
+		AbsoluteTimeout: s.AbsoluteTimeout,
+		IdleTimeout:     s.IdleTimeout,
+		RenewInterval:   s.RenewInterval,
+	}
+}
+
+// Password is the configuration of the password policy and hashing.
+type Password struct {
+	MinLength           int    `yaml:"minLength"`
+	MinCharacterClasses int    `yaml:"minCharacterClasses"`
+	DenyListPath        string `yaml:"denyListPath"`
+	// Hasher is the algorithm which hashes passwords, "bcrypt" or "argon2id".
+	// The hashes made by the other are still verified, and rehashed on login.
+	Hasher     string   `yaml:"hasher"`
+	BcryptCost int      `yaml:"bcryptCost"`
+	Argon2id   Argon2id `yaml:"argon2id"`
+}
+
+// Policy returns model.PasswordPolicy without the deny list.
+func (p Password) Policy() model.PasswordPolicy {
+	policy := model.DefaultPasswordPolicy()
+	policy.MinLength = p.MinLength
+	policy.MinCharacterClasses = p.MinCharacterClasses
+	return policy
+}
+
+// PasswordHasher returns util.PasswordHasher which hashes passwords by Hasher.
+func (p Password) PasswordHasher() util.PasswordHasher {
+	bcryptHasher := util.NewBcryptHasher(p.BcryptCost)
+	argon2idHasher := util.NewArgon2idHasher(p.Argon2id.Params())
+
+	if p.Hasher == HasherArgon2id {
+		return util.NewVersionedHasher(argon2idHasher, bcryptHasher)
+	}
+	return util.NewVersionedHasher(bcryptHasher, argon2idHasher)
+}
+
+// Argon2id is the configuration of the parameters of argon2id.
+type Argon2id struct {
+	Memory      uint32 `yaml:"memory"`
+	Iterations  uint32 `yaml:"iterations"`
+	Parallelism uint8  `yaml:"parallelism"`
+	SaltLength  int    `yaml:"saltLength"`
+	KeyLength   int    `yaml:"keyLength"`
+}
+
+// Params returns util.Argon2idParams.
+func (a Argon2id) Params() util.Argon2idParams {
+	return util.Argon2idParams{
+		Memory:      a.Memory,
+		Iterations:  a.Iterations,
+		Parallelism: a.Parallelism,
+		SaltLength:  a.SaltLength,
+		KeyLength:   a.KeyLength,
+	}
+}
+
+// RateLimit is the configuration of the rate limits.
+type RateLimit struct {
+	Global         RateLimitRule `yaml:"global"`
+	Authentication RateLimitRule `yaml:"authentication"`
+	User           RateLimitRule `yaml:"user"`
+	Post           RateLimitRule `yaml:"post"`
+}
+
+// Limits returns model.RateLimits.
+func (r RateLimit) Limits() model.RateLimits {
+	return model.RateLimits{
+		Global:         r.Global.Limit(),
+		Authentication: r.Authentication.Limit(),
+		User:           r.User.Limit(),
+		Post:           r.Post.Limit(),
+	}
+}
+
+// RateLimitRule is the configuration of a rate limit.
+type RateLimitRule struct {
+	Requests int           `yaml:"requests"`
+	Period   time.Duration `yaml:"period"`
+}
+
+// Limit returns model.RateLimit.
+func (r RateLimitRule) Limit() model.RateLimit {
+	return model.RateLimit{
+		Limit:  r.Requests,
+		Period: r.Period,
+	}
+}
+
+// LoginAttempt is the configuration of the throttling of login attempts.
+type LoginAttempt struct {
+	// Store is where the failed attempts are stored, "mysql" or "memory".
+	// "memory" is only for a single server.
+	Store string             `yaml:"store"`
+	User  LoginAttemptPolicy `yaml:"user"`
+	IP    LoginAttemptPolicy `yaml:"ip"`
+}
+
+// LoginAttemptPolicy is the configuration of model.LoginAttemptPolicy.
+type LoginAttemptPolicy struct {
+	FreeFailures    int           `yaml:"freeFailures"`
+	BaseDelay       time.Duration `yaml:"baseDelay"`
+	MaxDelay        time.Duration `yaml:"maxDelay"`
+	LockoutFailures int           `yaml:"lockoutFailures"`
+	LockoutDuration time.Duration `yaml:"lockoutDuration"`
+}
+
+// Policy returns model.LoginAttemptPolicy.
+func (p LoginAttemptPolicy) Policy() model.LoginAttemptPolicy {
+	return model.LoginAttemptPolicy{
+		FreeFailures:    p.FreeFailures,
+		BaseDelay:       p.BaseDelay,
+		MaxDelay:        p.MaxDelay,
+		LockoutFailures: p.LockoutFailures,
+		LockoutDuration: p.LockoutDuration,
+	}
+}
+
+// SoftDelete is the configuration of the purge of the soft-deleted threads and comments.
+type SoftDelete struct {
+	// Retention is the period for which the deleted contents can be restored before they are purged.
+	Retention      time.Duration `yaml:"retention"`
+	PurgeInterval  time.Duration `yaml:"purgeInterval"`
+	PurgeBatchSize int           `yaml:"purgeBatchSize"`
+}
+
+// Default returns Config with the default values.
+func Default() *Config {
+	expiry := model.DefaultSessionExpiry()
+	passwordPolicy := model.DefaultPasswordPolicy()
+	argon2id := util.DefaultArgon2idParams()
+	limits := model.DefaultRateLimits()
+
+	return &Config{
+		Server: Server{
+			Addr:              ":8080",
+			ClientDir:         "./../client/nuxt-vue-go-chat/dist",
+			ReadHeaderTimeout: 10 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			MaxHeaderBytes:    1 << 20,
+			ShutdownDelay:     5 * time.Second,
+			ShutdownTimeout:   30 * time.Second,
+			TrustedProxies:    []string{},
+		},
+		DB: DB{
+			DSN:                  "root:@tcp(nvgdb:3306)/nuxt_vue_go_chat?charset=utf8mb4&parseTime=True",
+			MaxOpenConns:         25,
+			MaxIdleConns:         25,
+			ConnMaxLifetime:      5 * time.Minute,
+			AutoMigrate:          false,
+			MigrationLockTimeout: time.Minute,
+		},
+		Log: Log{
+			Level: "debug",
+		},
+		Health: Health{
+			Timeout: 2 * time.Second,
+		},
+		Metrics: Metrics{
+			Enabled: true,
+			Path:    "/metrics",
+		},
+		Tracing: Tracing{
+			Exporter:    ExporterNone,
+			FilePath:    "./traces.jsonl",
+			ServiceName: "nuxt-vue-go-chat",
+			SampleRatio: 1,
+		},
+		Cookie: Cookie{
+			SameSite: "lax",
+		},
+		Session: Session{
+			AbsoluteTimeout: expiry.AbsoluteTimeout,
+			IdleTimeout:     expiry.IdleTimeout,
+			RenewInterval:   expiry.RenewInterval,
+		},
+		Password: Password{
+			MinLength:           passwordPolicy.MinLength,
+			MinCharacterClasses: passwordPolicy.MinCharacterClasses,
+			DenyListPath:        "./resources/password_deny_list.txt",
+			Hasher:              HasherBcrypt,
+			BcryptCost:          util.DefaultBcryptCost,
+			Argon2id: Argon2id{
+				Memory:      argon2id.Memory,
+				Iterations:  argon2id.Iterations,
+				Parallelism: argon2id.Parallelism,
+				SaltLength:  argon2id.SaltLength,
+				KeyLength:   argon2id.KeyLength,
+			},
+		},
+		RateLimit: RateLimit{
+			Global:         rateLimitRule(limits.Global),
+			Authentication: rateLimitRule(limits.Authentication),
+			User:           rateLimitRule(limits.User),
+			Post:           rateLimitRule(limits.Post),
+		},
+		LoginAttempt: LoginAttempt{
+			Store: StoreMySQL,
+			User:  loginAttemptPolicy(model.DefaultUserLoginAttemptPolicy()),
+			IP:    loginAttemptPolicy(model.DefaultIPLoginAttemptPolicy()),
+		},
+		SoftDelete: SoftDelete{
+			Retention:      30 * 24 * time.Hour,
+			PurgeInterval:  time.Hour,
+			PurgeBatchSize: 500,
+		},
+	}
+}
+
+// rateLimitRule returns RateLimitRule of model.RateLimit.
+func rateLimitRule(limit model.RateLimit) RateLimitRule {
+	return RateLimitRule{
+		Requests: limit.Limit,
+		Period:   limit.Period,
+	}
+}
+
+// loginAttemptPolicy returns LoginAttemptPolicy of model.LoginAttemptPolicy.
+func loginAttemptPolicy(policy model.LoginAttemptPolicy) LoginAttemptPolicy {
+	return LoginAttemptPolicy{
+		FreeFailures:    policy.FreeFailures,
+		BaseDelay:       policy.BaseDelay,
+		MaxDelay:        policy.MaxDelay,
+		LockoutFailures: policy.LockoutFailures,
+		LockoutDuration: policy.LockoutDuration,
+	}
+}
+
+// Load loads Config from the YAML file at path over the default values,
+// overrides it by the environment variables, and validates it.
+// The file is optional, so that path can be empty.
+func Load(path string) (*Config, error) {
+	return load(path, os.LookupEnv)
+}
+
+// load loads Config looking up the environment variables by lookupEnv.
+func load(path string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	c := Default()
+
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read config file")
+		}
+
+		if err := yaml.UnmarshalStrict(data, c); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal config file")
+		}
+	}
+
+	if err := applyEnv(c, lookupEnv); err != nil {
+		return nil, errors.Wrap(err, "failed to apply environment variables")
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Validate validates Config, and returns the error which has all of the invalid values.
+func (c *Config) Validate() error {
+	var invalid []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			invalid = append(invalid, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(c.Server.Addr != "", "server.addr is required")
+	check((c.Server.TLS.CertFile == "") == (c.Server.TLS.KeyFile == ""), "server.tls.certFile and server.tls.keyFile must be given together")
+	check(c.Server.ReadTimeout >= 0 && c.Server.WriteTimeout >= 0, "server.readTimeout and server.writeTimeout must not be negative")
+	check(c.Server.ReadHeaderTimeout > 0, "server.readHeaderTimeout must be positive")
+	check(c.Server.IdleTimeout >= 0, "server.idleTimeout must not be negative")
+	check(c.Server.MaxHeaderBytes > 0, "server.maxHeaderBytes must be positive")
+	check(c.Server.ShutdownDelay >= 0, "server.shutdownDelay must not be negative")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdownTimeout must be positive")
+	_, err := c.Server.TrustedProxyNets()
+	check(err == nil, "server.trustedProxies is invalid, %v", err)
+
+	check(c.DB.DSN != "", "db.dsn is required")
+	check(c.DB.MaxOpenConns >= 0, "db.maxOpenConns must not be negative")
+	check(c.DB.MaxIdleConns >= 0, "db.maxIdleConns must not be negative")
+	check(c.DB.MaxOpenConns == 0 || c.DB.MaxIdleConns <= c.DB.MaxOpenConns, "db.maxIdleConns must not be more than db.maxOpenConns")
+	check(c.DB.ConnMaxLifetime >= 0, "db.connMaxLifetime must not be negative")
+	check(c.DB.MigrationLockTimeout > 0, "db.migrationLockTimeout must be positive")
+
+	check(c.Health.Timeout > 0, "health.timeout must be positive")
+	check(!c.Metrics.Enabled || strings.HasPrefix(c.Metrics.Path, "/"), "metrics.path must start with /, %s", c.Metrics.Path)
+	check(c.Tracing.Exporter == ExporterNone || c.Tracing.Exporter == ExporterStdout || c.Tracing.Exporter == ExporterFile,
+		"tracing.exporter must be %s, %s or %s, %s", ExporterNone, ExporterStdout, ExporterFile, c.Tracing.Exporter)
+	check(c.Tracing.Exporter != ExporterFile || c.Tracing.FilePath != "", "tracing.filePath is required when tracing.exporter is %s", ExporterFile)
+	check(c.Tracing.ServiceName != "", "tracing.serviceName is required")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sampleRatio must be from 0 to 1, %v", c.Tracing.SampleRatio)
+
+	_, err = c.Log.ZapLevel()
+	check(err == nil, "log.level is invalid, %s", c.Log.Level)
+
+	switch strings.ToLower(c.Cookie.SameSite) {
+	case "default", "lax", "strict":
+	default:
+		check(false, "cookie.sameSite must be one of default, lax and strict")
+	}
+
+	check(c.Session.AbsoluteTimeout > 0, "session.absoluteTimeout must be positive")
+	check(c.Session.IdleTimeout > 0, "session.idleTimeout must be positive")
+	check(c.Session.RenewInterval >= 0 && c.Session.RenewInterval < c.Session.IdleTimeout, "session.renewInterval must be less than session.idleTimeout")
+
+	check(c.Password.MinLength > 0, "password.minLength must be positive")
+	check(c.Password.MinCharacterClasses >= 0 && c.Password.MinCharacterClasses <= 4, "password.minCharacterClasses must be between 0 and 4")
+	check(c.Password.Hasher == HasherBcrypt || c.Password.Hasher == HasherArgon2id, "password.hasher must be %s or %s", HasherBcrypt, HasherArgon2id)
+	check(c.Password.BcryptCost >= bcrypt.MinCost && c.Password.BcryptCost <= bcrypt.MaxCost, "password.bcryptCost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+	check(c.Password.Argon2id.Memory > 0 && c.Password.Argon2id.Iterations > 0 && c.Password.Argon2id.Parallelism > 0, "password.argon2id.memory, iterations and parallelism must be positive")
+	check(c.Password.Argon2id.SaltLength >= 8 && c.Password.Argon2id.KeyLength >= 16, "password.argon2id.saltLength must be at least 8, and keyLength must be at least 16")
+
+	rules := []struct {
+		name string
+		rule RateLimitRule
+	}{
+		{name: "global", rule: c.RateLimit.Global},
+		{name: "authentication", rule: c.RateLimit.Authentication},
+		{name: "user", rule: c.RateLimit.User},
+		{name: "post", rule: c.RateLimit.Post},
+	}
+	for _, r := range rules {
+		check(r.rule.Requests > 0 && r.rule.Period > 0, "rateLimit.%s.requests and period must be positive", r.name)
+	}
+
+	check(c.LoginAttempt.Store == StoreMySQL || c.LoginAttempt.Store == StoreMemory, "loginAttempt.store must be %s or %s", StoreMySQL, StoreMemory)
+	policies := []struct {
+		name   string
+		policy LoginAttemptPolicy
+	}{
+		{name: "user", policy: c.LoginAttempt.User},
+		{name: "ip", policy: c.LoginAttempt.IP},
+	}
+	for _, p := range policies {
+		check(p.policy.FreeFailures >= 0 && p.policy.LockoutFailures > p.policy.FreeFailures, "loginAttempt.%s.lockoutFailures must be more than freeFailures", p.name)
+		check(p.policy.BaseDelay > 0 && p.policy.MaxDelay >= p.policy.BaseDelay, "loginAttempt.%s.maxDelay must not be less than baseDelay", p.name)
+		check(p.policy.LockoutDuration > 0, "loginAttempt.%s.lockoutDuration must be positive", p.name)
+	}
+
+	check(c.SoftDelete.Retention >= 0, "softDelete.retention must not be negative")
+	check(c.SoftDelete.PurgeInterval > 0 && c.SoftDelete.PurgeBatchSize > 0, "softDelete.purgeInterval and purgeBatchSize must be positive")
+
+	if len(invalid) > 0 {
+		return errors.Errorf("invalid config: %s", strings.Join(invalid, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// lookupEnvForTest returns the function which looks up env.
+func lookupEnvForTest(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+}
+
+// writeConfigForTest writes the config file, and returns the path of it.
+func writeConfigForTest(t *testing.T, content string) string {
+	f, err := ioutil.TempFile("", "config*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return f.Name()
+}
+
+// removeForTest removes the file written by writeConfigForTest.
+func removeForTest(t *testing.T, path string) {
+	if err := os.Remove(path); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLoad_example(t *testing.T) {
+	got, err := load("../config.example.yaml", lookupEnvForTest(nil))
+	if err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	if want := Default(); !reflect.DeepEqual(got, want) {
+		t.Errorf("load() = %+v, want the default %+v", got, want)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := writeConfigForTest(t, `
+server:
+  addr: ":9090"
+db:
+  maxOpenConns: 10
+  maxIdleConns: 5
+log:
+  level: info
+rateLimit:
+  user:
+    requests: 100
+`)
+	defer removeForTest(t, path)
+
+	env := map[string]string{
+		"NVGC_DB_DSN":                   "user:pass@tcp(db:3306)/chat",
+		"NVGC_DB_MAX_IDLE_CONNS":        "10",
+		"NVGC_COOKIE_SECURE":            "true",
+		"NVGC_SESSION_IDLE_TIMEOUT":     "2h",
+		"NVGC_PASSWORD_ARGON2ID_MEMORY": "32768",
+		"NVGC_LOGIN_ATTEMPT_STORE":      StoreMemory,
+		"NVGC_RATE_LIMIT_POST_REQUESTS": "5",
+		"NVGC_SERVER_TLS_CERT_FILE":     "cert.pem",
+		"NVGC_SERVER_TLS_KEY_FILE":      "key.pem",
+		"NVGC_TRACING_EXPORTER":         ExporterStdout,
+		"NVGC_TRACING_SAMPLE_RATIO":     "0.25",
+		"NVGC_SOFT_DELETE_RETENTION":    "168h",
+		"NVGC_SERVER_TRUSTED_PROXIES":   "10.0.0.0/8, 192.0.2.1",
+	}
+
+	got, err := load(path, lookupEnvForTest(env))
+	if err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	want := Default()
+	want.Server.Addr = ":9090"
+	want.Server.TLS = TLS{CertFile: "cert.pem", KeyFile: "key.pem"}
+	want.DB.DSN = "user:pass@tcp(db:3306)/chat"
+	want.DB.MaxOpenConns = 10
+	want.DB.MaxIdleConns = 10
+	want.Log.Level = "info"
+	want.Cookie.Secure = true
+	want.Session.IdleTimeout = 2 * time.Hour
+	want.Password.Argon2id.Memory = 32768
+	want.LoginAttempt.Store = StoreMemory
+	want.RateLimit.User.Requests = 100
+	want.RateLimit.Post.Requests = 5
+	want.Tracing.Exporter = ExporterStdout
+	want.Tracing.SampleRatio = 0.25
+	want.SoftDelete.Retention = 7 * 24 * time.Hour
+	want.Server.TrustedProxies = []string{"10.0.0.0/8", "192.0.2.1"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("load() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoad_error(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		env     map[string]string
+		wantErr []string
+	}{
+		{
+			name:    "When the file has an unknown key, returns error",
+			content: "server:\n  port: 8080\n",
+			wantErr: []string{"failed to unmarshal config file"},
+		},
+		{
+			name:    "When the environment variable can not be parsed, returns error",
+			env:     map[string]string{"NVGC_DB_MAX_OPEN_CONNS": "many"},
+			wantErr: []string{"NVGC_DB_MAX_OPEN_CONNS"},
+		},
+		{
+			name:    "When some values are invalid, returns error which has all of them",
+			content: "db:\n  dsn: \"\"\n  maxOpenConns: 5\nlog:\n  level: verbose\npassword:\n  hasher: md5\n",
+			wantErr: []string{
+				"db.dsn is required",
+				"db.maxIdleConns must not be more than db.maxOpenConns",
+				"log.level is invalid, verbose",
+				"password.hasher must be bcrypt or argon2id",
+			},
+		},
+		{
+			name:    "When the tracing is invalid, returns error",
+			content: "tracing:\n  exporter: file\n  filePath: \"\"\n  sampleRatio: 2\n",
+			wantErr: []string{
+				"tracing.filePath is required when tracing.exporter is file",
+				"tracing.sampleRatio must be from 0 to 1, 2",
+			},
+		},
+		{
+			name:    "When the trusted proxy is neither IP nor CIDR, returns error",
+			env:     map[string]string{"NVGC_SERVER_TRUSTED_PROXIES": "10.0.0.0/8,proxy"},
+			wantErr: []string{"server.trustedProxies is invalid, proxy is neither IP nor CIDR"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := ""
+			if tt.content != "" {
+				path = writeConfigForTest(t, tt.content)
+				defer removeForTest(t, path)
+			}
+
+			_, err := load(path, lookupEnvForTest(tt.env))
+			if err == nil {
+				t.Fatal("load() error = nil, want error")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("load() error = %v, want containing %s", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_TrustedProxyNets(t *testing.T) {
+	s := Server{TrustedProxies: []string{"10.0.0.0/8", "192.0.2.1", "2001:db8::1"}}
+
+	got, err := s.TrustedProxyNets()
+	if err != nil {
+		t.Fatalf("Server.TrustedProxyNets() error = %v", err)
+	}
+
+	want := []string{"10.0.0.0/8", "192.0.2.1/32", "2001:db8::1/128"}
+	if len(got) != len(want) {
+		t.Fatalf("Server.TrustedProxyNets() = %v, want %v", got, want)
+	}
+	for i, n := range got {
+		if n.String() != want[i] {
+			t.Errorf("Server.TrustedProxyNets()[%d] = %s, want %s", i, n, want[i])
+		}
+	}
+}
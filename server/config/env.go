@@ -0,0 +1,115 @@
+package config
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// durationType is the type of time.Duration.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// applyEnv overrides the values of c by the environment variables named after the yaml keys.
+func applyEnv(c *Config, lookupEnv func(string) (string, bool)) error {
+	return applyEnvToStruct(reflect.ValueOf(c).Elem(), EnvPrefix, lookupEnv)
+}
+
+// applyEnvToStruct overrides the fields of the struct v by the environment variables which start with prefix.
+func applyEnvToStruct(v reflect.Value, prefix string, lookupEnv func(string) (string, bool)) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := prefix + "_" + envName(field.Tag.Get("yaml"))
+
+		if field.Type.Kind() == reflect.Struct {
+			if err := applyEnvToStruct(v.Field(i), name, lookupEnv); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, ok := lookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		if err := setValue(v.Field(i), value); err != nil {
+			return errors.Wrapf(err, "failed to set %s", name)
+		}
+	}
+
+	return nil
+}
+
+// envName returns the yaml key in upper snake case, e.g. MAX_OPEN_CONNS for maxOpenConns.
+func envName(key string) string {
+	var b strings.Builder
+	for i, r := range key {
+		if unicode.IsUpper(r) && i > 0 {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// setValue parses value according to the type of v, and sets it.
+func setValue(v reflect.Value, value string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return errors.Errorf("unsupported type, %s", v.Type())
+		}
+		// the elements are separated by commas, e.g. 10.0.0.0/8,192.0.2.1.
+		elems := []string{}
+		for _, elem := range strings.Split(value, ",") {
+			if elem = strings.TrimSpace(elem); elem != "" {
+				elems = append(elems, elem)
+			}
+		}
+		v.Set(reflect.ValueOf(elems))
+	default:
+		return errors.Errorf("unsupported type, %s", v.Type())
+	}
+
+	return nil
+}
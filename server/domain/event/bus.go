@@ -0,0 +1,22 @@
+package event
+
+import "context"
+
+// Handler handles the domain event.
+type Handler func(ctx context.Context, e Event)
+
+// Publisher is the interface which publishes domain events.
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event) error
+}
+
+// Subscriber is the interface which subscribes domain events.
+type Subscriber interface {
+	Subscribe(name Name, handler Handler) (unsubscribe func())
+}
+
+// Bus is the interface of the domain event bus.
+type Bus interface {
+	Publisher
+	Subscriber
+}
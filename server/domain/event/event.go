@@ -0,0 +1,154 @@
+package event
+
+import "github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+
+// Name is name of the domain event.
+type Name string
+
+// names of the domain event.
+const (
+	NameThreadCreated   Name = "thread.created"
+	NameThreadUpdated   Name = "thread.updated"
+	NameThreadDeleted   Name = "thread.deleted"
+	NameThreadRestored  Name = "thread.restored"
+	NameCommentCreated  Name = "comment.created"
+	NameCommentUpdated  Name = "comment.updated"
+	NameCommentDeleted  Name = "comment.deleted"
+	NameCommentRestored Name = "comment.restored"
+	NameUserBanned      Name = "user.banned"
+	NameLoggedOut       Name = "user.loggedOut"
+)
+
+// Event is the interface of the domain event.
+type Event interface {
+	EventName() Name
+}
+
+// ThreadCreated is the event which occurs when the thread has been created.
+type ThreadCreated struct {
+	Thread *model.Thread `json:"thread"`
+}
+
+// EventName returns name of the event.
+func (e *ThreadCreated) EventName() Name {
+	return NameThreadCreated
+}
+
+// ThreadUpdated is the event which occurs when the thread has been updated.
+type ThreadUpdated struct {
+	Thread *model.Thread `json:"thread"`
+}
+
+// EventName returns name of the event.
+func (e *ThreadUpdated) EventName() Name {
+	return NameThreadUpdated
+}
+
+// ThreadDeleted is the event which occurs when the thread has been deleted.
+type ThreadDeleted struct {
+	ThreadID uint32 `json:"threadId"`
+}
+
+// EventName returns name of the event.
+func (e *ThreadDeleted) EventName() Name {
+	return NameThreadDeleted
+}
+
+// ThreadRestored is the event which occurs when the deleted thread has been restored.
+type ThreadRestored struct {
+	Thread *model.Thread `json:"thread"`
+}
+
+// EventName returns name of the event.
+func (e *ThreadRestored) EventName() Name {
+	return NameThreadRestored
+}
+
+// CommentCreated is the event which occurs when the comment has been created.
+type CommentCreated struct {
+	Comment *model.Comment `json:"comment"`
+}
+
+// EventName returns name of the event.
+func (e *CommentCreated) EventName() Name {
+	return NameCommentCreated
+}
+
+// CommentUpdated is the event which occurs when the comment has been updated.
+type CommentUpdated struct {
+	Comment *model.Comment `json:"comment"`
+}
+
+// EventName returns name of the event.
+func (e *CommentUpdated) EventName() Name {
+	return NameCommentUpdated
+}
+
+// CommentDeleted is the event which occurs when the comment has been deleted.
+type CommentDeleted struct {
+	Comment *model.Comment `json:"comment"`
+}
+
+// EventName returns name of the event.
+func (e *CommentDeleted) EventName() Name {
+	return NameCommentDeleted
+}
+
+// CommentRestored is the event which occurs when the deleted comment has been restored.
+type CommentRestored struct {
+	Comment *model.Comment `json:"comment"`
+}
+
+// EventName returns name of the event.
+func (e *CommentRestored) EventName() Name {
+	return NameCommentRestored
+}
+
+// UserBanned is the event which occurs when the user has been banned.
+type UserBanned struct {
+	UserID uint32 `json:"userId"`
+}
+
+// EventName returns name of the event.
+func (e *UserBanned) EventName() Name {
+	return NameUserBanned
+}
+
+// LoggedOut is the event which occurs when the user has logged out and the session has been deleted.
+type LoggedOut struct {
+	SessionID string `json:"sessionId"`
+}
+
+// EventName returns name of the event.
+func (e *LoggedOut) EventName() Name {
+	return NameLoggedOut
+}
+
+// New generates and returns the empty event of the given name.
+// It is used to decode events received from other server instances.
+func New(name Name) (Event, bool) {
+	switch name {
+	case NameThreadCreated:
+		return &ThreadCreated{}, true
+	case NameThreadUpdated:
+		return &ThreadUpdated{}, true
+	case NameThreadDeleted:
+		return &ThreadDeleted{}, true
+	case NameThreadRestored:
+		return &ThreadRestored{}, true
+	case NameCommentCreated:
+		return &CommentCreated{}, true
+	case NameCommentUpdated:
+		return &CommentUpdated{}, true
+	case NameCommentDeleted:
+		return &CommentDeleted{}, true
+	case NameCommentRestored:
+		return &CommentRestored{}, true
+	case NameUserBanned:
+		return &UserBanned{}, true
+	case NameLoggedOut:
+		return &LoggedOut{}, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// settings of APIToken.
+const (
+	// APITokenPrefix is the prefix of API tokens, which makes leaked tokens easy to find.
+	APITokenPrefix = "nvgc_"
+	// APITokenNameMaxLength is the max length of the name of APIToken.
+	APITokenNameMaxLength = 64
+	// APITokenTouchInterval is the interval to update LastUsedAt,
+	// so that every request does not write to DB.
+	APITokenTouchInterval = time.Minute
+)
+
+// APIToken is the personal API token which the user mints for CLI tools and bots.
+// Only the hash of the token is stored, and the token itself is shown to the user only once.
+type APIToken struct {
+	ID         uint32
+	UserID     uint32
+	Name       string
+	TokenHash  string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+// MarshalLogObject for zap logger.
+func (t APIToken) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt32("id", int32(t.ID))
+	enc.AddInt32("userID", int32(t.UserID))
+	enc.AddString("name", t.Name)
+	enc.AddTime("createdAt", t.CreatedAt)
+	enc.AddTime("lastUsedAt", t.LastUsedAt)
+	return nil
+}
+
+// ShouldTouch returns whether LastUsedAt should be updated or not.
+func (t *APIToken) ShouldTouch(now time.Time) bool {
+	return now.Sub(t.LastUsedAt) >= APITokenTouchInterval
+}
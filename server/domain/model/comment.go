@@ -8,11 +8,14 @@ import (
 )
 
 // Comment is comment model.
+// Edited is whether the content has been edited, and EditCount is how many times it has been.
 type Comment struct {
 	ID        uint32 `json:"id"`
 	Content   string `json:"content"`
 	ThreadID  uint32 `json:"threadId"`
 	*User     `json:"user"`
+	Edited    bool      `json:"edited"`
+	EditCount uint32    `json:"editCount"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
@@ -25,6 +28,7 @@ func (c Comment) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	if err := enc.AddObject("user", c.User); err != nil {
 		return err
 	}
+	enc.AddUint32("editCount", c.EditCount)
 	enc.AddTime("createdAt", c.CreatedAt)
 	enc.AddTime("updatedAt", c.UpdatedAt)
 	return nil
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// CommentRevision is the content of Comment before it was edited.
+type CommentRevision struct {
+	ID        uint32 `json:"id"`
+	CommentID uint32 `json:"commentId"`
+	Content   string `json:"content"`
+	// EditedBy is the user who has replaced the content.
+	EditedBy *User `json:"editedBy"`
+	// CreatedAt is when the content was written, and EditedAt is when it was replaced.
+	CreatedAt time.Time `json:"createdAt"`
+	EditedAt  time.Time `json:"editedAt"`
+}
+
+// MarshalLogObject for zap logger.
+func (r CommentRevision) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt32("id", int32(r.ID))
+	enc.AddInt32("commentID", int32(r.CommentID))
+	if err := enc.AddObject("editedBy", r.EditedBy); err != nil {
+		return err
+	}
+	enc.AddTime("createdAt", r.CreatedAt)
+	enc.AddTime("editedAt", r.EditedAt)
+	return nil
+}
+
+// CommentRevisionList is list of the revisions of Comment, which are ordered from the oldest.
+type CommentRevisionList struct {
+	Revisions []*CommentRevision `json:"revisions"`
+}
@@ -4,6 +4,8 @@ package model
 const (
 	InvalidID         = 0
 	SessionIDAtCookie = "SESSION_ID"
+	CSRFTokenAtCookie = "CSRF_TOKEN"
+	CSRFTokenHeader   = "X-CSRF-Token"
 )
 
 // InvalidReason is InvalidReason message for developer.
@@ -28,6 +30,11 @@ const (
 	DomainModelNameSession DomainModelName = "Session"
 	DomainModelNameThread  DomainModelName = "Thread"
 	DomainModelNameComment DomainModelName = "Comment"
+
+	DomainModelNamePasswordResetToken DomainModelName = "PasswordResetToken"
+	DomainModelNameAPIToken           DomainModelName = "APIToken"
+	DomainModelNameLoginAttempt       DomainModelName = "LoginAttempt"
+	DomainModelNameCommentRevision    DomainModelName = "CommentRevision"
 )
 
 // PropertyName is property name for developer.
@@ -45,6 +52,9 @@ const (
 	TitleProperty    PropertyName = "Title"
 	PassWordProperty PropertyName = "Password"
 	ThreadIDProperty PropertyName = "ThreadID"
+	RoleProperty     PropertyName = "Role"
+	TokenProperty    PropertyName = "Token"
+	KeyProperty      PropertyName = "Key"
 )
 
 // FailedToBeginTx is error of tx begin.
@@ -62,6 +72,8 @@ const (
 const (
 	SessionValidIDForTest   = "testValidSessionID12345678"
 	SessionInValidIDForTest = "testInvalidSessionID12345678"
+	UserAgentForTest        = "testUserAgent"
+	IPForTest               = "192.0.2.1"
 	TitleForTest            = "TitleForTest"
 )
 
@@ -78,6 +90,11 @@ const (
 	CommentContentForTest          = "ContentForTest"
 )
 
+// CommentRevision
+const (
+	CommentRevisionValidIDForTest uint32 = 1
+)
+
 // error message for test
 const (
 	ErrorMessageForTest = "some error has occurred"
@@ -0,0 +1,46 @@
+package model
+
+import "context"
+
+// contextKey is the type of keys of values which are bound into context.Context.
+type contextKey int
+
+// keys of values which are bound into context.Context.
+const (
+	sessionContextKey contextKey = iota
+	userContextKey
+	clientContextKey
+)
+
+// WithSession returns the copy of ctx which holds the session of the user who requested.
+func WithSession(ctx context.Context, session *Session) context.Context {
+	return context.WithValue(ctx, sessionContextKey, session)
+}
+
+// SessionFromContext returns the session of the user who requested.
+func SessionFromContext(ctx context.Context) (*Session, bool) {
+	session, ok := ctx.Value(sessionContextKey).(*Session)
+	return session, ok && session != nil
+}
+
+// WithUser returns the copy of ctx which holds the user who requested.
+func WithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userContextKey, user)
+}
+
+// UserFromContext returns the user who requested.
+func UserFromContext(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(userContextKey).(*User)
+	return user, ok && user != nil
+}
+
+// WithClient returns the copy of ctx which holds the client which requested.
+func WithClient(ctx context.Context, client *Client) context.Context {
+	return context.WithValue(ctx, clientContextKey, client)
+}
+
+// ClientFromContext returns the client which requested.
+func ClientFromContext(ctx context.Context) (*Client, bool) {
+	client, ok := ctx.Value(clientContextKey).(*Client)
+	return client, ok && client != nil
+}
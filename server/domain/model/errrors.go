@@ -3,6 +3,7 @@ package model
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // RepositoryMethod is method of Repository.
@@ -138,3 +139,81 @@ type OtherServerError struct {
 func (e *OtherServerError) Error() string {
 	return e.InvalidReason
 }
+
+// ForbiddenError expresses that the user is not allowed to operate specified data.
+type ForbiddenError struct {
+	BaseErr error
+	UserID  uint32
+	PropertyName
+	PropertyValue interface{}
+	DomainModelName
+}
+
+// Error returns error message.
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("forbidden, %s: %v, %s", e.PropertyName, e.PropertyValue, e.DomainModelName)
+}
+
+// BannedError expresses that the user has been banned.
+type BannedError struct {
+	UserID uint32
+}
+
+// Error returns error message.
+func (e *BannedError) Error() string {
+	return fmt.Sprintf("user has been banned, ID: %d", e.UserID)
+}
+
+// SessionExpiredError expresses that the session has expired.
+type SessionExpiredError struct {
+	SessionID string
+}
+
+// Error returns error message.
+func (e *SessionExpiredError) Error() string {
+	return "session has expired"
+}
+
+// CSRFError expresses that the CSRF token of the request is missing or does not match.
+type CSRFError struct {
+}
+
+// Error returns error message.
+func (e *CSRFError) Error() string {
+	return "csrf token is missing or invalid"
+}
+
+// TooManyAttemptsError expresses that the request must wait for RetryAfter because of too many attempts.
+type TooManyAttemptsError struct {
+	RetryAfter time.Duration
+}
+
+// Error returns error message.
+func (e *TooManyAttemptsError) Error() string {
+	return fmt.Sprintf("too many attempts, retry after %s", e.RetryAfter)
+}
+
+// RetryAfterSeconds returns RetryAfter in seconds for Retry-After header, which is rounded up.
+func (e *TooManyAttemptsError) RetryAfterSeconds() int {
+	return retryAfterSeconds(e.RetryAfter)
+}
+
+// RateLimitError expresses that the request exceeds the rate limit, and must wait for RetryAfter.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+// Error returns error message.
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded, retry after %s", e.RetryAfter)
+}
+
+// RetryAfterSeconds returns RetryAfter in seconds for Retry-After header, which is rounded up.
+func (e *RateLimitError) RetryAfterSeconds() int {
+	return retryAfterSeconds(e.RetryAfter)
+}
+
+// retryAfterSeconds returns d in seconds, which is rounded up.
+func retryAfterSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
@@ -0,0 +1,74 @@
+package model
+
+import "time"
+
+// LoginAttempt is the record of the failed login attempts of a key, i.e. the user name or the client IP.
+type LoginAttempt struct {
+	Key          string
+	Failures     int
+	LastFailedAt time.Time
+}
+
+// LoginAttemptPolicy is the policy of the delay of login attempts after failures.
+// After FreeFailures, the attempts are delayed by BaseDelay which is doubled on every failure up to MaxDelay,
+// and after LockoutFailures, the attempts are locked out for LockoutDuration.
+// The failures are forgotten when LockoutDuration passes after the last failure.
+type LoginAttemptPolicy struct {
+	FreeFailures    int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	LockoutFailures int
+	LockoutDuration time.Duration
+}
+
+// DefaultUserLoginAttemptPolicy returns LoginAttemptPolicy of the user name.
+func DefaultUserLoginAttemptPolicy() LoginAttemptPolicy {
+	return LoginAttemptPolicy{
+		FreeFailures:    3,
+		BaseDelay:       time.Second,
+		MaxDelay:        time.Minute,
+		LockoutFailures: 10,
+		LockoutDuration: 15 * time.Minute,
+	}
+}
+
+// DefaultIPLoginAttemptPolicy returns LoginAttemptPolicy of the client IP.
+// It is looser than the user name, because many users can share an IP.
+func DefaultIPLoginAttemptPolicy() LoginAttemptPolicy {
+	return LoginAttemptPolicy{
+		FreeFailures:    20,
+		BaseDelay:       time.Second,
+		MaxDelay:        time.Minute,
+		LockoutFailures: 100,
+		LockoutDuration: 15 * time.Minute,
+	}
+}
+
+// ForgetBefore returns the time before which the failures are forgotten.
+func (p LoginAttemptPolicy) ForgetBefore(now time.Time) time.Time {
+	return now.Add(-p.LockoutDuration)
+}
+
+// RetryAfter returns how long the next attempt must wait, and 0 if it can be attempted now.
+func (p LoginAttemptPolicy) RetryAfter(attempt *LoginAttempt, now time.Time) time.Duration {
+	if attempt == nil || attempt.Failures <= p.FreeFailures || !attempt.LastFailedAt.After(p.ForgetBefore(now)) {
+		return 0
+	}
+
+	wait := p.LockoutDuration
+	if attempt.Failures < p.LockoutFailures {
+		wait = p.BaseDelay
+		for i := p.FreeFailures + 1; i < attempt.Failures && wait < p.MaxDelay; i++ {
+			wait *= 2
+		}
+		if wait > p.MaxDelay {
+			wait = p.MaxDelay
+		}
+	}
+
+	retryAfter := attempt.LastFailedAt.Add(wait).Sub(now)
+	if retryAfter < 0 {
+		return 0
+	}
+	return retryAfter
+}
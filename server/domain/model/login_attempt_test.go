@@ -0,0 +1,93 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoginAttemptPolicy_RetryAfter(t *testing.T) {
+	policy := LoginAttemptPolicy{
+		FreeFailures:    3,
+		BaseDelay:       time.Second,
+		MaxDelay:        4 * time.Second,
+		LockoutFailures: 10,
+		LockoutDuration: 15 * time.Minute,
+	}
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		attempt *LoginAttempt
+		want    time.Duration
+	}{
+		{
+			name:    "When there is no failure, returns 0",
+			attempt: nil,
+			want:    0,
+		},
+		{
+			name:    "When the failures are not more than FreeFailures, returns 0",
+			attempt: &LoginAttempt{Failures: 3, LastFailedAt: now},
+			want:    0,
+		},
+		{
+			name:    "When the failures exceed FreeFailures by 1, returns BaseDelay",
+			attempt: &LoginAttempt{Failures: 4, LastFailedAt: now},
+			want:    time.Second,
+		},
+		{
+			name:    "When the failures exceed FreeFailures by 2, returns doubled BaseDelay",
+			attempt: &LoginAttempt{Failures: 5, LastFailedAt: now},
+			want:    2 * time.Second,
+		},
+		{
+			name:    "When the delay exceeds MaxDelay, returns MaxDelay",
+			attempt: &LoginAttempt{Failures: 9, LastFailedAt: now},
+			want:    4 * time.Second,
+		},
+		{
+			name:    "When the delay has partly passed, returns the rest",
+			attempt: &LoginAttempt{Failures: 5, LastFailedAt: now.Add(-time.Second)},
+			want:    time.Second,
+		},
+		{
+			name:    "When the delay has passed, returns 0",
+			attempt: &LoginAttempt{Failures: 5, LastFailedAt: now.Add(-time.Minute)},
+			want:    0,
+		},
+		{
+			name:    "When the failures reach LockoutFailures, returns LockoutDuration",
+			attempt: &LoginAttempt{Failures: 10, LastFailedAt: now},
+			want:    15 * time.Minute,
+		},
+		{
+			name:    "When LockoutDuration has passed, returns 0",
+			attempt: &LoginAttempt{Failures: 100, LastFailedAt: now.Add(-15 * time.Minute)},
+			want:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.RetryAfter(tt.attempt, now); got != tt.want {
+				t.Errorf("LoginAttemptPolicy.RetryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTooManyAttemptsError_RetryAfterSeconds(t *testing.T) {
+	tests := []struct {
+		retryAfter time.Duration
+		want       int
+	}{
+		{retryAfter: time.Second, want: 1},
+		{retryAfter: 1500 * time.Millisecond, want: 2},
+		{retryAfter: time.Millisecond, want: 1},
+	}
+	for _, tt := range tests {
+		e := &TooManyAttemptsError{RetryAfter: tt.retryAfter}
+		if got := e.RetryAfterSeconds(); got != tt.want {
+			t.Errorf("TooManyAttemptsError.RetryAfterSeconds() = %v, want %v", got, tt.want)
+		}
+	}
+}
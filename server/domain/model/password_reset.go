@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultPasswordResetTokenTTL is the default lifetime of PasswordResetToken.
+const DefaultPasswordResetTokenTTL = time.Hour
+
+// PasswordResetToken is PasswordResetToken model.
+// Only the hash of the token is stored, and the token itself is delivered to the user.
+type PasswordResetToken struct {
+	TokenHash string
+	UserID    uint32
+	Used      bool
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// MarshalLogObject for zap logger.
+func (t PasswordResetToken) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt32("userID", int32(t.UserID))
+	enc.AddBool("used", t.Used)
+	enc.AddTime("createdAt", t.CreatedAt)
+	enc.AddTime("expiresAt", t.ExpiresAt)
+	return nil
+}
+
+// IsRedeemable returns whether the token can be redeemed or not.
+func (t *PasswordResetToken) IsRedeemable(now time.Time) bool {
+	return !t.Used && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// RateLimit is the limit of requests by token bucket.
+// The bucket holds Limit tokens at most, and is refilled with Limit tokens per Period.
+type RateLimit struct {
+	Limit  int
+	Period time.Duration
+}
+
+// rate returns the number of tokens refilled per second.
+func (l RateLimit) rate() float64 {
+	return float64(l.Limit) / l.Period.Seconds()
+}
+
+// RateLimits is the limits of the route groups.
+type RateLimits struct {
+	// Global limits all requests per client IP.
+	Global RateLimit
+	// Authentication limits the requests to sign up, login and reset password per client IP.
+	Authentication RateLimit
+	// User limits the requests of the authenticated user.
+	User RateLimit
+	// Post limits the requests of the authenticated user to post threads and comments.
+	Post RateLimit
+}
+
+// DefaultRateLimits returns the default RateLimits.
+func DefaultRateLimits() RateLimits {
+	return RateLimits{
+		Global:         RateLimit{Limit: 600, Period: time.Minute},
+		Authentication: RateLimit{Limit: 10, Period: time.Minute},
+		User:           RateLimit{Limit: 300, Period: time.Minute},
+		Post:           RateLimit{Limit: 30, Period: time.Minute},
+	}
+}
+
+// RateLimitBucket is the token bucket of a key.
+type RateLimitBucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// NewRateLimitBucket generates and returns RateLimitBucket which is full.
+func NewRateLimitBucket(limit RateLimit, now time.Time) *RateLimitBucket {
+	return &RateLimitBucket{
+		Tokens:    float64(limit.Limit),
+		UpdatedAt: now,
+	}
+}
+
+// RateLimitStatus is the status of the bucket after a request takes a token.
+type RateLimitStatus struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAt    time.Time
+	RetryAfter time.Duration
+}
+
+// Take refills the bucket and takes a token from it, and returns the status.
+func (b *RateLimitBucket) Take(limit RateLimit, now time.Time) *RateLimitStatus {
+	rate := limit.rate()
+
+	if elapsed := now.Sub(b.UpdatedAt).Seconds(); elapsed > 0 {
+		b.Tokens = math.Min(float64(limit.Limit), b.Tokens+elapsed*rate)
+		b.UpdatedAt = now
+	}
+
+	status := &RateLimitStatus{
+		Limit: limit.Limit,
+	}
+
+	if b.Tokens >= 1 {
+		b.Tokens--
+		status.Allowed = true
+	} else {
+		status.RetryAfter = secondsToDuration((1 - b.Tokens) / rate)
+	}
+
+	status.Remaining = int(b.Tokens)
+	status.ResetAt = now.Add(secondsToDuration((float64(limit.Limit) - b.Tokens) / rate))
+	return status
+}
+
+// IsFull returns whether the bucket has been refilled up to the limit, i.e. it can be forgotten.
+func (b *RateLimitBucket) IsFull(limit RateLimit, now time.Time) bool {
+	return b.Tokens+now.Sub(b.UpdatedAt).Seconds()*limit.rate() >= float64(limit.Limit)
+}
+
+// secondsToDuration converts seconds to time.Duration.
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimitBucket_Take(t *testing.T) {
+	limit := RateLimit{Limit: 2, Period: 2 * time.Second}
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	bucket := NewRateLimitBucket(limit, now)
+
+	tests := []struct {
+		name          string
+		now           time.Time
+		wantAllowed   bool
+		wantRemaining int
+		wantRetry     time.Duration
+		wantResetAt   time.Time
+	}{
+		{
+			name:          "When the bucket is full, the request is allowed",
+			now:           now,
+			wantAllowed:   true,
+			wantRemaining: 1,
+			wantResetAt:   now.Add(time.Second),
+		},
+		{
+			name:          "When the bucket has a token, the request is allowed",
+			now:           now,
+			wantAllowed:   true,
+			wantRemaining: 0,
+			wantResetAt:   now.Add(2 * time.Second),
+		},
+		{
+			name:          "When the bucket is empty, the request is not allowed",
+			now:           now.Add(500 * time.Millisecond),
+			wantAllowed:   false,
+			wantRemaining: 0,
+			wantRetry:     500 * time.Millisecond,
+			wantResetAt:   now.Add(2 * time.Second),
+		},
+		{
+			name:          "When the bucket is refilled, the request is allowed",
+			now:           now.Add(time.Second),
+			wantAllowed:   true,
+			wantRemaining: 0,
+			wantResetAt:   now.Add(3 * time.Second),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bucket.Take(limit, tt.now)
+			if got.Allowed != tt.wantAllowed || got.Remaining != tt.wantRemaining || got.RetryAfter != tt.wantRetry || !got.ResetAt.Equal(tt.wantResetAt) || got.Limit != limit.Limit {
+				t.Errorf("RateLimitBucket.Take() = %#v, want allowed %v, remaining %d, retry after %v, reset at %v",
+					got, tt.wantAllowed, tt.wantRemaining, tt.wantRetry, tt.wantResetAt)
+			}
+		})
+	}
+
+	if !bucket.IsFull(limit, now.Add(3*time.Second)) {
+		t.Error("RateLimitBucket.IsFull() = false, want true after refilled")
+	}
+}
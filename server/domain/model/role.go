@@ -0,0 +1,26 @@
+package model
+
+// Role is role of user.
+type Role string
+
+// roles of user.
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+// String return as string.
+func (r Role) String() string {
+	return string(r)
+}
+
+// IsValid returns whether the role is defined or not.
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleUser, RoleModerator, RoleAdmin:
+		return true
+	default:
+		return false
+	}
+}
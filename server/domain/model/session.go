@@ -1,22 +1,115 @@
 package model
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 
 	"go.uber.org/zap/zapcore"
 )
 
+// Default expiry of sessions.
+const (
+	DefaultSessionAbsoluteTimeout = 30 * 24 * time.Hour
+	DefaultSessionIdleTimeout     = 24 * time.Hour
+	DefaultSessionRenewInterval   = time.Minute
+)
+
+// SessionExpiry is the expiry policy of sessions.
+type SessionExpiry struct {
+	// AbsoluteTimeout is the lifetime since the session was created.
+	AbsoluteTimeout time.Duration
+	// IdleTimeout is the lifetime since the session was accessed last.
+	IdleTimeout time.Duration
+	// RenewInterval is the interval at which the last access is written,
+	// so that not every request writes to the sessions table.
+	RenewInterval time.Duration
+}
+
+// DefaultSessionExpiry returns the default SessionExpiry.
+func DefaultSessionExpiry() SessionExpiry {
+	return SessionExpiry{
+		AbsoluteTimeout: DefaultSessionAbsoluteTimeout,
+		IdleTimeout:     DefaultSessionIdleTimeout,
+		RenewInterval:   DefaultSessionRenewInterval,
+	}
+}
+
+// CookieMaxAge returns max age of the session cookie in seconds.
+func (e SessionExpiry) CookieMaxAge() int {
+	return int(e.IdleTimeout / time.Second)
+}
+
+// max length of the client information which is recorded in sessions.
+const (
+	maxUserAgentLength = 255
+	maxIPLength        = 45
+)
+
+// Client is the client which requested.
+type Client struct {
+	UserAgent string
+	IP        string
+}
+
 // Session is Session model.
 type Session struct {
-	ID        string
-	UserID    uint32
-	CreatedAt time.Time
+	ID             string
+	UserID         uint32
+	UserAgent      string
+	IP             string
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+	LastAccessedAt time.Time
 }
 
 // MarshalLogObject for zap logger.
 func (s Session) MarshalLogObject(enc zapcore.ObjectEncoder) error {
-	enc.AddString("id", s.ID)
+	enc.AddString("id", s.PublicID())
 	enc.AddInt32("userID", int32(s.UserID))
+	enc.AddString("userAgent", s.UserAgent)
+	enc.AddString("ip", s.IP)
 	enc.AddTime("createdAt", s.CreatedAt)
+	enc.AddTime("expiresAt", s.ExpiresAt)
+	enc.AddTime("lastAccessedAt", s.LastAccessedAt)
 	return nil
 }
+
+// IsExpired returns whether the session has passed the absolute expiry or has been idle too long.
+func (s *Session) IsExpired(now time.Time, expiry SessionExpiry) bool {
+	if !now.Before(s.ExpiresAt) {
+		return true
+	}
+
+	return !now.Before(s.LastAccessedAt.Add(expiry.IdleTimeout))
+}
+
+// ShouldRenew returns whether the last access of the session should be written.
+func (s *Session) ShouldRenew(now time.Time, expiry SessionExpiry) bool {
+	return !now.Before(s.LastAccessedAt.Add(expiry.RenewInterval))
+}
+
+// SetClient records the client information into the session.
+func (s *Session) SetClient(client *Client) {
+	if client == nil {
+		return
+	}
+
+	s.UserAgent = truncate(client.UserAgent, maxUserAgentLength)
+	s.IP = truncate(client.IP, maxIPLength)
+}
+
+// PublicID returns the identifier of the session which can be shown to clients.
+// The session id itself is a credential, so it is never exposed except for the cookie.
+func (s *Session) PublicID() string {
+	sum := sha256.Sum256([]byte(s.ID))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// truncate truncates s to max bytes.
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSession_IsExpired(t *testing.T) {
+	now := time.Now()
+	expiry := DefaultSessionExpiry()
+
+	tests := []struct {
+		name    string
+		session *Session
+		want    bool
+	}{
+		{
+			name: "When the session is alive, returns false",
+			session: &Session{
+				ExpiresAt:      now.Add(time.Hour),
+				LastAccessedAt: now.Add(-time.Minute),
+			},
+			want: false,
+		},
+		{
+			name: "When the session has passed the absolute expiry, returns true",
+			session: &Session{
+				ExpiresAt:      now.Add(-time.Second),
+				LastAccessedAt: now,
+			},
+			want: true,
+		},
+		{
+			name: "When the session has been idle longer than idle timeout, returns true",
+			session: &Session{
+				ExpiresAt:      now.Add(time.Hour),
+				LastAccessedAt: now.Add(-expiry.IdleTimeout),
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.session.IsExpired(now, expiry); got != tt.want {
+				t.Errorf("Session.IsExpired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSession_ShouldRenew(t *testing.T) {
+	now := time.Now()
+	expiry := DefaultSessionExpiry()
+
+	tests := []struct {
+		name    string
+		session *Session
+		want    bool
+	}{
+		{
+			name: "When the session has been accessed within renew interval, returns false",
+			session: &Session{
+				LastAccessedAt: now.Add(-expiry.RenewInterval / 2),
+			},
+			want: false,
+		},
+		{
+			name: "When the session has not been accessed within renew interval, returns true",
+			session: &Session{
+				LastAccessedAt: now.Add(-expiry.RenewInterval),
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.session.ShouldRenew(now, expiry); got != tt.want {
+				t.Errorf("Session.ShouldRenew() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
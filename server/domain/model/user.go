@@ -10,8 +10,10 @@ import (
 type User struct {
 	ID        uint32    `json:"id"`
 	Name      string    `json:"name" binding:"required"`
-	SessionID string    `json:"sessionId"`
+	SessionID string    `json:"sessionId"` // the session which has been just created, which is not stored in users.
 	Password  string    `json:"password" binding:"required"`
+	Role      Role      `json:"role,omitempty"`
+	Banned    bool      `json:"banned,omitempty"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
@@ -22,7 +24,44 @@ func (u User) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddString("name", u.Name)
 	enc.AddString("sessionID", u.SessionID)
 	enc.AddString("password", u.Password)
+	enc.AddString("role", u.Role.String())
+	enc.AddBool("banned", u.Banned)
 	enc.AddTime("createdAt", u.CreatedAt)
 	enc.AddTime("updatedAt", u.UpdatedAt)
 	return nil
 }
+
+// HasRole returns whether the user has one of the roles.
+func (u *User) HasRole(roles ...Role) bool {
+	for _, role := range roles {
+		if u.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// UserList is list of user.
+type UserList struct {
+	Users   []*User `json:"users"`
+	HasNext bool    `json:"hasNext"`
+	Cursor  uint32  `json:"cursor"`
+}
+
+// MarshalLogObject for zap logger.
+func (ul UserList) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if err := enc.AddArray("users", zapcore.ArrayMarshalerFunc(func(inner zapcore.ArrayEncoder) error {
+		for _, u := range ul.Users {
+			if err := inner.AppendObject(u); err != nil {
+				return err
+			}
+		}
+		return nil
+	})); err != nil {
+		return err
+	}
+
+	enc.AddBool("hasNext", ul.HasNext)
+	enc.AddInt32("cursor", int32(ul.Cursor))
+	return nil
+}
@@ -0,0 +1,180 @@
+package model
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// UserNamePolicy is the policy of the name of users.
+// The name is compared after it is normalized by NormalizeUserName.
+type UserNamePolicy struct {
+	MinLength     int
+	MaxLength     int
+	ReservedNames []string
+}
+
+// DefaultUserNamePolicy returns UserNamePolicy.
+// MaxLength is the length of the column of users.name.
+func DefaultUserNamePolicy() UserNamePolicy {
+	return UserNamePolicy{
+		MinLength: 3,
+		MaxLength: 30,
+		ReservedNames: []string{
+			"admin", "administrator", "root", "system", "support", "moderator", "me", "null", "undefined",
+		},
+	}
+}
+
+// NormalizeUserName returns the name in NFKC,
+// so that the names which look the same, e.g. the full-width and the half-width, are the same.
+func NormalizeUserName(name string) string {
+	return norm.NFKC.String(strings.TrimSpace(name))
+}
+
+// Validate returns the errors of all rules which the normalized name violates.
+func (p UserNamePolicy) Validate(name string) []*InvalidParamError {
+	var errs []*InvalidParamError
+	invalid := func(reason string) {
+		errs = append(errs, &InvalidParamError{
+			PropertyName:  NameProperty,
+			PropertyValue: name,
+			InvalidReason: reason,
+		})
+	}
+
+	length := utf8.RuneCountInString(name)
+	if length < p.MinLength {
+		invalid(fmt.Sprintf("name must be at least %d characters", p.MinLength))
+	}
+	if length > p.MaxLength {
+		invalid(fmt.Sprintf("name must be at most %d characters", p.MaxLength))
+	}
+
+	for _, r := range name {
+		if !isUserNameRune(r) {
+			invalid("name can contain only letters, digits, '_', '-' and '.'")
+			break
+		}
+	}
+
+	for _, reserved := range p.ReservedNames {
+		if strings.EqualFold(name, reserved) {
+			invalid("name is reserved")
+			break
+		}
+	}
+
+	return errs
+}
+
+// isUserNameRune returns whether r can be used in the name of users.
+func isUserNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.'
+}
+
+// PasswordDenyList is the set of the passwords which are too common to be used.
+// The passwords are lower-cased.
+type PasswordDenyList map[string]struct{}
+
+// ReadPasswordDenyList reads PasswordDenyList which has a password per line.
+// Blank lines and lines starting with '#' are skipped.
+func ReadPasswordDenyList(r io.Reader) (PasswordDenyList, error) {
+	list := PasswordDenyList{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		list[strings.ToLower(line)] = struct{}{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Contains returns whether the password is in the list regardless of the case.
+func (l PasswordDenyList) Contains(password string) bool {
+	_, ok := l[strings.ToLower(password)]
+	return ok
+}
+
+// PasswordPolicy is the policy of the password of users.
+// MaxBytes exists because bcrypt ignores the bytes after 72 bytes.
+// MinCharacterClasses is the number of the classes, i.e. lower, upper, digit and symbol, which the password must contain.
+type PasswordPolicy struct {
+	MinLength           int
+	MaxBytes            int
+	MinCharacterClasses int
+	DenyList            PasswordDenyList
+}
+
+// DefaultPasswordPolicy returns PasswordPolicy without DenyList.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:           8,
+		MaxBytes:            72,
+		MinCharacterClasses: 2,
+	}
+}
+
+// Validate returns the errors of all rules which the password of the user named userName violates.
+// The password is never contained in the errors.
+func (p PasswordPolicy) Validate(userName, password string) []*InvalidParamError {
+	var errs []*InvalidParamError
+	invalid := func(reason string) {
+		errs = append(errs, &InvalidParamError{
+			PropertyName:  PassWordProperty,
+			InvalidReason: reason,
+		})
+	}
+
+	if utf8.RuneCountInString(password) < p.MinLength {
+		invalid(fmt.Sprintf("password must be at least %d characters", p.MinLength))
+	}
+	if len(password) > p.MaxBytes {
+		invalid(fmt.Sprintf("password must be at most %d bytes", p.MaxBytes))
+	}
+
+	if characterClasses(password) < p.MinCharacterClasses {
+		invalid(fmt.Sprintf("password must contain at least %d of lower-case letters, upper-case letters, digits and symbols", p.MinCharacterClasses))
+	}
+
+	if p.DenyList.Contains(password) {
+		invalid("password is too common")
+	}
+
+	if userName != "" && strings.EqualFold(password, userName) {
+		invalid("password must not be the same as name")
+	}
+
+	return errs
+}
+
+// characterClasses returns the number of the character classes which s contains.
+func characterClasses(s string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
@@ -0,0 +1,161 @@
+package model
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// invalidReasons returns the reasons of errs.
+func invalidReasons(errs []*InvalidParamError) []string {
+	var reasons []string
+	for _, err := range errs {
+		reasons = append(reasons, err.InvalidReason)
+	}
+	return reasons
+}
+
+func TestNormalizeUserName(t *testing.T) {
+	tests := []struct {
+		name     string
+		userName string
+		want     string
+	}{
+		{
+			name:     "When the name is ASCII, returns it as it is",
+			userName: "testUser",
+			want:     "testUser",
+		},
+		{
+			name:     "When the name has full-width characters, returns them in half-width",
+			userName: "ｔｅｓｔＵｓｅｒ１",
+			want:     "testUser1",
+		},
+		{
+			name:     "When the name has spaces around it, returns it trimmed",
+			userName: " testUser ",
+			want:     "testUser",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeUserName(tt.userName); got != tt.want {
+				t.Errorf("NormalizeUserName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserNamePolicy_Validate(t *testing.T) {
+	policy := UserNamePolicy{
+		MinLength:     3,
+		MaxLength:     10,
+		ReservedNames: []string{"admin"},
+	}
+
+	tests := []struct {
+		name     string
+		userName string
+		want     []string
+	}{
+		{
+			name:     "When the name follows the policy, returns no error",
+			userName: "test_user",
+			want:     nil,
+		},
+		{
+			name:     "When the name has letters other than ASCII, returns no error",
+			userName: "テストユーザー",
+			want:     nil,
+		},
+		{
+			name:     "When the name is too short and has invalid characters, returns all errors",
+			userName: "a!",
+			want: []string{
+				"name must be at least 3 characters",
+				"name can contain only letters, digits, '_', '-' and '.'",
+			},
+		},
+		{
+			name:     "When the name is too long, returns error",
+			userName: strings.Repeat("a", 11),
+			want:     []string{"name must be at most 10 characters"},
+		},
+		{
+			name:     "When the name is reserved regardless of the case, returns error",
+			userName: "Admin",
+			want:     []string{"name is reserved"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := invalidReasons(policy.Validate(tt.userName)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserNamePolicy.Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	denyList, err := ReadPasswordDenyList(strings.NewReader("# comment\n\nPassword1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := DefaultPasswordPolicy()
+	policy.DenyList = denyList
+
+	tests := []struct {
+		name     string
+		userName string
+		password string
+		want     []string
+	}{
+		{
+			name:     "When the password follows the policy, returns no error",
+			userName: UserNameForTest,
+			password: "correct-horse",
+			want:     nil,
+		},
+		{
+			name:     "When the password is empty, returns all errors",
+			userName: UserNameForTest,
+			password: "",
+			want: []string{
+				"password must be at least 8 characters",
+				"password must contain at least 2 of lower-case letters, upper-case letters, digits and symbols",
+			},
+		},
+		{
+			name:     "When the password is longer than bcrypt can hash, returns error",
+			userName: UserNameForTest,
+			password: strings.Repeat("aA", 37),
+			want:     []string{"password must be at most 72 bytes"},
+		},
+		{
+			name:     "When the password is in the deny list regardless of the case, returns error",
+			userName: UserNameForTest,
+			password: "PASSWORD1",
+			want:     []string{"password is too common"},
+		},
+		{
+			name:     "When the password is the same as the name, returns error",
+			userName: "testUser1",
+			password: "TestUser1",
+			want:     []string{"password must not be the same as name"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := policy.Validate(tt.userName, tt.password)
+			if got := invalidReasons(errs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PasswordPolicy.Validate() = %v, want %v", got, tt.want)
+			}
+			for _, err := range errs {
+				if err.PropertyValue != nil {
+					t.Errorf("PasswordPolicy.Validate() contains the password %v", err.PropertyValue)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestUserList_MarshalLogObject(t *testing.T) {
+	tests := []struct {
+		name      string
+		ul        UserList
+		wantNames []string
+	}{
+		{
+			name: "When users are given, logs them as an array",
+			ul: UserList{
+				Users: []*User{
+					{ID: 1, Name: "alice"},
+					{ID: 2, Name: "bob"},
+				},
+				HasNext: true,
+				Cursor:  2,
+			},
+			wantNames: []string{"alice", "bob"},
+		},
+		{
+			name:      "When no users are given, logs an empty array",
+			ul:        UserList{},
+			wantNames: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := zapcore.NewMapObjectEncoder()
+			if err := tt.ul.MarshalLogObject(enc); err != nil {
+				t.Fatalf("UserList.MarshalLogObject() error = %v", err)
+			}
+
+			users, ok := enc.Fields["users"].([]interface{})
+			if !ok {
+				t.Fatalf("UserList.MarshalLogObject() users = %#v, want array", enc.Fields["users"])
+			}
+			if len(users) != len(tt.wantNames) {
+				t.Fatalf("UserList.MarshalLogObject() len(users) = %d, want %d", len(users), len(tt.wantNames))
+			}
+			for i, u := range users {
+				if got := u.(map[string]interface{})["name"]; got != tt.wantNames[i] {
+					t.Errorf("UserList.MarshalLogObject() users[%d].name = %v, want %v", i, got, tt.wantNames[i])
+				}
+			}
+			if got := enc.Fields["hasNext"]; got != tt.ul.HasNext {
+				t.Errorf("UserList.MarshalLogObject() hasNext = %v, want %v", got, tt.ul.HasNext)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+)
+
+// APITokenRepository is repository of API token.
+type APITokenRepository interface {
+	GetAPITokenByHash(ctx context.Context, m query.SQLManager, tokenHash string) (*model.APIToken, error)
+	ListAPITokensByUserID(ctx context.Context, m query.SQLManager, userID uint32) ([]*model.APIToken, error)
+	InsertAPIToken(ctx context.Context, m query.SQLManager, token *model.APIToken) (uint32, error)
+	TouchAPIToken(ctx context.Context, m query.SQLManager, id uint32, lastUsedAt time.Time) error
+	DeleteAPIToken(ctx context.Context, m query.SQLManager, userID, id uint32) error
+	DeleteAPITokensByUserID(ctx context.Context, m query.SQLManager, userID uint32) (int64, error)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
 
@@ -12,7 +13,13 @@ import (
 type CommentRepository interface {
 	ListComments(ctx context.Context, m query.SQLManager, threadID uint32, limit int, cursor uint32) (*model.CommentList, error)
 	GetCommentByID(ctx context.Context, m query.SQLManager, id uint32) (*model.Comment, error)
+	GetCommentByIDForUpdate(ctx context.Context, m query.SQLManager, id uint32) (*model.Comment, error)
+	GetDeletedCommentByID(ctx context.Context, m query.SQLManager, id uint32) (*model.Comment, error)
 	InsertComment(ctx context.Context, m query.SQLManager, comment *model.Comment) (uint32, error)
 	UpdateComment(ctx context.Context, m query.SQLManager, id uint32, comment *model.Comment) error
-	DeleteComment(ctx context.Context, m query.SQLManager, id uint32) error
+	DeleteComment(ctx context.Context, m query.SQLManager, id, deletedBy uint32, deletedAt time.Time) error
+	DeleteCommentsByThreadID(ctx context.Context, m query.SQLManager, threadID, deletedBy uint32, deletedAt time.Time) (int64, error)
+	RestoreComment(ctx context.Context, m query.SQLManager, id uint32) error
+	RestoreCommentsByThreadID(ctx context.Context, m query.SQLManager, threadID uint32) (int64, error)
+	PurgeDeletedComments(ctx context.Context, m query.SQLManager, deletedBefore time.Time, limit int) (int64, error)
 }
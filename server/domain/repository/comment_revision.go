@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+)
+
+// CommentRevisionRepository is Repository of CommentRevision.
+type CommentRevisionRepository interface {
+	ListCommentRevisions(ctx context.Context, m query.SQLManager, commentID uint32) ([]*model.CommentRevision, error)
+	InsertCommentRevision(ctx context.Context, m query.SQLManager, revision *model.CommentRevision) (uint32, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+)
+
+// LoginAttemptRepository is repository of login attempt.
+type LoginAttemptRepository interface {
+	GetLoginAttempt(ctx context.Context, m query.SQLManager, key string) (*model.LoginAttempt, error)
+	IncrementLoginAttempt(ctx context.Context, m query.SQLManager, key string, now, forgetBefore time.Time) error
+	DeleteLoginAttempt(ctx context.Context, m query.SQLManager, key string) error
+}
@@ -10,6 +10,7 @@ import (
 	model "github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	query "github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
 	reflect "reflect"
+	time "time"
 )
 
 // MockCommentRepository is a mock of CommentRepository interface
@@ -65,6 +66,36 @@ func (mr *MockCommentRepositoryMockRecorder) GetCommentByID(ctx, m, id interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentByID", reflect.TypeOf((*MockCommentRepository)(nil).GetCommentByID), ctx, m, id)
 }
 
+// GetCommentByIDForUpdate mocks base method
+func (m_2 *MockCommentRepository) GetCommentByIDForUpdate(ctx context.Context, m query.SQLManager, id uint32) (*model.Comment, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "GetCommentByIDForUpdate", ctx, m, id)
+	ret0, _ := ret[0].(*model.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentByIDForUpdate indicates an expected call of GetCommentByIDForUpdate
+func (mr *MockCommentRepositoryMockRecorder) GetCommentByIDForUpdate(ctx, m, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentByIDForUpdate", reflect.TypeOf((*MockCommentRepository)(nil).GetCommentByIDForUpdate), ctx, m, id)
+}
+
+// GetDeletedCommentByID mocks base method
+func (m_2 *MockCommentRepository) GetDeletedCommentByID(ctx context.Context, m query.SQLManager, id uint32) (*model.Comment, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "GetDeletedCommentByID", ctx, m, id)
+	ret0, _ := ret[0].(*model.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedCommentByID indicates an expected call of GetDeletedCommentByID
+func (mr *MockCommentRepositoryMockRecorder) GetDeletedCommentByID(ctx, m, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedCommentByID", reflect.TypeOf((*MockCommentRepository)(nil).GetDeletedCommentByID), ctx, m, id)
+}
+
 // InsertComment mocks base method
 func (m_2 *MockCommentRepository) InsertComment(ctx context.Context, m query.SQLManager, comment *model.Comment) (uint32, error) {
 	m_2.ctrl.T.Helper()
@@ -95,15 +126,74 @@ func (mr *MockCommentRepositoryMockRecorder) UpdateComment(ctx, m, id, comment i
 }
 
 // DeleteComment mocks base method
-func (m_2 *MockCommentRepository) DeleteComment(ctx context.Context, m query.SQLManager, id uint32) error {
+func (m_2 *MockCommentRepository) DeleteComment(ctx context.Context, m query.SQLManager, id, deletedBy uint32, deletedAt time.Time) error {
 	m_2.ctrl.T.Helper()
-	ret := m_2.ctrl.Call(m_2, "DeleteComment", ctx, m, id)
+	ret := m_2.ctrl.Call(m_2, "DeleteComment", ctx, m, id, deletedBy, deletedAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteComment indicates an expected call of DeleteComment
-func (mr *MockCommentRepositoryMockRecorder) DeleteComment(ctx, m, id interface{}) *gomock.Call {
+func (mr *MockCommentRepositoryMockRecorder) DeleteComment(ctx, m, id, deletedBy, deletedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockCommentRepository)(nil).DeleteComment), ctx, m, id, deletedBy, deletedAt)
+}
+
+// DeleteCommentsByThreadID mocks base method
+func (m_2 *MockCommentRepository) DeleteCommentsByThreadID(ctx context.Context, m query.SQLManager, threadID, deletedBy uint32, deletedAt time.Time) (int64, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "DeleteCommentsByThreadID", ctx, m, threadID, deletedBy, deletedAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCommentsByThreadID indicates an expected call of DeleteCommentsByThreadID
+func (mr *MockCommentRepositoryMockRecorder) DeleteCommentsByThreadID(ctx, m, threadID, deletedBy, deletedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCommentsByThreadID", reflect.TypeOf((*MockCommentRepository)(nil).DeleteCommentsByThreadID), ctx, m, threadID, deletedBy, deletedAt)
+}
+
+// RestoreComment mocks base method
+func (m_2 *MockCommentRepository) RestoreComment(ctx context.Context, m query.SQLManager, id uint32) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RestoreComment", ctx, m, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreComment indicates an expected call of RestoreComment
+func (mr *MockCommentRepositoryMockRecorder) RestoreComment(ctx, m, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreComment", reflect.TypeOf((*MockCommentRepository)(nil).RestoreComment), ctx, m, id)
+}
+
+// RestoreCommentsByThreadID mocks base method
+func (m_2 *MockCommentRepository) RestoreCommentsByThreadID(ctx context.Context, m query.SQLManager, threadID uint32) (int64, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RestoreCommentsByThreadID", ctx, m, threadID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreCommentsByThreadID indicates an expected call of RestoreCommentsByThreadID
+func (mr *MockCommentRepositoryMockRecorder) RestoreCommentsByThreadID(ctx, m, threadID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCommentsByThreadID", reflect.TypeOf((*MockCommentRepository)(nil).RestoreCommentsByThreadID), ctx, m, threadID)
+}
+
+// PurgeDeletedComments mocks base method
+func (m_2 *MockCommentRepository) PurgeDeletedComments(ctx context.Context, m query.SQLManager, deletedBefore time.Time, limit int) (int64, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "PurgeDeletedComments", ctx, m, deletedBefore, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedComments indicates an expected call of PurgeDeletedComments
+func (mr *MockCommentRepositoryMockRecorder) PurgeDeletedComments(ctx, m, deletedBefore, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockCommentRepository)(nil).DeleteComment), ctx, m, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedComments", reflect.TypeOf((*MockCommentRepository)(nil).PurgeDeletedComments), ctx, m, deletedBefore, limit)
 }
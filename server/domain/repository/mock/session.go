@@ -10,6 +10,7 @@ import (
 	model "github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	query "github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
 	reflect "reflect"
+	time "time"
 )
 
 // MockSessionRepository is a mock of SessionRepository interface
@@ -50,6 +51,21 @@ func (mr *MockSessionRepositoryMockRecorder) GetSessionByID(ctx, m, id interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByID", reflect.TypeOf((*MockSessionRepository)(nil).GetSessionByID), ctx, m, id)
 }
 
+// ListSessionsByUserID mocks base method
+func (m_2 *MockSessionRepository) ListSessionsByUserID(ctx context.Context, m query.SQLManager, userID uint32) ([]*model.Session, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "ListSessionsByUserID", ctx, m, userID)
+	ret0, _ := ret[0].([]*model.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessionsByUserID indicates an expected call of ListSessionsByUserID
+func (mr *MockSessionRepositoryMockRecorder) ListSessionsByUserID(ctx, m, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessionsByUserID", reflect.TypeOf((*MockSessionRepository)(nil).ListSessionsByUserID), ctx, m, userID)
+}
+
 // InsertSession mocks base method
 func (m_2 *MockSessionRepository) InsertSession(ctx context.Context, m query.SQLManager, session *model.Session) error {
 	m_2.ctrl.T.Helper()
@@ -77,3 +93,62 @@ func (mr *MockSessionRepositoryMockRecorder) DeleteSession(ctx, m, id interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockSessionRepository)(nil).DeleteSession), ctx, m, id)
 }
+
+// DeleteSessionsByUserID mocks base method
+func (m_2 *MockSessionRepository) DeleteSessionsByUserID(ctx context.Context, m query.SQLManager, userID uint32) (int64, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "DeleteSessionsByUserID", ctx, m, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSessionsByUserID indicates an expected call of DeleteSessionsByUserID
+func (mr *MockSessionRepositoryMockRecorder) DeleteSessionsByUserID(ctx, m, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionsByUserID", reflect.TypeOf((*MockSessionRepository)(nil).DeleteSessionsByUserID), ctx, m, userID)
+}
+
+// DeleteOtherSessions mocks base method
+func (m_2 *MockSessionRepository) DeleteOtherSessions(ctx context.Context, m query.SQLManager, userID uint32, keepID string) (int64, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "DeleteOtherSessions", ctx, m, userID, keepID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOtherSessions indicates an expected call of DeleteOtherSessions
+func (mr *MockSessionRepositoryMockRecorder) DeleteOtherSessions(ctx, m, userID, keepID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOtherSessions", reflect.TypeOf((*MockSessionRepository)(nil).DeleteOtherSessions), ctx, m, userID, keepID)
+}
+
+// RenewSession mocks base method
+func (m_2 *MockSessionRepository) RenewSession(ctx context.Context, m query.SQLManager, id string, lastAccessedAt time.Time) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RenewSession", ctx, m, id, lastAccessedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenewSession indicates an expected call of RenewSession
+func (mr *MockSessionRepositoryMockRecorder) RenewSession(ctx, m, id, lastAccessedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewSession", reflect.TypeOf((*MockSessionRepository)(nil).RenewSession), ctx, m, id, lastAccessedAt)
+}
+
+// DeleteExpiredSessions mocks base method
+func (m_2 *MockSessionRepository) DeleteExpiredSessions(ctx context.Context, m query.SQLManager, now time.Time, expiry model.SessionExpiry, limit int) (int64, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "DeleteExpiredSessions", ctx, m, now, expiry, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredSessions indicates an expected call of DeleteExpiredSessions
+func (mr *MockSessionRepositoryMockRecorder) DeleteExpiredSessions(ctx, m, now, expiry, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredSessions", reflect.TypeOf((*MockSessionRepository)(nil).DeleteExpiredSessions), ctx, m, now, expiry, limit)
+}
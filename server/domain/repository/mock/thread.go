@@ -10,6 +10,7 @@ import (
 	model "github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	query "github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
 	reflect "reflect"
+	time "time"
 )
 
 // MockThreadRepository is a mock of ThreadRepository interface
@@ -65,6 +66,21 @@ func (mr *MockThreadRepositoryMockRecorder) GetThreadByID(ctx, m, id interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThreadByID", reflect.TypeOf((*MockThreadRepository)(nil).GetThreadByID), ctx, m, id)
 }
 
+// GetDeletedThreadByID mocks base method
+func (m_2 *MockThreadRepository) GetDeletedThreadByID(ctx context.Context, m query.SQLManager, id uint32) (*model.Thread, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "GetDeletedThreadByID", ctx, m, id)
+	ret0, _ := ret[0].(*model.Thread)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedThreadByID indicates an expected call of GetDeletedThreadByID
+func (mr *MockThreadRepositoryMockRecorder) GetDeletedThreadByID(ctx, m, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedThreadByID", reflect.TypeOf((*MockThreadRepository)(nil).GetDeletedThreadByID), ctx, m, id)
+}
+
 // GetThreadByTitle mocks base method
 func (m_2 *MockThreadRepository) GetThreadByTitle(ctx context.Context, m query.SQLManager, name string) (*model.Thread, error) {
 	m_2.ctrl.T.Helper()
@@ -110,15 +126,44 @@ func (mr *MockThreadRepositoryMockRecorder) UpdateThread(ctx, m, id, thead inter
 }
 
 // DeleteThread mocks base method
-func (m_2 *MockThreadRepository) DeleteThread(ctx context.Context, m query.SQLManager, id uint32) error {
+func (m_2 *MockThreadRepository) DeleteThread(ctx context.Context, m query.SQLManager, id, deletedBy uint32, deletedAt time.Time) error {
 	m_2.ctrl.T.Helper()
-	ret := m_2.ctrl.Call(m_2, "DeleteThread", ctx, m, id)
+	ret := m_2.ctrl.Call(m_2, "DeleteThread", ctx, m, id, deletedBy, deletedAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteThread indicates an expected call of DeleteThread
-func (mr *MockThreadRepositoryMockRecorder) DeleteThread(ctx, m, id interface{}) *gomock.Call {
+func (mr *MockThreadRepositoryMockRecorder) DeleteThread(ctx, m, id, deletedBy, deletedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteThread", reflect.TypeOf((*MockThreadRepository)(nil).DeleteThread), ctx, m, id, deletedBy, deletedAt)
+}
+
+// RestoreThread mocks base method
+func (m_2 *MockThreadRepository) RestoreThread(ctx context.Context, m query.SQLManager, id uint32) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RestoreThread", ctx, m, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreThread indicates an expected call of RestoreThread
+func (mr *MockThreadRepositoryMockRecorder) RestoreThread(ctx, m, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreThread", reflect.TypeOf((*MockThreadRepository)(nil).RestoreThread), ctx, m, id)
+}
+
+// PurgeDeletedThreads mocks base method
+func (m_2 *MockThreadRepository) PurgeDeletedThreads(ctx context.Context, m query.SQLManager, deletedBefore time.Time, limit int) (int64, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "PurgeDeletedThreads", ctx, m, deletedBefore, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedThreads indicates an expected call of PurgeDeletedThreads
+func (mr *MockThreadRepositoryMockRecorder) PurgeDeletedThreads(ctx, m, deletedBefore, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteThread", reflect.TypeOf((*MockThreadRepository)(nil).DeleteThread), ctx, m, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedThreads", reflect.TypeOf((*MockThreadRepository)(nil).PurgeDeletedThreads), ctx, m, deletedBefore, limit)
 }
@@ -35,6 +35,21 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ListUsers mocks base method
+func (m_2 *MockUserRepository) ListUsers(ctx context.Context, m query.SQLManager, cursor uint32, limit int) (*model.UserList, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "ListUsers", ctx, m, cursor, limit)
+	ret0, _ := ret[0].(*model.UserList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers
+func (mr *MockUserRepositoryMockRecorder) ListUsers(ctx, m, cursor, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserRepository)(nil).ListUsers), ctx, m, cursor, limit)
+}
+
 // GetUserByID mocks base method
 func (m_2 *MockUserRepository) GetUserByID(ctx context.Context, m query.SQLManager, id uint32) (*model.User, error) {
 	m_2.ctrl.T.Helper()
@@ -94,6 +109,34 @@ func (mr *MockUserRepositoryMockRecorder) UpdateUser(ctx, m, id, user interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserRepository)(nil).UpdateUser), ctx, m, id, user)
 }
 
+// UpdateUserRole mocks base method
+func (m_2 *MockUserRepository) UpdateUserRole(ctx context.Context, m query.SQLManager, id uint32, role model.Role) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "UpdateUserRole", ctx, m, id, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole
+func (mr *MockUserRepositoryMockRecorder) UpdateUserRole(ctx, m, id, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockUserRepository)(nil).UpdateUserRole), ctx, m, id, role)
+}
+
+// UpdateUserBanned mocks base method
+func (m_2 *MockUserRepository) UpdateUserBanned(ctx context.Context, m query.SQLManager, id uint32, banned bool) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "UpdateUserBanned", ctx, m, id, banned)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserBanned indicates an expected call of UpdateUserBanned
+func (mr *MockUserRepositoryMockRecorder) UpdateUserBanned(ctx, m, id, banned interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserBanned", reflect.TypeOf((*MockUserRepository)(nil).UpdateUserBanned), ctx, m, id, banned)
+}
+
 // DeleteUser mocks base method
 func (m_2 *MockUserRepository) DeleteUser(ctx context.Context, m query.SQLManager, id uint32) error {
 	m_2.ctrl.T.Helper()
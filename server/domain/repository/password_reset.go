@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+)
+
+// PasswordResetTokenRepository is repository of password reset token.
+type PasswordResetTokenRepository interface {
+	GetPasswordResetTokenByHash(ctx context.Context, m query.SQLManager, tokenHash string) (*model.PasswordResetToken, error)
+	InsertPasswordResetToken(ctx context.Context, m query.SQLManager, token *model.PasswordResetToken) error
+	UsePasswordResetToken(ctx context.Context, m query.SQLManager, tokenHash string, now time.Time) error
+}
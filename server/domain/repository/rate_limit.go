@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+)
+
+// RateLimitStore is the store of the token buckets of rate limit.
+// It is not a repository of DB, so it does not take query.SQLManager.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit model.RateLimit, now time.Time) (*model.RateLimitStatus, error)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
 
@@ -11,6 +12,11 @@ import (
 // SessionRepository is repository of session.
 type SessionRepository interface {
 	GetSessionByID(ctx context.Context, m query.SQLManager, id string) (*model.Session, error)
+	ListSessionsByUserID(ctx context.Context, m query.SQLManager, userID uint32) ([]*model.Session, error)
 	InsertSession(ctx context.Context, m query.SQLManager, session *model.Session) error
 	DeleteSession(ctx context.Context, m query.SQLManager, id string) error
+	DeleteSessionsByUserID(ctx context.Context, m query.SQLManager, userID uint32) (int64, error)
+	DeleteOtherSessions(ctx context.Context, m query.SQLManager, userID uint32, keepID string) (int64, error)
+	RenewSession(ctx context.Context, m query.SQLManager, id string, lastAccessedAt time.Time) error
+	DeleteExpiredSessions(ctx context.Context, m query.SQLManager, now time.Time, expiry model.SessionExpiry, limit int) (int64, error)
 }
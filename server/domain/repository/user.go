@@ -9,9 +9,12 @@ import (
 
 // UserRepository is repository of user.
 type UserRepository interface {
+	ListUsers(ctx context.Context, m query.SQLManager, cursor uint32, limit int) (*model.UserList, error)
 	GetUserByID(ctx context.Context, m query.SQLManager, id uint32) (*model.User, error)
 	GetUserByName(ctx context.Context, m query.SQLManager, name string) (*model.User, error)
 	InsertUser(ctx context.Context, m query.SQLManager, user *model.User) (uint32, error)
 	UpdateUser(ctx context.Context, m query.SQLManager, id uint32, user *model.User) error
+	UpdateUserRole(ctx context.Context, m query.SQLManager, id uint32, role model.Role) error
+	UpdateUserBanned(ctx context.Context, m query.SQLManager, id uint32, banned bool) error
 	DeleteUser(ctx context.Context, m query.SQLManager, id uint32) error
 }
@@ -8,7 +8,9 @@ import (
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/util"
+	"go.uber.org/zap"
 )
 
 // AuthenticationService is interface of domain service of authentication.
@@ -18,19 +20,22 @@ type AuthenticationService interface {
 
 // authenticationService is domain service of authentication.
 type authenticationService struct {
-	repo repository.UserRepository
+	repo   repository.UserRepository
+	hasher util.PasswordHasher
 }
 
 // NewAuthenticationService generates and returns AuthenticationService.
-func NewAuthenticationService(repo repository.UserRepository) AuthenticationService {
+func NewAuthenticationService(repo repository.UserRepository, hasher util.PasswordHasher) AuthenticationService {
 	return &authenticationService{
-		repo: repo,
+		repo:   repo,
+		hasher: hasher,
 	}
 }
 
 // Authenticate authenticate user.
+// When the password hash of the user has been made with outdated parameters, it is rehashed with the current ones.
 func (s *authenticationService) Authenticate(ctx context.Context, m query.SQLManager, userName, password string) (ok bool, user *model.User, err error) {
-	gotUser, err := s.repo.GetUserByName(ctx, m, userName)
+	gotUser, err := s.repo.GetUserByName(ctx, m, model.NormalizeUserName(userName))
 	if err != nil {
 		if _, ok := errors.Cause(err).(*model.NoSuchDataError); ok {
 			return false, nil, &model.AuthenticationErr{
@@ -41,9 +46,37 @@ func (s *authenticationService) Authenticate(ctx context.Context, m query.SQLMan
 		return false, nil, errors.Wrap(err, "failed, to get user by name")
 	}
 
-	if !util.CheckHashOfPassword(password, gotUser.Password) {
+	ok, rehash, err := s.hasher.Verify(password, gotUser.Password)
+	if err != nil {
+		return false, nil, errors.Wrap(err, "failed to verify password")
+	}
+
+	if !ok {
 		return false, nil, nil
 	}
 
+	if rehash {
+		s.rehash(ctx, m, gotUser, password)
+	}
+
 	return true, gotUser, nil
 }
+
+// rehash rehashes the password of the user with the current parameters.
+// The failure is only logged, because the user has been authenticated by the outdated hash which is still valid.
+func (s *authenticationService) rehash(ctx context.Context, m query.SQLManager, user *model.User, password string) {
+	hashed, err := s.hasher.Hash(password)
+	if err != nil {
+		logger.FromContext(ctx).Warn("failed to rehash password", zap.String("error message", err.Error()))
+		return
+	}
+
+	rehashed := *user
+	rehashed.Password = hashed
+	if err := s.repo.UpdateUser(ctx, m, user.ID, &rehashed); err != nil {
+		logger.FromContext(ctx).Warn("failed to update rehashed password", zap.String("error message", err.Error()))
+		return
+	}
+
+	user.Password = hashed
+}
@@ -9,10 +9,13 @@ import (
 	"github.com/sekky0905/nuxt-vue-go-chat/server/util"
 
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	mock_repository "github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository/mock"
-	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	mock_query "github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query/mock"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/testutil"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func Test_authenticationService_Authenticate(t *testing.T) {
@@ -21,7 +24,7 @@ func Test_authenticationService_Authenticate(t *testing.T) {
 
 	testutil.SetFakeTime(time.Now())
 
-	mockDBM := db.NewDBManager()
+	mockDBM := mock_query.NewMockDBManager(ctrl)
 	mockRepo := mock_repository.NewMockUserRepository(ctrl)
 
 	type args struct {
@@ -35,7 +38,8 @@ func Test_authenticationService_Authenticate(t *testing.T) {
 		err  error
 	}
 
-	hashedPass, err := util.HashPassword(model.PasswordForTest)
+	hasher := util.NewBcryptHasher(util.DefaultBcryptCost)
+	hashedPass, err := hasher.Hash(model.PasswordForTest)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -130,7 +134,8 @@ func Test_authenticationService_Authenticate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &authenticationService{
-				repo: mockRepo,
+				repo:   mockRepo,
+				hasher: hasher,
 			}
 
 			ur, ok := s.repo.(*mock_repository.MockUserRepository)
@@ -155,3 +160,93 @@ func Test_authenticationService_Authenticate(t *testing.T) {
 		})
 	}
 }
+
+func Test_authenticationService_Authenticate_rehash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mock_query.NewMockDBManager(ctrl)
+	ctx := context.Background()
+
+	bcryptHasher := util.NewBcryptHasher(bcrypt.MinCost)
+	argon2idHasher := util.NewArgon2idHasher(util.Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+
+	outdated, err := util.NewBcryptHasher(bcrypt.MinCost + 1).Hash(model.PasswordForTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous, err := argon2idHasher.Hash(model.PasswordForTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := bcryptHasher.Hash(model.PasswordForTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		password     string
+		expectUpdate bool
+		updateErr    error
+		wantRehashed bool
+	}{
+		{
+			name:         "When the hash has been made with the outdated cost, rehashes it",
+			password:     outdated,
+			expectUpdate: true,
+			wantRehashed: true,
+		},
+		{
+			name:         "When the hash has been made by the previous hasher, rehashes it",
+			password:     previous,
+			expectUpdate: true,
+			wantRehashed: true,
+		},
+		{
+			name:         "When the hash has been made by the current hasher, does not rehash it",
+			password:     current,
+			expectUpdate: false,
+			wantRehashed: false,
+		},
+		{
+			name:         "When failed to update the rehashed password, keeps the outdated hash",
+			password:     outdated,
+			expectUpdate: true,
+			updateErr:    errors.New(model.ErrorMessageForTest),
+			wantRehashed: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mock_repository.NewMockUserRepository(ctrl)
+			s := &authenticationService{
+				repo:   repo,
+				hasher: util.NewVersionedHasher(bcryptHasher, argon2idHasher),
+			}
+
+			stored := &model.User{ID: model.UserValidIDForTest, Name: model.UserNameForTest, Password: tt.password}
+			repo.EXPECT().GetUserByName(ctx, m, model.UserNameForTest).Return(stored, nil)
+
+			if tt.expectUpdate {
+				repo.EXPECT().UpdateUser(ctx, m, model.UserValidIDForTest, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, m query.SQLManager, id uint32, u *model.User) error {
+						ok, rehash, err := bcryptHasher.Verify(model.PasswordForTest, u.Password)
+						if err != nil || !ok || rehash {
+							t.Errorf("rehashed password = %s, want the current hash of the password", u.Password)
+						}
+						return tt.updateErr
+					})
+			}
+
+			ok, user, err := s.Authenticate(ctx, m, model.UserNameForTest, model.PasswordForTest)
+			if err != nil || !ok {
+				t.Fatalf("authenticationService.Authenticate() = %v, %v, want true, nil", ok, err)
+			}
+
+			if rehashed := user.Password != tt.password; rehashed != tt.wantRehashed {
+				t.Errorf("rehashed = %v, want %v", rehashed, tt.wantRehashed)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+)
+
+// AuthorizationPolicy is the policy which decides whether the user can modify contents.
+type AuthorizationPolicy interface {
+	CanModifyThread(user *model.User, thread *model.Thread) error
+	CanModifyComment(user *model.User, comment *model.Comment) error
+}
+
+// AuthorizationOverride decides whether the user can modify contents of others,
+// e.g. moderators and admins.
+type AuthorizationOverride func(user *model.User) bool
+
+// AllowRoles returns AuthorizationOverride which allows the users who have one of the roles.
+func AllowRoles(roles ...model.Role) AuthorizationOverride {
+	return func(user *model.User) bool {
+		return user.HasRole(roles...)
+	}
+}
+
+// authorizationPolicy allows only the author to modify contents unless overridden.
+type authorizationPolicy struct {
+	overrides []AuthorizationOverride
+}
+
+// NewAuthorizationPolicy generates and returns AuthorizationPolicy.
+func NewAuthorizationPolicy(overrides ...AuthorizationOverride) AuthorizationPolicy {
+	return &authorizationPolicy{
+		overrides: overrides,
+	}
+}
+
+// CanModifyThread returns ForbiddenError if the user can not modify the thread.
+func (p *authorizationPolicy) CanModifyThread(user *model.User, thread *model.Thread) error {
+	return p.canModify(user, thread.User, thread.ID, model.DomainModelNameThread)
+}
+
+// CanModifyComment returns ForbiddenError if the user can not modify the comment.
+func (p *authorizationPolicy) CanModifyComment(user *model.User, comment *model.Comment) error {
+	return p.canModify(user, comment.User, comment.ID, model.DomainModelNameComment)
+}
+
+// canModify returns ForbiddenError if the user is neither the author nor allowed by overrides.
+func (p *authorizationPolicy) canModify(user, author *model.User, id uint32, name model.DomainModelName) error {
+	if user == nil {
+		return errors.WithStack(&model.AuthenticationErr{})
+	}
+
+	if author != nil && author.ID == user.ID {
+		return nil
+	}
+
+	for _, override := range p.overrides {
+		if override(user) {
+			return nil
+		}
+	}
+
+	return errors.WithStack(&model.ForbiddenError{
+		UserID:          user.ID,
+		PropertyName:    model.IDProperty,
+		PropertyValue:   id,
+		DomainModelName: name,
+	})
+}
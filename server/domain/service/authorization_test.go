@@ -0,0 +1,178 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+)
+
+func Test_authorizationPolicy_CanModifyThread(t *testing.T) {
+	thread := &model.Thread{
+		ID:    model.ThreadValidIDForTest,
+		Title: model.TitleForTest,
+		User: &model.User{
+			ID:   model.UserValidIDForTest,
+			Name: model.UserNameForTest,
+		},
+	}
+
+	type fields struct {
+		overrides []AuthorizationOverride
+	}
+	type args struct {
+		user   *model.User
+		thread *model.Thread
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "When the user is the author, returns nil",
+			args: args{
+				user: &model.User{
+					ID: model.UserValidIDForTest,
+				},
+				thread: thread,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "When the user is not the author, returns ForbiddenError",
+			args: args{
+				user: &model.User{
+					ID: model.UserInValidIDForTest,
+				},
+				thread: thread,
+			},
+			wantErr: &model.ForbiddenError{},
+		},
+		{
+			name: "When the user is not the author but allowed by override, returns nil",
+			fields: fields{
+				overrides: []AuthorizationOverride{
+					func(user *model.User) bool {
+						return user.ID == model.UserInValidIDForTest
+					},
+				},
+			},
+			args: args{
+				user: &model.User{
+					ID: model.UserInValidIDForTest,
+				},
+				thread: thread,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "When the user is a moderator and moderators are allowed, returns nil",
+			fields: fields{
+				overrides: []AuthorizationOverride{AllowRoles(model.RoleModerator, model.RoleAdmin)},
+			},
+			args: args{
+				user: &model.User{
+					ID:   model.UserInValidIDForTest,
+					Role: model.RoleModerator,
+				},
+				thread: thread,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "When the user is a plain user and only moderators are allowed, returns ForbiddenError",
+			fields: fields{
+				overrides: []AuthorizationOverride{AllowRoles(model.RoleModerator, model.RoleAdmin)},
+			},
+			args: args{
+				user: &model.User{
+					ID:   model.UserInValidIDForTest,
+					Role: model.RoleUser,
+				},
+				thread: thread,
+			},
+			wantErr: &model.ForbiddenError{},
+		},
+		{
+			name: "When the user is not given, returns AuthenticationErr",
+			args: args{
+				user:   nil,
+				thread: thread,
+			},
+			wantErr: &model.AuthenticationErr{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewAuthorizationPolicy(tt.fields.overrides...)
+
+			err := p.CanModifyThread(tt.args.user, tt.args.thread)
+			switch tt.wantErr.(type) {
+			case nil:
+				if err != nil {
+					t.Errorf("authorizationPolicy.CanModifyThread() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			case *model.ForbiddenError:
+				if _, ok := errors.Cause(err).(*model.ForbiddenError); !ok {
+					t.Errorf("authorizationPolicy.CanModifyThread() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			case *model.AuthenticationErr:
+				if _, ok := errors.Cause(err).(*model.AuthenticationErr); !ok {
+					t.Errorf("authorizationPolicy.CanModifyThread() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func Test_authorizationPolicy_CanModifyComment(t *testing.T) {
+	comment := &model.Comment{
+		ID:       model.CommentValidIDForTest,
+		ThreadID: model.ThreadValidIDForTest,
+		User: &model.User{
+			ID:   model.UserValidIDForTest,
+			Name: model.UserNameForTest,
+		},
+		Content: model.CommentContentForTest,
+	}
+
+	tests := []struct {
+		name    string
+		user    *model.User
+		wantErr bool
+	}{
+		{
+			name: "When the user is the author, returns nil",
+			user: &model.User{
+				ID: model.UserValidIDForTest,
+			},
+			wantErr: false,
+		},
+		{
+			name: "When the user is not the author, returns ForbiddenError",
+			user: &model.User{
+				ID: model.UserInValidIDForTest,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewAuthorizationPolicy()
+
+			err := p.CanModifyComment(tt.user, comment)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authorizationPolicy.CanModifyComment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if _, ok := errors.Cause(err).(*model.ForbiddenError); !ok {
+					t.Errorf("authorizationPolicy.CanModifyComment() error = %v, want ForbiddenError", err)
+				}
+			}
+		})
+	}
+}
@@ -9,8 +9,8 @@ import (
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
 	mock_repository "github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository/mock"
-	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	mock_query "github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query/mock"
 )
 
 func Test_commentService_IsAlreadyExistID(t *testing.T) {
@@ -49,7 +49,7 @@ func Test_commentService_IsAlreadyExistID(t *testing.T) {
 			},
 			args: args{
 				ctx: context.Background(),
-				m:   db.NewDBManager(),
+				m:   mock_query.NewMockDBManager(ctrl),
 				id:  model.CommentValidIDForTest,
 			},
 			returnArgs: returnArgs{
@@ -74,7 +74,7 @@ func Test_commentService_IsAlreadyExistID(t *testing.T) {
 			},
 			args: args{
 				ctx: context.Background(),
-				m:   db.NewDBManager(),
+				m:   mock_query.NewMockDBManager(ctrl),
 				id:  model.CommentInValidIDForTest,
 			},
 			returnArgs: returnArgs{
@@ -91,7 +91,7 @@ func Test_commentService_IsAlreadyExistID(t *testing.T) {
 			},
 			args: args{
 				ctx: context.Background(),
-				m:   db.NewDBManager(),
+				m:   mock_query.NewMockDBManager(ctrl),
 				id:  model.CommentInValidIDForTest,
 			},
 			returnArgs: returnArgs{
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/util"
+)
+
+// LoginAttemptService is interface of domain service of login attempt.
+type LoginAttemptService interface {
+	Check(ctx context.Context, m query.SQLManager, userName, ip string) error
+	RecordFailure(ctx context.Context, m query.SQLManager, userName, ip string) error
+	RecordSuccess(ctx context.Context, m query.SQLManager, userName string) error
+}
+
+// loginAttemptService is domain service of login attempt,
+// which tracks failed login attempts per user name and per client IP.
+type loginAttemptService struct {
+	repo       repository.LoginAttemptRepository
+	userPolicy model.LoginAttemptPolicy
+	ipPolicy   model.LoginAttemptPolicy
+	now        func() time.Time
+}
+
+// NewLoginAttemptService generates and returns LoginAttemptService.
+func NewLoginAttemptService(repo repository.LoginAttemptRepository, userPolicy, ipPolicy model.LoginAttemptPolicy) LoginAttemptService {
+	return &loginAttemptService{
+		repo:       repo,
+		userPolicy: userPolicy,
+		ipPolicy:   ipPolicy,
+		now:        time.Now,
+	}
+}
+
+// Check returns TooManyAttemptsError if the user name or the client IP must wait for the next attempt.
+func (s *loginAttemptService) Check(ctx context.Context, m query.SQLManager, userName, ip string) error {
+	now := s.now()
+
+	var retryAfter time.Duration
+	for _, k := range s.keys(userName, ip) {
+		attempt, err := s.repo.GetLoginAttempt(ctx, m, k.key)
+		if err != nil {
+			if _, ok := errors.Cause(err).(*model.NoSuchDataError); ok {
+				continue
+			}
+			return errors.Wrap(err, "failed to get login attempt")
+		}
+
+		if wait := k.policy.RetryAfter(attempt, now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	if retryAfter > 0 {
+		return errors.WithStack(&model.TooManyAttemptsError{RetryAfter: retryAfter})
+	}
+
+	return nil
+}
+
+// RecordFailure records the failed login attempt of the user name and the client IP.
+func (s *loginAttemptService) RecordFailure(ctx context.Context, m query.SQLManager, userName, ip string) error {
+	now := s.now()
+
+	for _, k := range s.keys(userName, ip) {
+		if err := s.repo.IncrementLoginAttempt(ctx, m, k.key, now, k.policy.ForgetBefore(now)); err != nil {
+			return errors.Wrap(err, "failed to increment login attempt")
+		}
+	}
+
+	return nil
+}
+
+// RecordSuccess forgets the failed login attempts of the user name.
+// The failures of the client IP are kept, so that an attacker can not reset them by logging in to own account.
+func (s *loginAttemptService) RecordSuccess(ctx context.Context, m query.SQLManager, userName string) error {
+	if err := s.repo.DeleteLoginAttempt(ctx, m, userKey(userName)); err != nil {
+		return errors.Wrap(err, "failed to delete login attempt")
+	}
+
+	return nil
+}
+
+// loginAttemptKey is the key of login attempts and the policy of it.
+type loginAttemptKey struct {
+	key    string
+	policy model.LoginAttemptPolicy
+}
+
+// keys returns the keys of the user name and the client IP.
+// The client IP is skipped if it is unknown.
+func (s *loginAttemptService) keys(userName, ip string) []loginAttemptKey {
+	keys := []loginAttemptKey{{key: userKey(userName), policy: s.userPolicy}}
+	if ip != "" {
+		keys = append(keys, loginAttemptKey{key: util.HashToken("ip:" + ip), policy: s.ipPolicy})
+	}
+	return keys
+}
+
+// userKey returns the key of the user name.
+// The key is hashed so that the length is fixed and the user name is not stored,
+// and is case-insensitive as the user name is in DB.
+func userKey(userName string) string {
+	return util.HashToken("user:" + strings.ToLower(userName))
+}
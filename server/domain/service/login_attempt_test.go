@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	mock_repository "github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/testutil"
+)
+
+func Test_loginAttemptService_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testutil.SetFakeTime(time.Now())
+
+	policy := model.DefaultUserLoginAttemptPolicy()
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		userAttempt *model.LoginAttempt
+		ipAttempt   *model.LoginAttempt
+		wantErr     error
+	}{
+		{
+			name:    "When there is no failure, Check returns nil",
+			wantErr: nil,
+		},
+		{
+			name:        "When the user name has failed too many times, Check returns TooManyAttemptsError",
+			userAttempt: &model.LoginAttempt{Failures: policy.LockoutFailures, LastFailedAt: testutil.TimeNow()},
+			wantErr:     &model.TooManyAttemptsError{RetryAfter: policy.LockoutDuration},
+		},
+		{
+			name:      "When the client IP has failed too many times, Check returns TooManyAttemptsError",
+			ipAttempt: &model.LoginAttempt{Failures: policy.FreeFailures + 1, LastFailedAt: testutil.TimeNow()},
+			wantErr:   &model.TooManyAttemptsError{RetryAfter: policy.BaseDelay},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mock_repository.NewMockLoginAttemptRepository(ctrl)
+			expectGetLoginAttempt(ctx, repo, userKey(model.UserNameForTest), tt.userAttempt)
+			expectGetLoginAttempt(ctx, repo, gomock.Not(userKey(model.UserNameForTest)), tt.ipAttempt)
+
+			s := &loginAttemptService{
+				repo:       repo,
+				userPolicy: policy,
+				ipPolicy:   policy,
+				now:        testutil.TimeNow,
+			}
+
+			err := s.Check(ctx, nil, model.UserNameForTest, model.IPForTest)
+			if !reflect.DeepEqual(errors.Cause(err), tt.wantErr) {
+				t.Errorf("loginAttemptService.Check() error = %#v, wantErr %#v", errors.Cause(err), tt.wantErr)
+			}
+		})
+	}
+}
+
+// expectGetLoginAttempt expects GetLoginAttempt which returns the attempt, or NoSuchDataError if it is nil.
+func expectGetLoginAttempt(ctx context.Context, repo *mock_repository.MockLoginAttemptRepository, key interface{}, attempt *model.LoginAttempt) {
+	if attempt == nil {
+		repo.EXPECT().GetLoginAttempt(ctx, nil, key).Return(nil, &model.NoSuchDataError{})
+		return
+	}
+	repo.EXPECT().GetLoginAttempt(ctx, nil, key).Return(attempt, nil)
+}
+
+func Test_loginAttemptService_RecordFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testutil.SetFakeTime(time.Now())
+
+	userPolicy := model.DefaultUserLoginAttemptPolicy()
+	ipPolicy := model.DefaultIPLoginAttemptPolicy()
+	ctx := context.Background()
+
+	repo := mock_repository.NewMockLoginAttemptRepository(ctrl)
+	repo.EXPECT().IncrementLoginAttempt(ctx, nil, userKey("TestUserName"), testutil.TimeNow(), userPolicy.ForgetBefore(testutil.TimeNow())).Return(nil)
+	repo.EXPECT().IncrementLoginAttempt(ctx, nil, gomock.Not(userKey("TestUserName")), testutil.TimeNow(), ipPolicy.ForgetBefore(testutil.TimeNow())).Return(nil)
+
+	s := &loginAttemptService{
+		repo:       repo,
+		userPolicy: userPolicy,
+		ipPolicy:   ipPolicy,
+		now:        testutil.TimeNow,
+	}
+
+	if err := s.RecordFailure(ctx, nil, "TestUserName", model.IPForTest); err != nil {
+		t.Errorf("loginAttemptService.RecordFailure() error = %v", err)
+	}
+
+	if userKey("TestUserName") != userKey("testusername") {
+		t.Error("userKey() must be case-insensitive")
+	}
+}
@@ -50,6 +50,35 @@ func (mr *MockUserServiceMockRecorder) NewUser(name, password interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewUser", reflect.TypeOf((*MockUserService)(nil).NewUser), name, password)
 }
 
+// SetPassword mocks base method
+func (m *MockUserService) SetPassword(user *model.User, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", user, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPassword indicates an expected call of SetPassword
+func (mr *MockUserServiceMockRecorder) SetPassword(user, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockUserService)(nil).SetPassword), user, password)
+}
+
+// VerifyPassword mocks base method
+func (m *MockUserService) VerifyPassword(user *model.User, password string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyPassword", user, password)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyPassword indicates an expected call of VerifyPassword
+func (mr *MockUserServiceMockRecorder) VerifyPassword(user, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyPassword", reflect.TypeOf((*MockUserService)(nil).VerifyPassword), user, password)
+}
+
 // IsAlreadyExistID mocks base method
 func (m_2 *MockUserService) IsAlreadyExistID(ctx context.Context, m query.SQLManager, id uint32) (bool, error) {
 	m_2.ctrl.T.Helper()
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+)
+
+// Notifier delivers notifications to users, e.g. via email.
+type Notifier interface {
+	NotifyPasswordReset(ctx context.Context, user *model.User, token string, expiresAt time.Time) error
+}
@@ -23,21 +23,26 @@ type SessionRepoFactory func(ctx context.Context) repository.SessionRepository
 
 // sessionService is domain service of session.
 type sessionService struct {
-	repo repository.SessionRepository
+	repo   repository.SessionRepository
+	expiry model.SessionExpiry
 }
 
 // NewSessionService generates and returns SessionService.
-func NewSessionService(repo repository.SessionRepository) SessionService {
+func NewSessionService(repo repository.SessionRepository, expiry model.SessionExpiry) SessionService {
 	return &sessionService{
-		repo: repo,
+		repo:   repo,
+		expiry: expiry,
 	}
 }
 
 // NewSession generates and returns Session.
 func (s *sessionService) NewSession(userID uint32) *model.Session {
+	now := time.Now()
 	session := &model.Session{
-		UserID:    userID,
-		CreatedAt: time.Now(),
+		UserID:         userID,
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(s.expiry.AbsoluteTimeout),
+		LastAccessedAt: now,
 	}
 	return session
 }
@@ -9,8 +9,8 @@ import (
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
 	mock_repository "github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository/mock"
-	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	mock_query "github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query/mock"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/testutil"
 )
 
@@ -50,7 +50,7 @@ func Test_threadService_IsAlreadyExistID(t *testing.T) {
 			},
 			args: args{
 				ctx: context.Background(),
-				m:   db.NewDBManager(),
+				m:   mock_query.NewMockDBManager(ctrl),
 				id:  model.ThreadValidIDForTest,
 			},
 			returnArgs: returnArgs{
@@ -76,7 +76,7 @@ func Test_threadService_IsAlreadyExistID(t *testing.T) {
 			},
 			args: args{
 				ctx: context.Background(),
-				m:   db.NewDBManager(),
+				m:   mock_query.NewMockDBManager(ctrl),
 				id:  model.ThreadInValidIDForTest,
 			},
 			returnArgs: returnArgs{
@@ -93,7 +93,7 @@ func Test_threadService_IsAlreadyExistID(t *testing.T) {
 			},
 			args: args{
 				ctx: context.Background(),
-				m:   db.NewDBManager(),
+				m:   mock_query.NewMockDBManager(ctrl),
 				id:  model.ThreadInValidIDForTest,
 			},
 			returnArgs: returnArgs{
@@ -162,7 +162,7 @@ func Test_threadService_IsAlreadyExistTitle(t *testing.T) {
 			},
 			args: args{
 				ctx:   context.Background(),
-				m:     db.NewDBManager(),
+				m:     mock_query.NewMockDBManager(ctrl),
 				title: model.TitleForTest,
 			},
 			returnArgs: returnArgs{
@@ -188,7 +188,7 @@ func Test_threadService_IsAlreadyExistTitle(t *testing.T) {
 			},
 			args: args{
 				ctx:   context.Background(),
-				m:     db.NewDBManager(),
+				m:     mock_query.NewMockDBManager(ctrl),
 				title: model.TitleForTest,
 			},
 			returnArgs: returnArgs{
@@ -205,7 +205,7 @@ func Test_threadService_IsAlreadyExistTitle(t *testing.T) {
 			},
 			args: args{
 				ctx:   context.Background(),
-				m:     db.NewDBManager(),
+				m:     mock_query.NewMockDBManager(ctrl),
 				title: model.TitleForTest,
 			},
 			returnArgs: returnArgs{
@@ -14,6 +14,8 @@ import (
 // UserService is interface of domain service of user.
 type UserService interface {
 	NewUser(name, password string) (*model.User, error)
+	SetPassword(user *model.User, password string) error
+	VerifyPassword(user *model.User, password string) (bool, error)
 	IsAlreadyExistID(ctx context.Context, m query.SQLManager, id uint32) (bool, error)
 	IsAlreadyExistName(ctx context.Context, m query.SQLManager, name string) (bool, error)
 }
@@ -23,31 +25,71 @@ type UserRepoFactory func(ctx context.Context) repository.UserRepository
 
 // userService is domain service of user.
 type userService struct {
-	repo repository.UserRepository
+	repo           repository.UserRepository
+	namePolicy     model.UserNamePolicy
+	passwordPolicy model.PasswordPolicy
+	hasher         util.PasswordHasher
 }
 
 // NewUserService generates and returns UserService.
-func NewUserService(m query.SQLManager, repo repository.UserRepository) UserService {
+func NewUserService(m query.SQLManager, repo repository.UserRepository, namePolicy model.UserNamePolicy, passwordPolicy model.PasswordPolicy, hasher util.PasswordHasher) UserService {
 	return &userService{
-		repo: repo,
+		repo:           repo,
+		namePolicy:     namePolicy,
+		passwordPolicy: passwordPolicy,
+		hasher:         hasher,
 	}
 }
 
 // NewUser generates and reruns User.
+// The name is normalized, and the name and the password are validated by the policies.
 func (s *userService) NewUser(name, password string) (*model.User, error) {
-	hashed, err := util.HashPassword(password)
+	name = model.NormalizeUserName(name)
+
+	errs := s.namePolicy.Validate(name)
+	errs = append(errs, s.passwordPolicy.Validate(name, password)...)
+	if len(errs) > 0 {
+		return nil, errors.WithStack(&model.InvalidParamsError{Errors: errs})
+	}
+
+	hashed, err := s.hasher.Hash(password)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, "failed to hash password")
 	}
 
 	return &model.User{
 		Name:      name,
 		Password:  hashed,
+		Role:      model.RoleUser,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}, nil
 }
 
+// SetPassword validates the new password of the user by the policy, and sets the hash of it to the user.
+func (s *userService) SetPassword(user *model.User, password string) error {
+	if errs := s.passwordPolicy.Validate(user.Name, password); len(errs) > 0 {
+		return errors.WithStack(&model.InvalidParamsError{Errors: errs})
+	}
+
+	hashed, err := s.hasher.Hash(password)
+	if err != nil {
+		return errors.Wrap(err, "failed to hash password")
+	}
+	user.Password = hashed
+
+	return nil
+}
+
+// VerifyPassword returns whether the password is the one of the user.
+func (s *userService) VerifyPassword(user *model.User, password string) (bool, error) {
+	ok, _, err := s.hasher.Verify(password, user.Password)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to verify password")
+	}
+	return ok, nil
+}
+
 // IsAlreadyExistID checks whether the data specified by id already exists or not.
 func (s *userService) IsAlreadyExistID(ctx context.Context, m query.SQLManager, id uint32) (bool, error) {
 	searched, err := s.repo.GetUserByID(ctx, m, id)
@@ -11,8 +11,10 @@ import (
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
 	mock_repository "github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository/mock"
-	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db"
+	mock_query "github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query/mock"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/testutil"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/util"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func Test_userService_IsAlreadyExistID(t *testing.T) {
@@ -51,7 +53,7 @@ func Test_userService_IsAlreadyExistID(t *testing.T) {
 			},
 			args: args{
 				ctx: context.Background(),
-				m:   db.NewDBManager(),
+				m:   mock_query.NewMockDBManager(ctrl),
 				id:  model.UserValidIDForTest,
 			},
 			returnArgs: returnArgs{
@@ -75,7 +77,7 @@ func Test_userService_IsAlreadyExistID(t *testing.T) {
 			},
 			args: args{
 				ctx: context.Background(),
-				m:   db.NewDBManager(),
+				m:   mock_query.NewMockDBManager(ctrl),
 				id:  model.UserInValidIDForTest,
 			},
 			returnArgs: returnArgs{
@@ -92,7 +94,7 @@ func Test_userService_IsAlreadyExistID(t *testing.T) {
 			},
 			args: args{
 				ctx: context.Background(),
-				m:   db.NewDBManager(),
+				m:   mock_query.NewMockDBManager(ctrl),
 				id:  model.UserInValidIDForTest,
 			},
 			returnArgs: returnArgs{
@@ -184,7 +186,7 @@ func Test_userService_IsAlreadyExistName(t *testing.T) {
 			},
 			args: args{
 				ctx:  context.Background(),
-				m:    db.NewDBManager(),
+				m:    mock_query.NewMockDBManager(ctrl),
 				name: model.UserNameForTest,
 			},
 			returnArgs: returnArgs{
@@ -201,7 +203,7 @@ func Test_userService_IsAlreadyExistName(t *testing.T) {
 			},
 			args: args{
 				ctx:  context.Background(),
-				m:    db.NewDBManager(),
+				m:    mock_query.NewMockDBManager(ctrl),
 				name: model.UserNameForTest,
 			},
 			returnArgs: returnArgs{
@@ -233,3 +235,63 @@ func Test_userService_IsAlreadyExistName(t *testing.T) {
 		})
 	}
 }
+
+func Test_userService_NewUser(t *testing.T) {
+	s := &userService{
+		namePolicy:     model.DefaultUserNamePolicy(),
+		passwordPolicy: model.DefaultPasswordPolicy(),
+		hasher:         util.NewBcryptHasher(bcrypt.MinCost),
+	}
+
+	tests := []struct {
+		name       string
+		userName   string
+		password   string
+		wantName   string
+		wantErrLen int
+	}{
+		{
+			name:     "When the name and the password follow the policies, returns the user with the normalized name",
+			userName: "ｔｅｓｔＵｓｅｒ",
+			password: "correct-horse",
+			wantName: "testUser",
+		},
+		{
+			name:       "When both of the name and the password violate the policies, returns InvalidParamsError with all errors",
+			userName:   "a",
+			password:   "a",
+			wantErrLen: 4,
+		},
+		{
+			name:       "When the name is reserved, returns InvalidParamsError",
+			userName:   "admin",
+			password:   "correct-horse",
+			wantErrLen: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.NewUser(tt.userName, tt.password)
+			if tt.wantErrLen > 0 {
+				paramsErr, ok := errors.Cause(err).(*model.InvalidParamsError)
+				if !ok {
+					t.Fatalf("userService.NewUser() error = %v, want InvalidParamsError", err)
+				}
+				if len(paramsErr.Errors) != tt.wantErrLen {
+					t.Errorf("userService.NewUser() errors = %v, want %d errors", paramsErr.Errors, tt.wantErrLen)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("userService.NewUser() error = %v", err)
+			}
+			if got.Name != tt.wantName {
+				t.Errorf("userService.NewUser() name = %v, want %v", got.Name, tt.wantName)
+			}
+			if got.Role != model.RoleUser {
+				t.Errorf("userService.NewUser() role = %v, want %v", got.Role, model.RoleUser)
+			}
+		})
+	}
+}
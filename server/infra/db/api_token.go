@@ -0,0 +1,230 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+)
+
+// apiTokenRepository is repository of API token.
+type apiTokenRepository struct {
+}
+
+// NewAPITokenRepository generates and returns APITokenRepository.
+func NewAPITokenRepository() repository.APITokenRepository {
+	return &apiTokenRepository{}
+}
+
+// ErrorMsg generates and returns error message.
+func (repo *apiTokenRepository) ErrorMsg(method model.RepositoryMethod, err error) error {
+	return &model.RepositoryError{
+		BaseErr:          err,
+		RepositoryMethod: method,
+		DomainModelName:  model.DomainModelNameAPIToken,
+	}
+}
+
+// GetAPITokenByHash gets and returns a record specified by the hash of the token.
+func (repo *apiTokenRepository) GetAPITokenByHash(ctx context.Context, m query.SQLManager, tokenHash string) (*model.APIToken, error) {
+	q := "SELECT id, user_id, name, token_hash, created_at, last_used_at FROM api_tokens WHERE token_hash=?"
+
+	list, err := repo.list(ctx, m, model.RepositoryMethodREAD, q, tokenHash)
+	if err != nil {
+		err = errors.Wrap(err, "failed to list api token")
+		return nil, repo.ErrorMsg(model.RepositoryMethodREAD, err)
+	}
+
+	if len(list) == 0 {
+		err = &model.NoSuchDataError{
+			PropertyName:    model.TokenProperty,
+			DomainModelName: model.DomainModelNameAPIToken,
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	return list[0], nil
+}
+
+// ListAPITokensByUserID lists records of the user, ordered by id.
+func (repo *apiTokenRepository) ListAPITokensByUserID(ctx context.Context, m query.SQLManager, userID uint32) ([]*model.APIToken, error) {
+	q := "SELECT id, user_id, name, token_hash, created_at, last_used_at FROM api_tokens WHERE user_id=? ORDER BY id ASC"
+
+	list, err := repo.list(ctx, m, model.RepositoryMethodLIST, q, userID)
+	if err != nil {
+		err = errors.Wrap(err, "failed to list api token")
+		return nil, repo.ErrorMsg(model.RepositoryMethodLIST, err)
+	}
+
+	return list, nil
+}
+
+// list gets and returns list of records.
+func (repo *apiTokenRepository) list(ctx context.Context, m query.SQLManager, method model.RepositoryMethod, q string, args ...interface{}) (tokens []*model.APIToken, err error) {
+	stmt, err := m.PrepareContext(ctx, q)
+	if err != nil {
+		err = errors.Wrap(err, "failed to prepare context")
+		return nil, repo.ErrorMsg(method, err)
+	}
+	defer func() {
+		err = stmt.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		err = errors.Wrap(err, "failed to execute context")
+		return nil, repo.ErrorMsg(method, err)
+	}
+	defer func() {
+		err = rows.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("rows.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	list := make([]*model.APIToken, 0)
+	for rows.Next() {
+		token := &model.APIToken{}
+
+		err = rows.Scan(
+			&token.ID,
+			&token.UserID,
+			&token.Name,
+			&token.TokenHash,
+			&token.CreatedAt,
+			&token.LastUsedAt,
+		)
+
+		if err != nil {
+			err = errors.Wrap(err, "failed to scan rows")
+			return nil, repo.ErrorMsg(method, err)
+		}
+
+		list = append(list, token)
+	}
+
+	return list, nil
+}
+
+// InsertAPIToken insert a record.
+func (repo *apiTokenRepository) InsertAPIToken(ctx context.Context, m query.SQLManager, token *model.APIToken) (uint32, error) {
+	q := "INSERT INTO api_tokens (user_id, name, token_hash, created_at, last_used_at) VALUES (?, ?, ?, ?, ?)"
+
+	stmt, err := m.PrepareContext(ctx, q)
+	if err != nil {
+		err = errors.Wrap(err, "failed to prepare context")
+		return model.InvalidID, repo.ErrorMsg(model.RepositoryMethodInsert, err)
+	}
+	defer func() {
+		err = stmt.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	result, err := stmt.ExecContext(ctx, token.UserID, token.Name, token.TokenHash, token.CreatedAt, token.LastUsedAt)
+	if err != nil {
+		err = errors.Wrap(err, "failed to execute context")
+		return model.InvalidID, repo.ErrorMsg(model.RepositoryMethodInsert, err)
+	}
+
+	affect, err := result.RowsAffected()
+	if affect != 1 {
+		err = errors.Errorf("total affected: %d ", affect)
+		return model.InvalidID, repo.ErrorMsg(model.RepositoryMethodInsert, err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		err = errors.Wrap(err, "failed to get last insert id")
+		return model.InvalidID, repo.ErrorMsg(model.RepositoryMethodInsert, err)
+	}
+
+	return uint32(id), nil
+}
+
+// TouchAPIToken updates the last use of a record.
+func (repo *apiTokenRepository) TouchAPIToken(ctx context.Context, m query.SQLManager, id uint32, lastUsedAt time.Time) error {
+	q := "UPDATE api_tokens SET last_used_at=? WHERE id=?"
+
+	stmt, err := m.PrepareContext(ctx, q)
+	if err != nil {
+		err = errors.Wrap(err, "failed to prepare context")
+		return repo.ErrorMsg(model.RepositoryMethodUPDATE, err)
+	}
+	defer func() {
+		err = stmt.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	if _, err := stmt.ExecContext(ctx, lastUsedAt, id); err != nil {
+		err = errors.Wrap(err, "failed to execute context")
+		return repo.ErrorMsg(model.RepositoryMethodUPDATE, err)
+	}
+
+	return nil
+}
+
+// DeleteAPIToken deletes a record of the user.
+// The record of other users is not deleted, and NoSuchDataError is returned.
+func (repo *apiTokenRepository) DeleteAPIToken(ctx context.Context, m query.SQLManager, userID, id uint32) error {
+	affect, err := repo.deleteMany(ctx, m, "DELETE FROM api_tokens WHERE id=? AND user_id=?", id, userID)
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		err = &model.NoSuchDataError{
+			PropertyName:    model.IDProperty,
+			PropertyValue:   id,
+			DomainModelName: model.DomainModelNameAPIToken,
+		}
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// DeleteAPITokensByUserID deletes all records of the user, and returns the number of deleted records.
+func (repo *apiTokenRepository) DeleteAPITokensByUserID(ctx context.Context, m query.SQLManager, userID uint32) (int64, error) {
+	return repo.deleteMany(ctx, m, "DELETE FROM api_tokens WHERE user_id=?", userID)
+}
+
+// deleteMany deletes records, and returns the number of deleted records.
+func (repo *apiTokenRepository) deleteMany(ctx context.Context, m query.SQLManager, q string, args ...interface{}) (int64, error) {
+	stmt, err := m.PrepareContext(ctx, q)
+	if err != nil {
+		err = errors.Wrap(err, "failed to prepare context")
+		return 0, repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+	}
+	defer func() {
+		err = stmt.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	result, err := stmt.ExecContext(ctx, args...)
+	if err != nil {
+		err = errors.Wrap(err, "failed to execute context")
+		return 0, repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+	}
+
+	affect, err := result.RowsAffected()
+	if err != nil {
+		err = errors.Wrap(err, "failed to get rows affected")
+		return 0, repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+	}
+
+	return affect, nil
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/testutil"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func Test_apiTokenRepository_GetAPITokenByHash(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	testutil.SetFakeTime(time.Now())
+
+	want := &model.APIToken{
+		ID:         1,
+		UserID:     model.UserValidIDForTest,
+		Name:       "cli",
+		TokenHash:  "hash",
+		CreatedAt:  testutil.TimeNow(),
+		LastUsedAt: testutil.TimeNow(),
+	}
+
+	q := "SELECT id, user_id, name, token_hash, created_at, last_used_at FROM api_tokens WHERE token_hash=\\?"
+	columns := []string{"id", "user_id", "name", "token_hash", "created_at", "last_used_at"}
+
+	rows := sqlmock.NewRows(columns).AddRow(want.ID, want.UserID, want.Name, want.TokenHash, want.CreatedAt, want.LastUsedAt)
+	mock.ExpectPrepare(q).ExpectQuery().WithArgs(want.TokenHash).WillReturnRows(rows)
+
+	repo := &apiTokenRepository{}
+	got, err := repo.GetAPITokenByHash(context.Background(), db, want.TokenHash)
+	if err != nil {
+		t.Fatalf("apiTokenRepository.GetAPITokenByHash() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("apiTokenRepository.GetAPITokenByHash() = %v, want %v", got, want)
+	}
+
+	mock.ExpectPrepare(q).ExpectQuery().WithArgs("unknown").WillReturnRows(sqlmock.NewRows(columns))
+	_, err = repo.GetAPITokenByHash(context.Background(), db, "unknown")
+	if _, ok := errors.Cause(err).(*model.NoSuchDataError); !ok {
+		t.Errorf("apiTokenRepository.GetAPITokenByHash() error = %#v, want NoSuchDataError", err)
+	}
+}
+
+func Test_apiTokenRepository_DeleteAPIToken(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	tests := []struct {
+		name        string
+		rowAffected int64
+		err         error
+		wantErr     error
+	}{
+		{
+			name:        "When the token of the user is deleted, returns nil",
+			rowAffected: 1,
+			wantErr:     nil,
+		},
+		{
+			name:        "When the token is not of the user, returns NoSuchDataError",
+			rowAffected: 0,
+			wantErr:     &model.NoSuchDataError{},
+		},
+		{
+			name:    "when DB error has occurred、returns RepositoryError",
+			err:     errors.New(model.ErrorMessageForTest),
+			wantErr: &model.RepositoryError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := "DELETE FROM api_tokens WHERE id=\\? AND user_id=\\?"
+			prep := mock.ExpectPrepare(q)
+
+			if tt.err != nil {
+				prep.ExpectExec().WithArgs(1, model.UserValidIDForTest).WillReturnError(tt.err)
+			} else {
+				prep.ExpectExec().WithArgs(1, model.UserValidIDForTest).WillReturnResult(sqlmock.NewResult(0, tt.rowAffected))
+			}
+
+			repo := &apiTokenRepository{}
+			err := repo.DeleteAPIToken(context.Background(), db, model.UserValidIDForTest, 1)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("apiTokenRepository.DeleteAPIToken() error = %v", err)
+				}
+				return
+			}
+
+			if reflect.TypeOf(errors.Cause(err)) != reflect.TypeOf(tt.wantErr) {
+				t.Errorf("apiTokenRepository.DeleteAPIToken() error = %#v, wantErr %#v", errors.Cause(err), tt.wantErr)
+			}
+		})
+	}
+}
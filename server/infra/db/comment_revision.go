@@ -0,0 +1,139 @@
+package db
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+)
+
+// commentRevisionRepository is repository of the revision of comment.
+type commentRevisionRepository struct {
+}
+
+// NewCommentRevisionRepository generates and returns CommentRevisionRepository.
+func NewCommentRevisionRepository() repository.CommentRevisionRepository {
+	return &commentRevisionRepository{}
+}
+
+// ErrorMsg generates and returns error message.
+func (repo *commentRevisionRepository) ErrorMsg(method model.RepositoryMethod, err error) error {
+	return &model.RepositoryError{
+		BaseErr:          err,
+		RepositoryMethod: method,
+		DomainModelName:  model.DomainModelNameCommentRevision,
+	}
+}
+
+// ListCommentRevisions lists records of the comment, ordered from the oldest.
+func (repo *commentRevisionRepository) ListCommentRevisions(ctx context.Context, m query.SQLManager, commentID uint32) ([]*model.CommentRevision, error) {
+	q := `SELECT r.id, r.comment_id, r.content, u.id, u.name, r.created_at, r.edited_at
+	FROM comment_revisions AS r
+	INNER JOIN users AS u
+	ON r.edited_by = u.id
+	WHERE r.comment_id=?
+	ORDER BY r.id ASC;`
+
+	list, err := repo.list(ctx, m, model.RepositoryMethodLIST, q, commentID)
+	if err != nil {
+		err = errors.Wrap(err, "failed to list comment revisions")
+		return nil, repo.ErrorMsg(model.RepositoryMethodLIST, err)
+	}
+
+	return list, nil
+}
+
+// list gets and returns list of records.
+func (repo *commentRevisionRepository) list(ctx context.Context, m query.SQLManager, method model.RepositoryMethod, q string, args ...interface{}) (revisions []*model.CommentRevision, err error) {
+	stmt, err := m.PrepareContext(ctx, q)
+	if err != nil {
+		err = errors.Wrap(err, "failed to prepare context")
+		return nil, repo.ErrorMsg(method, err)
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		err = errors.Wrap(err, "failed to execute context")
+		return nil, repo.ErrorMsg(method, err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.FromContext(ctx).Error("rows.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	list := make([]*model.CommentRevision, 0)
+	for rows.Next() {
+		revision := &model.CommentRevision{
+			EditedBy: &model.User{},
+		}
+
+		err = rows.Scan(
+			&revision.ID,
+			&revision.CommentID,
+			&revision.Content,
+			&revision.EditedBy.ID,
+			&revision.EditedBy.Name,
+			&revision.CreatedAt,
+			&revision.EditedAt,
+		)
+
+		if err != nil {
+			err = errors.Wrap(err, "failed to scan rows")
+			return nil, repo.ErrorMsg(method, err)
+		}
+
+		list = append(list, revision)
+	}
+
+	return list, nil
+}
+
+// InsertCommentRevision insert a record.
+func (repo *commentRevisionRepository) InsertCommentRevision(ctx context.Context, m query.SQLManager, revision *model.CommentRevision) (uint32, error) {
+	q := "INSERT INTO comment_revisions (comment_id, content, edited_by, created_at, edited_at) VALUES (?, ?, ?, ?, ?)"
+
+	stmt, err := m.PrepareContext(ctx, q)
+	if err != nil {
+		err = errors.Wrap(err, "failed to prepare context")
+		return model.InvalidID, repo.ErrorMsg(model.RepositoryMethodInsert, err)
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	result, err := stmt.ExecContext(ctx, revision.CommentID, revision.Content, revision.EditedBy.ID, revision.CreatedAt, revision.EditedAt)
+	if err != nil {
+		err = errors.Wrap(err, "failed to execute context")
+		return model.InvalidID, repo.ErrorMsg(model.RepositoryMethodInsert, err)
+	}
+
+	affect, err := result.RowsAffected()
+	if err != nil {
+		err = errors.Wrap(err, "failed to get rows affected")
+		return model.InvalidID, repo.ErrorMsg(model.RepositoryMethodInsert, err)
+	}
+	if affect != 1 {
+		err = errors.Errorf("total affected: %d ", affect)
+		return model.InvalidID, repo.ErrorMsg(model.RepositoryMethodInsert, err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		err = errors.Wrap(err, "failed to get last insert id")
+		return model.InvalidID, repo.ErrorMsg(model.RepositoryMethodInsert, err)
+	}
+
+	return uint32(id), nil
+}
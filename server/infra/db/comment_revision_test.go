@@ -0,0 +1,250 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/testutil"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestNewCommentRevisionRepository(t *testing.T) {
+	tests := []struct {
+		name string
+		want repository.CommentRevisionRepository
+	}{
+		{
+			name: "When given appropriate args, returns commentRevisionRepository",
+			want: &commentRevisionRepository{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewCommentRevisionRepository(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewCommentRevisionRepository() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_commentRevisionRepository_ListCommentRevisions(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	testutil.SetFakeTime(time.Now())
+
+	user := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+	}
+
+	type args struct {
+		ctx       context.Context
+		m         query.SQLManager
+		commentID uint32
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []*model.CommentRevision
+		err     error
+		wantErr *model.RepositoryError
+	}{
+		{
+			name: "When the comment has been edited twice, returns 2 revisions ordered from the oldest",
+			args: args{
+				ctx:       context.Background(),
+				m:         db,
+				commentID: model.CommentValidIDForTest,
+			},
+			want: []*model.CommentRevision{
+				{
+					ID:        1,
+					CommentID: model.CommentValidIDForTest,
+					Content:   "first",
+					EditedBy:  user,
+					CreatedAt: testutil.TimeNow(),
+					EditedAt:  testutil.TimeNow(),
+				},
+				{
+					ID:        2,
+					CommentID: model.CommentValidIDForTest,
+					Content:   "second",
+					EditedBy:  user,
+					CreatedAt: testutil.TimeNow(),
+					EditedAt:  testutil.TimeNow(),
+				},
+			},
+		},
+		{
+			name: "When the comment has not been edited, returns empty list",
+			args: args{
+				ctx:       context.Background(),
+				m:         db,
+				commentID: model.CommentValidIDForTest,
+			},
+			want: []*model.CommentRevision{},
+		},
+		{
+			name: "When DB error has occurred, returns error",
+			args: args{
+				ctx:       context.Background(),
+				m:         db,
+				commentID: model.CommentValidIDForTest,
+			},
+			err: errors.New(model.ErrorMessageForTest),
+			wantErr: &model.RepositoryError{
+				RepositoryMethod: model.RepositoryMethodLIST,
+				DomainModelName:  model.DomainModelNameCommentRevision,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := `SELECT (.+)
+	FROM comment_revisions AS r
+	INNER JOIN users AS u
+	(.+);`
+			prep := mock.ExpectPrepare(q)
+
+			if tt.err != nil {
+				prep.ExpectQuery().WithArgs(tt.args.commentID).WillReturnError(tt.err)
+			} else {
+				rows := sqlmock.NewRows([]string{"r.id", "r.comment_id", "r.content", "u.id", "u.name", "r.created_at", "r.edited_at"})
+
+				for _, revision := range tt.want {
+					rows.AddRow(revision.ID, revision.CommentID, revision.Content, revision.EditedBy.ID, revision.EditedBy.Name, revision.CreatedAt, revision.EditedAt)
+				}
+
+				prep.ExpectQuery().WithArgs(tt.args.commentID).WillReturnRows(rows)
+			}
+
+			repo := &commentRevisionRepository{}
+			got, err := repo.ListCommentRevisions(tt.args.ctx, tt.args.m, tt.args.commentID)
+			if tt.wantErr != nil {
+				if errors.Cause(err).Error() != tt.wantErr.Error() {
+					t.Errorf("commentRevisionRepository.ListCommentRevisions() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("commentRevisionRepository.ListCommentRevisions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_commentRevisionRepository_InsertCommentRevision(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	testutil.SetFakeTime(time.Now())
+
+	revision := &model.CommentRevision{
+		CommentID: model.CommentValidIDForTest,
+		Content:   model.CommentContentForTest,
+		EditedBy: &model.User{
+			ID:   model.UserValidIDForTest,
+			Name: model.UserNameForTest,
+		},
+		CreatedAt: testutil.TimeNow(),
+		EditedAt:  testutil.TimeNow(),
+	}
+
+	type args struct {
+		ctx      context.Context
+		m        query.SQLManager
+		revision *model.CommentRevision
+		err      error
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		rowAffected int64
+		want        uint32
+		wantErr     *model.RepositoryError
+	}{
+		{
+			name: "When a revision is given, returns ID",
+			args: args{
+				ctx:      context.Background(),
+				m:        db,
+				revision: revision,
+			},
+			rowAffected: 1,
+			want:        model.CommentRevisionValidIDForTest,
+		},
+		{
+			name: "when RowAffected is 0、returns error",
+			args: args{
+				ctx:      context.Background(),
+				m:        db,
+				revision: revision,
+			},
+			rowAffected: 0,
+			wantErr: &model.RepositoryError{
+				RepositoryMethod: model.RepositoryMethodInsert,
+				DomainModelName:  model.DomainModelNameCommentRevision,
+			},
+		},
+		{
+			name: "when DB error has occurred、returns error",
+			args: args{
+				ctx:      context.Background(),
+				m:        db,
+				revision: revision,
+				err:      errors.New(model.ErrorMessageForTest),
+			},
+			wantErr: &model.RepositoryError{
+				RepositoryMethod: model.RepositoryMethodInsert,
+				DomainModelName:  model.DomainModelNameCommentRevision,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prep := mock.ExpectPrepare("INSERT INTO comment_revisions")
+
+			exec := prep.ExpectExec().WithArgs(tt.args.revision.CommentID, tt.args.revision.Content, tt.args.revision.EditedBy.ID, tt.args.revision.CreatedAt, tt.args.revision.EditedAt)
+
+			if tt.args.err != nil {
+				exec.WillReturnError(tt.args.err)
+			} else {
+				exec.WillReturnResult(sqlmock.NewResult(int64(model.CommentRevisionValidIDForTest), tt.rowAffected))
+			}
+
+			repo := &commentRevisionRepository{}
+
+			got, err := repo.InsertCommentRevision(tt.args.ctx, tt.args.m, tt.args.revision)
+			if tt.wantErr != nil {
+				if errors.Cause(err).Error() != tt.wantErr.Error() {
+					t.Errorf("commentRevisionRepository.InsertCommentRevision() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("commentRevisionRepository.InsertCommentRevision() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
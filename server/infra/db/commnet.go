@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
 
@@ -31,13 +32,18 @@ func (repo *commentRepository) ErrorMsg(method model.RepositoryMethod, err error
 }
 
 // ListThreads lists ThreadList.
+// The comments of the soft-deleted thread are hidden with it.
 func (repo *commentRepository) ListComments(ctx context.Context, m query.SQLManager, threadID uint32, limit int, cursor uint32) (*model.CommentList, error) {
-	q := `SELECT c.id, c.content, u.id, u.name, c.thread_id, c.created_at, c.updated_at
+	q := `SELECT c.id, c.content, u.id, u.name, c.thread_id, c.edit_count, c.created_at, c.updated_at
 	FROM comments AS c
+	INNER JOIN threads AS t
+	ON c.thread_id = t.id
 	INNER JOIN users AS u
 	ON c.user_id = u.id
 	WHERE c.id >= ?
 	AND c.thread_id = ?
+	AND c.deleted_at IS NULL
+	AND t.deleted_at IS NULL
 	ORDER BY c.id ASC
 	LIMIT ?;`
 
@@ -84,13 +90,51 @@ func (repo *commentRepository) ListComments(ctx context.Context, m query.SQLMana
 
 // GetThreadByID gets and returns a record specified by id.
 func (repo *commentRepository) GetCommentByID(ctx context.Context, m query.SQLManager, id uint32) (*model.Comment, error) {
-	q := `SELECT c.id, c.content, u.id, u.name, c.thread_id, c.created_at, c.updated_at
+	q := `SELECT c.id, c.content, u.id, u.name, c.thread_id, c.edit_count, c.created_at, c.updated_at
 	FROM comments AS c
 	INNER JOIN users AS u
 	ON c.user_id = u.id
 	WHERE c.id=?
+	AND c.deleted_at IS NULL
 	LIMIT 1;`
 
+	return repo.getByID(ctx, m, q, id)
+}
+
+// GetCommentByIDForUpdate gets and returns a record specified by id, and locks it until the tx ends,
+// so that the concurrent updates of it are serialized.
+func (repo *commentRepository) GetCommentByIDForUpdate(ctx context.Context, m query.SQLManager, id uint32) (*model.Comment, error) {
+	q := `SELECT c.id, c.content, u.id, u.name, c.thread_id, c.edit_count, c.created_at, c.updated_at
+	FROM comments AS c
+	INNER JOIN users AS u
+	ON c.user_id = u.id
+	WHERE c.id=?
+	AND c.deleted_at IS NULL
+	LIMIT 1
+	FOR UPDATE;`
+
+	return repo.getByID(ctx, m, q, id)
+}
+
+// GetDeletedCommentByID gets and returns a soft-deleted record specified by id.
+// The comments of the soft-deleted threads are not returned, because they are restored with their threads.
+func (repo *commentRepository) GetDeletedCommentByID(ctx context.Context, m query.SQLManager, id uint32) (*model.Comment, error) {
+	q := `SELECT c.id, c.content, u.id, u.name, c.thread_id, c.edit_count, c.created_at, c.updated_at
+	FROM comments AS c
+	INNER JOIN users AS u
+	ON c.user_id = u.id
+	INNER JOIN threads AS t
+	ON c.thread_id = t.id
+	WHERE c.id=?
+	AND c.deleted_at IS NOT NULL
+	AND t.deleted_at IS NULL
+	LIMIT 1;`
+
+	return repo.getByID(ctx, m, q, id)
+}
+
+// getByID gets and returns a record specified by id with q.
+func (repo *commentRepository) getByID(ctx context.Context, m query.SQLManager, q string, id uint32) (*model.Comment, error) {
 	comments, err := repo.list(ctx, m, model.RepositoryMethodREAD, q, id)
 
 	if len(comments) == 0 {
@@ -120,7 +164,7 @@ func (repo *commentRepository) list(ctx context.Context, m query.SQLManager, met
 	defer func() {
 		err = stmt.Close()
 		if err != nil {
-			logger.Logger.Error("stmt.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
@@ -134,7 +178,7 @@ func (repo *commentRepository) list(ctx context.Context, m query.SQLManager, met
 	defer func() {
 		err = rows.Close()
 		if err != nil {
-			logger.Logger.Error("rows.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("rows.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
@@ -150,6 +194,7 @@ func (repo *commentRepository) list(ctx context.Context, m query.SQLManager, met
 			&comment.User.ID,
 			&comment.User.Name,
 			&comment.ThreadID,
+			&comment.EditCount,
 			&comment.CreatedAt,
 			&comment.UpdatedAt,
 		)
@@ -158,6 +203,7 @@ func (repo *commentRepository) list(ctx context.Context, m query.SQLManager, met
 			err = errors.Wrap(err, "failed to scan rows")
 			return nil, repo.ErrorMsg(method, err)
 		}
+		comment.Edited = comment.EditCount > 0
 
 		list = append(list, comment)
 	}
@@ -176,7 +222,7 @@ func (repo *commentRepository) InsertComment(ctx context.Context, m query.SQLMan
 	defer func() {
 		err = stmt.Close()
 		if err != nil {
-			logger.Logger.Error("stmt.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
@@ -203,7 +249,7 @@ func (repo *commentRepository) InsertComment(ctx context.Context, m query.SQLMan
 
 // UpdateComment updates a record.
 func (repo *commentRepository) UpdateComment(ctx context.Context, m query.SQLManager, id uint32, comment *model.Comment) error {
-	q := "UPDATE comments SET content=?, updated_at= NOW() WHERE id=?;"
+	q := "UPDATE comments SET content=?, edit_count=?, updated_at=? WHERE id=?;"
 
 	stmt, err := m.PrepareContext(ctx, q)
 	if err != nil {
@@ -214,11 +260,11 @@ func (repo *commentRepository) UpdateComment(ctx context.Context, m query.SQLMan
 	defer func() {
 		err = stmt.Close()
 		if err != nil {
-			logger.Logger.Error("stmt.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
-	result, err := stmt.ExecContext(ctx, comment.Content, id)
+	result, err := stmt.ExecContext(ctx, comment.Content, comment.EditCount, comment.UpdatedAt, id)
 	if err != nil {
 		err = errors.Wrap(err, "failed to execute context")
 		return repo.ErrorMsg(model.RepositoryMethodUPDATE, err)
@@ -233,37 +279,91 @@ func (repo *commentRepository) UpdateComment(ctx context.Context, m query.SQLMan
 	return nil
 }
 
-// DeleteComment delete a record.
-func (repo *commentRepository) DeleteComment(ctx context.Context, m query.SQLManager, id uint32) error {
-	q := "DELETE FROM comments WHERE id=?;"
+// DeleteComment soft-deletes a record, which is hidden until it is restored or purged.
+func (repo *commentRepository) DeleteComment(ctx context.Context, m query.SQLManager, id, deletedBy uint32, deletedAt time.Time) error {
+	q := "UPDATE comments SET deleted_at=?, deleted_by=? WHERE id=? AND deleted_at IS NULL;"
+
+	affect, err := repo.exec(ctx, m, model.RepositoryMethodDELETE, q, deletedAt, deletedBy, id)
+	if err != nil {
+		return err
+	}
+	if affect != 1 {
+		err = errors.Errorf("total affected id: %d ", affect)
+		return repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+	}
+
+	return nil
+}
+
+// DeleteCommentsByThreadID soft-deletes all records of the thread, and returns the number of deleted records.
+// deletedAt should be the same as the thread's, so that they are restored with it.
+func (repo *commentRepository) DeleteCommentsByThreadID(ctx context.Context, m query.SQLManager, threadID, deletedBy uint32, deletedAt time.Time) (int64, error) {
+	q := "UPDATE comments SET deleted_at=?, deleted_by=? WHERE thread_id=? AND deleted_at IS NULL;"
+	return repo.exec(ctx, m, model.RepositoryMethodDELETE, q, deletedAt, deletedBy, threadID)
+}
+
+// RestoreComment restores a soft-deleted record.
+func (repo *commentRepository) RestoreComment(ctx context.Context, m query.SQLManager, id uint32) error {
+	q := "UPDATE comments SET deleted_at=NULL, deleted_by=NULL WHERE id=? AND deleted_at IS NOT NULL;"
+
+	affect, err := repo.exec(ctx, m, model.RepositoryMethodUPDATE, q, id)
+	if err != nil {
+		return err
+	}
+	if affect != 1 {
+		err = errors.Errorf("total affected id: %d ", affect)
+		return repo.ErrorMsg(model.RepositoryMethodUPDATE, err)
+	}
+
+	return nil
+}
+
+// RestoreCommentsByThreadID restores the records which have been soft-deleted with the thread,
+// and returns the number of restored records. It must be called before the thread is restored.
+// The comments which had been deleted before the thread are kept deleted.
+func (repo *commentRepository) RestoreCommentsByThreadID(ctx context.Context, m query.SQLManager, threadID uint32) (int64, error) {
+	q := `UPDATE comments AS c
+	INNER JOIN threads AS t
+	ON c.thread_id = t.id
+	SET c.deleted_at=NULL, c.deleted_by=NULL
+	WHERE t.id=?
+	AND c.deleted_at = t.deleted_at
+	AND c.deleted_by <=> t.deleted_by;`
+	return repo.exec(ctx, m, model.RepositoryMethodUPDATE, q, threadID)
+}
 
+// PurgeDeletedComments hard-deletes records which have been soft-deleted before deletedBefore up to limit,
+// and returns the number of purged records.
+func (repo *commentRepository) PurgeDeletedComments(ctx context.Context, m query.SQLManager, deletedBefore time.Time, limit int) (int64, error) {
+	q := "DELETE FROM comments WHERE deleted_at <= ? LIMIT ?;"
+	return repo.exec(ctx, m, model.RepositoryMethodDELETE, q, deletedBefore, limit)
+}
+
+// exec executes q, and returns the number of affected records.
+func (repo *commentRepository) exec(ctx context.Context, m query.SQLManager, method model.RepositoryMethod, q string, args ...interface{}) (int64, error) {
 	stmt, err := m.PrepareContext(ctx, q)
 	if err != nil {
 		err = errors.Wrap(err, "failed to prepare context")
-		return repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+		return 0, repo.ErrorMsg(method, err)
 	}
 	defer func() {
 		err = stmt.Close()
 		if err != nil {
-			logger.Logger.Error("stmt.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
-	result, err := stmt.ExecContext(ctx, id)
+	result, err := stmt.ExecContext(ctx, args...)
 	if err != nil {
 		err = errors.Wrap(err, "failed to execute context")
-		return repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+		return 0, repo.ErrorMsg(method, err)
 	}
 
 	affect, err := result.RowsAffected()
 	if err != nil {
 		err = errors.Wrap(err, "failed get rows affected")
-		return repo.ErrorMsg(model.RepositoryMethodDELETE, err)
-	}
-	if affect != 1 {
-		err = errors.Errorf("total affected id: %d ", affect)
-		return repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+		return 0, repo.ErrorMsg(method, err)
 	}
 
-	return nil
+	return affect, nil
 }
@@ -184,19 +184,24 @@ func Test_commentRepository_ListComments(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			// the comments of the soft-deleted thread must be hidden.
 			q := `SELECT (.+)
 	FROM comments AS c
+	INNER JOIN threads AS t
+	ON c.thread_id = t.id
 	INNER JOIN users AS u
+	(.+)
+	AND t.deleted_at IS NULL
 	(.+);`
 			prep := mock.ExpectPrepare(q)
 
 			if tt.wantErr != nil {
 				prep.ExpectQuery().WithArgs(tt.args.cursor, readyLimitForHasNext(tt.args.limit)).WillReturnError(tt.wantErr)
 			} else {
-				rows := sqlmock.NewRows([]string{"c.id", "c.content", "u.id", "u.name", "c.comment_id", "c.created_at", "c.updated_at"})
+				rows := sqlmock.NewRows([]string{"c.id", "c.content", "u.id", "u.name", "c.comment_id", "c.edit_count", "c.created_at", "c.updated_at"})
 
 				for _, comment := range tt.returnMock {
-					rows.AddRow(comment.ID, comment.Content, comment.User.ID, comment.User.Name, comment.ThreadID, comment.CreatedAt, comment.UpdatedAt)
+					rows.AddRow(comment.ID, comment.Content, comment.User.ID, comment.User.Name, comment.ThreadID, comment.EditCount, comment.CreatedAt, comment.UpdatedAt)
 				}
 
 				prep.ExpectQuery().WithArgs(tt.args.cursor, tt.args.commentID, readyLimitForHasNext(tt.args.limit)).WillReturnRows(rows)
@@ -256,7 +261,9 @@ func Test_commentRepository_GetCommentByID(t *testing.T) {
 					ID:   model.UserValidIDForTest,
 					Name: model.UserNameForTest,
 				},
-				Content: model.CommentContentForTest,
+				Content:   model.CommentContentForTest,
+				Edited:    true,
+				EditCount: 2,
 			},
 			wantErr: nil,
 		},
@@ -286,8 +293,8 @@ func Test_commentRepository_GetCommentByID(t *testing.T) {
 			if tt.wantErr != nil {
 				prep.ExpectQuery().WillReturnError(tt.wantErr)
 			} else {
-				rows := sqlmock.NewRows([]string{"c.id", "c.content", "u.id", "u.name", "c.comment_id", "c.created_at", "c.updated_at"}).
-					AddRow(tt.want.ID, tt.want.Content, tt.want.User.ID, tt.want.User.Name, tt.want.ThreadID, tt.want.CreatedAt, tt.want.UpdatedAt)
+				rows := sqlmock.NewRows([]string{"c.id", "c.content", "u.id", "u.name", "c.comment_id", "c.edit_count", "c.created_at", "c.updated_at"}).
+					AddRow(tt.want.ID, tt.want.Content, tt.want.User.ID, tt.want.User.Name, tt.want.ThreadID, tt.want.EditCount, tt.want.CreatedAt, tt.want.UpdatedAt)
 				prep.ExpectQuery().WithArgs(tt.want.ID).WillReturnRows(rows)
 			}
 
@@ -308,6 +315,72 @@ func Test_commentRepository_GetCommentByID(t *testing.T) {
 	}
 }
 
+func Test_commentRepository_GetCommentByIDForUpdate(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	testutil.SetFakeTime(time.Now())
+
+	want := &model.Comment{
+		ID:      model.CommentValidIDForTest,
+		Content: model.CommentContentForTest,
+		User: &model.User{
+			ID:   model.UserValidIDForTest,
+			Name: model.UserNameForTest,
+		},
+		ThreadID:  model.ThreadValidIDForTest,
+		Edited:    true,
+		EditCount: 1,
+		CreatedAt: testutil.TimeNow(),
+		UpdatedAt: testutil.TimeNow(),
+	}
+
+	tests := []struct {
+		name    string
+		exists  bool
+		want    *model.Comment
+		wantErr bool
+	}{
+		{
+			name:   "When a comment specified by id exists, returns it with the lock",
+			exists: true,
+			want:   want,
+		},
+		{
+			name:    "When a comment specified by id does not exist, returns NoSuchDataError",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := sqlmock.NewRows([]string{"c.id", "c.content", "u.id", "u.name", "c.thread_id", "c.edit_count", "c.created_at", "c.updated_at"})
+			if tt.exists {
+				rows.AddRow(want.ID, want.Content, want.User.ID, want.User.Name, want.ThreadID, want.EditCount, want.CreatedAt, want.UpdatedAt)
+			}
+			mock.ExpectPrepare("WHERE c.id=\\?\\s+AND c.deleted_at IS NULL\\s+LIMIT 1\\s+FOR UPDATE").
+				ExpectQuery().WithArgs(model.CommentValidIDForTest).WillReturnRows(rows)
+
+			repo := &commentRepository{}
+			got, err := repo.GetCommentByIDForUpdate(context.Background(), db, model.CommentValidIDForTest)
+			if tt.wantErr {
+				if _, ok := errors.Cause(err).(*model.NoSuchDataError); !ok {
+					t.Errorf("commentRepository.GetCommentByIDForUpdate() error = %v, want NoSuchDataError", err)
+				}
+				return
+			}
+
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("commentRepository.GetCommentByIDForUpdate() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
 func Test_commentRepository_InsertComment(t *testing.T) {
 	// set sqlmock
 	db, mock, err := sqlmock.New()
@@ -552,10 +625,10 @@ func Test_commentRepository_UpdateComment(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			query := "UPDATE comments SET title=\\?, updated_at=\\? WHERE id=\\?"
+			query := "UPDATE comments SET content=\\?, edit_count=\\?, updated_at=\\? WHERE id=\\?;"
 			prep := mock.ExpectPrepare(query)
 
-			exec := prep.ExpectExec().WithArgs(tt.args.comment.Content, tt.args.comment.User.ID, tt.args.comment.ThreadID, tt.args.comment.CreatedAt, tt.args.comment.UpdatedAt)
+			exec := prep.ExpectExec().WithArgs(tt.args.comment.Content, tt.args.comment.EditCount, tt.args.comment.UpdatedAt, tt.args.id)
 
 			if tt.args.err != nil {
 				exec.WillReturnError(tt.args.err)
@@ -570,6 +643,8 @@ func Test_commentRepository_UpdateComment(t *testing.T) {
 					t.Errorf("commentRepository.UpdateComment() error = %v, wantErr %v", err, tt.wantErr)
 					return
 				}
+			} else if err != nil {
+				t.Errorf("commentRepository.UpdateComment() error = %v, wantErr nil", err)
 			}
 		})
 	}
@@ -649,18 +724,18 @@ func Test_commentRepository_DeleteComment(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			query := "DELETE FROM comments WHERE id=\\?"
+			query := "UPDATE comments SET deleted_at=\\?, deleted_by=\\? WHERE id=\\? AND deleted_at IS NULL"
 			prep := mock.ExpectPrepare(query)
 
 			if tt.args.err != nil {
-				prep.ExpectExec().WithArgs(tt.args.id).WillReturnError(tt.args.err)
+				prep.ExpectExec().WithArgs(testutil.TimeNow(), model.UserValidIDForTest, tt.args.id).WillReturnError(tt.args.err)
 			} else {
-				prep.ExpectExec().WithArgs(tt.args.id).WillReturnResult(sqlmock.NewResult(1, tt.rowAffected))
+				prep.ExpectExec().WithArgs(testutil.TimeNow(), model.UserValidIDForTest, tt.args.id).WillReturnResult(sqlmock.NewResult(1, tt.rowAffected))
 			}
 
 			repo := &commentRepository{}
 
-			err := repo.DeleteComment(tt.args.ctx, tt.args.m, tt.args.id)
+			err := repo.DeleteComment(tt.args.ctx, tt.args.m, tt.args.id, model.UserValidIDForTest, testutil.TimeNow())
 			if tt.wantErr != nil {
 				if errors.Cause(err).Error() != tt.wantErr.Error() {
 					t.Errorf("commentRepository.DeleteComment() error = %v, wantErr %v", err, tt.wantErr)
@@ -670,3 +745,238 @@ func Test_commentRepository_DeleteComment(t *testing.T) {
 		})
 	}
 }
+
+func Test_commentRepository_DeleteCommentsByThreadID(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+	testutil.SetFakeTime(time.Now())
+
+	tests := []struct {
+		name        string
+		rowAffected int64
+		err         error
+		want        int64
+		wantErr     *model.RepositoryError
+	}{
+		{
+			name:        "When the thread has comments, returns the number of soft-deleted comments",
+			rowAffected: 3,
+			want:        3,
+		},
+		{
+			name:        "When the thread has no comment, returns 0",
+			rowAffected: 0,
+			want:        0,
+		},
+		{
+			name: "when DB error has occurred、returns error",
+			err:  errors.New(model.ErrorMessageForTest),
+			wantErr: &model.RepositoryError{
+				RepositoryMethod: model.RepositoryMethodDELETE,
+				DomainModelName:  model.DomainModelNameComment,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prep := mock.ExpectPrepare("UPDATE comments SET deleted_at=\\?, deleted_by=\\? WHERE thread_id=\\? AND deleted_at IS NULL")
+			if tt.err != nil {
+				prep.ExpectExec().WithArgs(testutil.TimeNow(), model.UserValidIDForTest, model.ThreadValidIDForTest).WillReturnError(tt.err)
+			} else {
+				prep.ExpectExec().WithArgs(testutil.TimeNow(), model.UserValidIDForTest, model.ThreadValidIDForTest).WillReturnResult(sqlmock.NewResult(0, tt.rowAffected))
+			}
+
+			repo := &commentRepository{}
+			got, err := repo.DeleteCommentsByThreadID(context.Background(), db, model.ThreadValidIDForTest, model.UserValidIDForTest, testutil.TimeNow())
+			if tt.wantErr != nil {
+				if errors.Cause(err).Error() != tt.wantErr.Error() {
+					t.Errorf("commentRepository.DeleteCommentsByThreadID() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil || got != tt.want {
+				t.Errorf("commentRepository.DeleteCommentsByThreadID() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_commentRepository_RestoreComment(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	tests := []struct {
+		name        string
+		rowAffected int64
+		err         error
+		wantErr     *model.RepositoryError
+	}{
+		{
+			name:        "When a deleted comment specified by id exists, returns nil",
+			rowAffected: 1,
+		},
+		{
+			name:        "when RowAffected is 0、returns error",
+			rowAffected: 0,
+			wantErr: &model.RepositoryError{
+				RepositoryMethod: model.RepositoryMethodUPDATE,
+				DomainModelName:  model.DomainModelNameComment,
+			},
+		},
+		{
+			name: "when DB error has occurred、returns error",
+			err:  errors.New(model.ErrorMessageForTest),
+			wantErr: &model.RepositoryError{
+				RepositoryMethod: model.RepositoryMethodUPDATE,
+				DomainModelName:  model.DomainModelNameComment,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prep := mock.ExpectPrepare("UPDATE comments SET deleted_at=NULL, deleted_by=NULL WHERE id=\\? AND deleted_at IS NOT NULL")
+			if tt.err != nil {
+				prep.ExpectExec().WithArgs(model.CommentValidIDForTest).WillReturnError(tt.err)
+			} else {
+				prep.ExpectExec().WithArgs(model.CommentValidIDForTest).WillReturnResult(sqlmock.NewResult(0, tt.rowAffected))
+			}
+
+			repo := &commentRepository{}
+			err := repo.RestoreComment(context.Background(), db, model.CommentValidIDForTest)
+			if tt.wantErr != nil {
+				if errors.Cause(err).Error() != tt.wantErr.Error() {
+					t.Errorf("commentRepository.RestoreComment() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("commentRepository.RestoreComment() error = %v", err)
+			}
+		})
+	}
+}
+
+func Test_commentRepository_RestoreCommentsByThreadID(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	tests := []struct {
+		name        string
+		rowAffected int64
+		err         error
+		want        int64
+		wantErr     *model.RepositoryError
+	}{
+		{
+			name:        "When some comments have been deleted with the thread, returns the number of restored comments",
+			rowAffected: 3,
+			want:        3,
+		},
+		{
+			name: "when DB error has occurred、returns error",
+			err:  errors.New(model.ErrorMessageForTest),
+			wantErr: &model.RepositoryError{
+				RepositoryMethod: model.RepositoryMethodUPDATE,
+				DomainModelName:  model.DomainModelNameComment,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// only the comments which have been deleted with the thread are restored.
+			prep := mock.ExpectPrepare("WHERE t.id=\\?\\s+AND c.deleted_at = t.deleted_at\\s+AND c.deleted_by <=> t.deleted_by")
+			if tt.err != nil {
+				prep.ExpectExec().WithArgs(model.ThreadValidIDForTest).WillReturnError(tt.err)
+			} else {
+				prep.ExpectExec().WithArgs(model.ThreadValidIDForTest).WillReturnResult(sqlmock.NewResult(0, tt.rowAffected))
+			}
+
+			repo := &commentRepository{}
+			got, err := repo.RestoreCommentsByThreadID(context.Background(), db, model.ThreadValidIDForTest)
+			if tt.wantErr != nil {
+				if errors.Cause(err).Error() != tt.wantErr.Error() {
+					t.Errorf("commentRepository.RestoreCommentsByThreadID() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil || got != tt.want {
+				t.Errorf("commentRepository.RestoreCommentsByThreadID() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_commentRepository_PurgeDeletedComments(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	testutil.SetFakeTime(time.Now())
+
+	tests := []struct {
+		name        string
+		rowAffected int64
+		err         error
+		want        int64
+		wantErr     *model.RepositoryError
+	}{
+		{
+			name:        "When some comments have been deleted before the time, returns the number of purged comments",
+			rowAffected: 3,
+			want:        3,
+		},
+		{
+			name: "when DB error has occurred、returns error",
+			err:  errors.New(model.ErrorMessageForTest),
+			wantErr: &model.RepositoryError{
+				RepositoryMethod: model.RepositoryMethodDELETE,
+				DomainModelName:  model.DomainModelNameComment,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prep := mock.ExpectPrepare("DELETE FROM comments WHERE deleted_at <= \\? LIMIT \\?")
+			if tt.err != nil {
+				prep.ExpectExec().WithArgs(testutil.TimeNow(), 100).WillReturnError(tt.err)
+			} else {
+				prep.ExpectExec().WithArgs(testutil.TimeNow(), 100).WillReturnResult(sqlmock.NewResult(0, tt.rowAffected))
+			}
+
+			repo := &commentRepository{}
+			got, err := repo.PurgeDeletedComments(context.Background(), db, testutil.TimeNow(), 100)
+			if tt.wantErr != nil {
+				if errors.Cause(err).Error() != tt.wantErr.Error() {
+					t.Errorf("commentRepository.PurgeDeletedComments() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil || got != tt.want {
+				t.Errorf("commentRepository.PurgeDeletedComments() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/config"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
 
@@ -16,16 +18,30 @@ type dbManager struct {
 	Conn *sql.DB
 }
 
-// NewDBManager generates and returns DBManager.
-func NewDBManager() query.DBManager {
-	conn, err := sql.Open("mysql", "root:@tcp(nvgdb:3306)/nuxt_vue_go_chat?charset=utf8mb4&parseTime=True")
+// Open opens the connection pool configured by c.
+func Open(c config.DB) (*sql.DB, error) {
+	conn, err := sql.Open("mysql", c.DSN)
 	if err != nil {
-		panic(err.Error())
+		return nil, errors.Wrap(err, "failed to open db")
+	}
+
+	conn.SetMaxOpenConns(c.MaxOpenConns)
+	conn.SetMaxIdleConns(c.MaxIdleConns)
+	conn.SetConnMaxLifetime(c.ConnMaxLifetime)
+
+	return conn, nil
+}
+
+// NewDBManager generates and returns DBManager which has the connection pool configured by c.
+func NewDBManager(c config.DB) (query.DBManager, error) {
+	conn, err := Open(c)
+	if err != nil {
+		return nil, err
 	}
 
 	return &dbManager{
 		Conn: conn,
-	}
+	}, nil
 }
 
 // Exec executes SQL.
@@ -54,7 +70,7 @@ func (s *dbManager) Query(query string, args ...interface{}) (*sql.Rows, error)
 
 // QueryContext executes query which return row with context.
 func (s *dbManager) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
-	rows, err := s.Conn.Query(query, args...)
+	rows, err := s.Conn.QueryContext(ctx, query, args...)
 	if err != nil {
 		err = &model.SQLError{
 			BaseErr:                   err,
@@ -75,6 +91,21 @@ func (s *dbManager) PrepareContext(ctx context.Context, query string) (*sql.Stmt
 	return s.Conn.PrepareContext(ctx, query)
 }
 
+// PingContext verifies the connection to the database is alive.
+func (s *dbManager) PingContext(ctx context.Context) error {
+	return s.Conn.PingContext(ctx)
+}
+
+// Stats returns the statistics of the connection pool.
+func (s *dbManager) Stats() sql.DBStats {
+	return s.Conn.Stats()
+}
+
+// Close closes the connection pool, waiting for the queries which have started.
+func (s *dbManager) Close() error {
+	return s.Conn.Close()
+}
+
 // Begin begins tx.
 func (s *dbManager) Begin() (query.TxManager, error) {
 	return s.Conn.Begin()
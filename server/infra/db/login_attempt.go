@@ -0,0 +1,130 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+)
+
+// loginAttemptRepository is repository of login attempt, which is shared among instances.
+type loginAttemptRepository struct {
+}
+
+// NewLoginAttemptRepository generates and returns LoginAttemptRepository.
+func NewLoginAttemptRepository() repository.LoginAttemptRepository {
+	return &loginAttemptRepository{}
+}
+
+// ErrorMsg generates and returns error message.
+func (repo *loginAttemptRepository) ErrorMsg(method model.RepositoryMethod, err error) error {
+	return &model.RepositoryError{
+		BaseErr:          err,
+		RepositoryMethod: method,
+		DomainModelName:  model.DomainModelNameLoginAttempt,
+	}
+}
+
+// GetLoginAttempt gets and returns a record specified by key.
+func (repo *loginAttemptRepository) GetLoginAttempt(ctx context.Context, m query.SQLManager, key string) (*model.LoginAttempt, error) {
+	q := "SELECT attempt_key, failures, last_failed_at FROM login_attempts WHERE attempt_key=?"
+
+	stmt, err := m.PrepareContext(ctx, q)
+	if err != nil {
+		err = errors.Wrap(err, "failed to prepare context")
+		return nil, repo.ErrorMsg(model.RepositoryMethodREAD, err)
+	}
+	defer func() {
+		err = stmt.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	rows, err := stmt.QueryContext(ctx, key)
+	if err != nil {
+		err = errors.Wrap(err, "failed to execute context")
+		return nil, repo.ErrorMsg(model.RepositoryMethodREAD, err)
+	}
+	defer func() {
+		err = rows.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("rows.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	if !rows.Next() {
+		err = &model.NoSuchDataError{
+			PropertyName:    model.KeyProperty,
+			PropertyValue:   key,
+			DomainModelName: model.DomainModelNameLoginAttempt,
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	attempt := &model.LoginAttempt{}
+	if err := rows.Scan(&attempt.Key, &attempt.Failures, &attempt.LastFailedAt); err != nil {
+		err = errors.Wrap(err, "failed to scan rows")
+		return nil, repo.ErrorMsg(model.RepositoryMethodREAD, err)
+	}
+
+	return attempt, nil
+}
+
+// IncrementLoginAttempt increments the failures of a record, or inserts a record if it does not exist.
+// The failures before forgetBefore are forgotten, and counted from 1 again.
+// It is a single statement, so that concurrent failures on several instances are all counted.
+func (repo *loginAttemptRepository) IncrementLoginAttempt(ctx context.Context, m query.SQLManager, key string, now, forgetBefore time.Time) error {
+	// failures is assigned before last_failed_at, so that it refers to the last failure before this one.
+	q := `INSERT INTO login_attempts (attempt_key, failures, last_failed_at) VALUES (?, 1, ?)
+ON DUPLICATE KEY UPDATE failures = IF(last_failed_at <= ?, 1, failures + 1), last_failed_at = VALUES(last_failed_at)`
+
+	stmt, err := m.PrepareContext(ctx, q)
+	if err != nil {
+		err = errors.Wrap(err, "failed to prepare context")
+		return repo.ErrorMsg(model.RepositoryMethodUPDATE, err)
+	}
+	defer func() {
+		err = stmt.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	if _, err := stmt.ExecContext(ctx, key, now, forgetBefore); err != nil {
+		err = errors.Wrap(err, "failed to execute context")
+		return repo.ErrorMsg(model.RepositoryMethodUPDATE, err)
+	}
+
+	return nil
+}
+
+// DeleteLoginAttempt deletes a record specified by key.
+// It is not an error that the record does not exist.
+func (repo *loginAttemptRepository) DeleteLoginAttempt(ctx context.Context, m query.SQLManager, key string) error {
+	q := "DELETE FROM login_attempts WHERE attempt_key=?"
+
+	stmt, err := m.PrepareContext(ctx, q)
+	if err != nil {
+		err = errors.Wrap(err, "failed to prepare context")
+		return repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+	}
+	defer func() {
+		err = stmt.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	if _, err := stmt.ExecContext(ctx, key); err != nil {
+		err = errors.Wrap(err, "failed to execute context")
+		return repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+	}
+
+	return nil
+}
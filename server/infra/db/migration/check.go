@@ -0,0 +1,56 @@
+package migration
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+)
+
+// Check checks whether all the migrations have been applied and none of them is dirty.
+func Check(ctx context.Context, m query.SQLManager, migrations []*Migration) error {
+	rows, err := m.QueryContext(ctx, "SELECT version, dirty FROM schema_migrations")
+	if err != nil {
+		return errors.Wrap(err, "failed to query schema_migrations")
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.FromContext(ctx).Error("rows.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	applied := make(map[uint64]bool)
+	for rows.Next() {
+		var version uint64
+		var dirty bool
+		if err := rows.Scan(&version, &dirty); err != nil {
+			return errors.Wrap(err, "failed to scan schema_migrations")
+		}
+		if dirty {
+			return errors.WithStack(&DirtyError{Version: version})
+		}
+		applied[version] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "failed to iterate schema_migrations")
+	}
+
+	var pending []string
+	for _, migration := range migrations {
+		if !applied[migration.Version] {
+			pending = append(pending, strconv.FormatUint(migration.Version, 10))
+		}
+	}
+
+	if len(pending) > 0 {
+		return errors.Errorf("schema has not been migrated, pending versions: %s", strings.Join(pending, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestCheck(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	tests := []struct {
+		name      string
+		versions  map[uint64]bool
+		wantErr   bool
+		wantDirty bool
+	}{
+		{
+			name:     "When all versions have been applied, returns nil",
+			versions: map[uint64]bool{1: false, 2: false},
+		},
+		{
+			name:     "When some versions are pending, returns error",
+			versions: map[uint64]bool{1: false},
+			wantErr:  true,
+		},
+		{
+			name:      "When a version is dirty, returns DirtyError",
+			versions:  map[uint64]bool{1: false, 2: true},
+			wantErr:   true,
+			wantDirty: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := sqlmock.NewRows([]string{"version", "dirty"})
+			for version, dirty := range tt.versions {
+				rows.AddRow(version, dirty)
+			}
+			mock.ExpectQuery("SELECT version, dirty FROM schema_migrations").WillReturnRows(rows)
+
+			err := Check(context.Background(), db, migrationsForTest())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, ok := errors.Cause(err).(*DirtyError); ok != tt.wantDirty {
+				t.Errorf("Check() error = %#v, wantDirty %v", err, tt.wantDirty)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// gen embeds the SQL files of the migrations into the Go source, so that they are built into the binary.
+// It is run by go generate in the directory of the migration package.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// paths relative to the migration package.
+const (
+	sqlDir     = "sql"
+	outputFile = "sql_files.go"
+)
+
+func main() {
+	src, err := generate(sqlDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(outputFile, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the Go source which has the SQL files in dir.
+func generate(dir string) ([]byte, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen/main.go; DO NOT EDIT.\n\n")
+	buf.WriteString("package migration\n\n")
+	buf.WriteString("// sqlFiles is the SQL files of the migrations by their names.\n")
+	buf.WriteString("var sqlFiles = map[string]string{\n")
+
+	// ReadDir returns the files sorted by name.
+	for _, info := range infos {
+		if info.IsDir() || filepath.Ext(info.Name()) != ".sql" {
+			continue
+		}
+
+		content, err := ioutil.ReadFile(filepath.Join(dir, info.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		fmt.Fprintf(&buf, "%q: %s,\n", info.Name(), quote(string(content)))
+	}
+
+	buf.WriteString("}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// quote returns the Go literal of s, which is the raw string literal if possible so that the SQL is readable.
+func quote(s string) string {
+	if strings.Contains(s, "`") || strings.Contains(s, "\r") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
@@ -0,0 +1,109 @@
+// Package migration migrates the schema of the database by the versioned SQL.
+// The SQL files in sql are embedded into sql_files.go by go generate, so that the binary has them.
+package migration
+
+//go:generate go run gen/main.go
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// fileNamePattern is the pattern of the names of the SQL files, e.g. 0001_init.up.sql.
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a version of the schema.
+// The statements of Up migrate the schema to the version, and the statements of Down revert it.
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      []string
+	Down    []string
+}
+
+// Migrations returns the migrations embedded into the binary in the order of their versions.
+func Migrations() ([]*Migration, error) {
+	return parse(sqlFiles)
+}
+
+// parse parses the SQL files by their names into the migrations in the order of their versions.
+// Every version must have both of the up and the down files.
+func parse(files map[string]string) ([]*Migration, error) {
+	byVersion := make(map[uint64]*Migration)
+	ups := make(map[uint64]bool)
+	downs := make(map[uint64]bool)
+
+	for name, content := range files {
+		matches := fileNamePattern.FindStringSubmatch(name)
+		if matches == nil {
+			return nil, errors.Errorf("invalid name of migration file, %s", name)
+		}
+
+		version, err := strconv.ParseUint(matches[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid version of migration file, %s", name)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = m
+		}
+		if m.Name != matches[2] {
+			return nil, errors.Errorf("version %d has different names, %s and %s", version, m.Name, matches[2])
+		}
+
+		switch matches[3] {
+		case "up":
+			m.Up = splitStatements(content)
+			ups[version] = true
+		case "down":
+			m.Down = splitStatements(content)
+			downs[version] = true
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for version, m := range byVersion {
+		if !ups[version] || !downs[version] {
+			return nil, errors.Errorf("version %d must have both of up and down files", version)
+		}
+		migrations = append(migrations, m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// splitStatements splits the SQL into the statements, which end with semicolons at the ends of lines,
+// because the driver does not execute multiple statements at once. The lines of comments are removed.
+func splitStatements(sql string) []string {
+	var statements []string
+	var current []string
+
+	for _, line := range strings.Split(sql, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+
+		current = append(current, strings.TrimRight(line, " \t\r"))
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSuffix(strings.Join(current, "\n"), ";"))
+			current = nil
+		}
+	}
+
+	if len(current) > 0 {
+		statements = append(statements, strings.Join(current, "\n"))
+	}
+
+	return statements
+}
@@ -0,0 +1,115 @@
+package migration
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMigrations_embedded(t *testing.T) {
+	infos, err := ioutil.ReadDir("sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(infos) != len(sqlFiles) {
+		t.Fatalf("the number of embedded files = %d, want %d, run go generate", len(sqlFiles), len(infos))
+	}
+
+	for _, info := range infos {
+		content, err := ioutil.ReadFile(filepath.Join("sql", info.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sqlFiles[info.Name()] != string(content) {
+			t.Errorf("%s is not embedded as it is, run go generate", info.Name())
+		}
+	}
+
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatalf("Migrations() error = %v", err)
+	}
+	if len(migrations) == 0 || migrations[0].Version != 1 {
+		t.Errorf("Migrations() does not start with version 1")
+	}
+}
+
+func Test_parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    []*Migration
+		wantErr bool
+	}{
+		{
+			name: "When the files are valid, returns the migrations in the order of their versions",
+			files: map[string]string{
+				"0002_add_index.up.sql":   "CREATE INDEX a ON t (a);",
+				"0002_add_index.down.sql": "DROP INDEX a ON t;",
+				"0001_init.up.sql":        "CREATE TABLE t (\n  a INT\n);\nCREATE TABLE u (b INT);",
+				"0001_init.down.sql":      "-- drops all\nDROP TABLE u;\nDROP TABLE t;\n",
+			},
+			want: []*Migration{
+				{Version: 1, Name: "init", Up: []string{"CREATE TABLE t (\n  a INT\n)", "CREATE TABLE u (b INT)"}, Down: []string{"DROP TABLE u", "DROP TABLE t"}},
+				{Version: 2, Name: "add_index", Up: []string{"CREATE INDEX a ON t (a)"}, Down: []string{"DROP INDEX a ON t"}},
+			},
+		},
+		{
+			name:    "When the down file is missing, returns error",
+			files:   map[string]string{"0001_init.up.sql": "CREATE TABLE t (a INT);"},
+			wantErr: true,
+		},
+		{
+			name:    "When the name is invalid, returns error",
+			files:   map[string]string{"init.sql": "CREATE TABLE t (a INT);"},
+			wantErr: true,
+		},
+		{
+			name: "When the names of the version are different, returns error",
+			files: map[string]string{
+				"0001_init.up.sql":    "CREATE TABLE t (a INT);",
+				"0001_other.down.sql": "DROP TABLE t;",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parse(tt.files)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parse() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMigrations_foreignKeys(t *testing.T) {
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatalf("Migrations() error = %v", err)
+	}
+
+	// contains returns whether any of statements contains substr.
+	contains := func(statements []string, substr string) bool {
+		for _, s := range statements {
+			if strings.Contains(s, substr) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, m := range migrations {
+		if contains(m.Up, "CONSTRAINT fk_comments_thread_id FOREIGN KEY") {
+			return
+		}
+	}
+	t.Error("Migrations() does not add the foreign key of comments to threads")
+}
@@ -0,0 +1,286 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+)
+
+// lockName is the name of the advisory lock which serializes the migrations of the instances.
+const lockName = "nvgc_schema_migrations"
+
+// createTableQuery creates the table of the applied versions.
+// dirty is set while the version is being applied or reverted, because DDL of MySQL can not be rolled back.
+const createTableQuery = `CREATE TABLE IF NOT EXISTS schema_migrations (
+  version BIGINT UNSIGNED NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  dirty TINYINT(1) NOT NULL DEFAULT 0,
+  applied_at DATETIME NOT NULL,
+  PRIMARY KEY (version)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`
+
+// DirtyError is the error which occurs when the version has failed in the middle of applying or reverting.
+// The schema must be fixed by hand, and then the row of the version must be fixed or deleted.
+type DirtyError struct {
+	Version uint64
+}
+
+// Error returns error message.
+func (e *DirtyError) Error() string {
+	return fmt.Sprintf("version %d is dirty, fix the schema and schema_migrations by hand", e.Version)
+}
+
+// Status is the status of the migration.
+type Status struct {
+	Migration *Migration
+	Applied   bool
+	Dirty     bool
+	AppliedAt time.Time
+}
+
+// record is the row of schema_migrations.
+type record struct {
+	version   uint64
+	dirty     bool
+	appliedAt time.Time
+}
+
+// Migrator applies and reverts the migrations.
+type Migrator struct {
+	db          *sql.DB
+	migrations  []*Migration
+	lockTimeout time.Duration
+}
+
+// NewMigrator generates and returns Migrator of the migrations, which waits for the other instances until lockTimeout.
+func NewMigrator(db *sql.DB, migrations []*Migration, lockTimeout time.Duration) *Migrator {
+	return &Migrator{
+		db:          db,
+		migrations:  migrations,
+		lockTimeout: lockTimeout,
+	}
+}
+
+// Up applies the pending migrations in the order of their versions, and returns the applied ones.
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	var applied []*Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		records, err := readRecords(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if err := checkDirty(records); err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := records[migration.Version]; ok {
+				continue
+			}
+
+			if err := apply(ctx, conn, migration); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the latest steps of the applied migrations in the reverse order of their versions, and returns the reverted ones.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	var reverted []*Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		records, err := readRecords(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if err := checkDirty(records); err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := records[migration.Version]; !ok {
+				continue
+			}
+
+			if err := revert(ctx, conn, migration); err != nil {
+				return err
+			}
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status returns the statuses of the migrations in the order of their versions.
+func (m *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get connection")
+	}
+	defer closeConn(conn)
+
+	if _, err := conn.ExecContext(ctx, createTableQuery); err != nil {
+		return nil, errors.Wrap(err, "failed to create schema_migrations")
+	}
+
+	records, err := readRecords(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := &Status{Migration: migration}
+		if r, ok := records[migration.Version]; ok {
+			status.Applied = true
+			status.Dirty = r.dirty
+			status.AppliedAt = r.appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// withLock calls f with the connection which holds the advisory lock.
+// The lock is held by the connection, so that all the statements must be executed on it.
+func (m *Migrator) withLock(ctx context.Context, f func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get connection")
+	}
+	defer closeConn(conn)
+
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, int(m.lockTimeout/time.Second)).Scan(&locked); err != nil {
+		return errors.Wrap(err, "failed to get lock")
+	}
+	if !locked.Valid || locked.Int64 != 1 {
+		return errors.Errorf("failed to get lock in %s, other instance may be migrating", m.lockTimeout)
+	}
+
+	defer func() {
+		// the lock is released with the connection as well, so the failure is only logged.
+		var released sql.NullInt64
+		if err := conn.QueryRowContext(context.Background(), "SELECT RELEASE_LOCK(?)", lockName).Scan(&released); err != nil {
+			logger.Logger.Warn("failed to release lock", zap.String("error message", err.Error()))
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, createTableQuery); err != nil {
+		return errors.Wrap(err, "failed to create schema_migrations")
+	}
+
+	return f(conn)
+}
+
+// apply applies the migration, marking it dirty until all statements have been executed.
+func apply(ctx context.Context, conn *sql.Conn, migration *Migration) error {
+	q := "INSERT INTO schema_migrations (version, name, dirty, applied_at) VALUES (?, ?, 1, ?)"
+	if _, err := conn.ExecContext(ctx, q, migration.Version, migration.Name, time.Now().UTC()); err != nil {
+		return errors.Wrapf(err, "failed to record version %d", migration.Version)
+	}
+
+	if err := execStatements(ctx, conn, migration.Up); err != nil {
+		return errors.Wrapf(err, "failed to apply version %d", migration.Version)
+	}
+
+	if _, err := conn.ExecContext(ctx, "UPDATE schema_migrations SET dirty = 0 WHERE version = ?", migration.Version); err != nil {
+		return errors.Wrapf(err, "failed to record version %d", migration.Version)
+	}
+
+	logger.FromContext(ctx).Info("applied migration", zap.Uint64("version", migration.Version), zap.String("name", migration.Name))
+	return nil
+}
+
+// revert reverts the migration, marking it dirty until all statements have been executed.
+func revert(ctx context.Context, conn *sql.Conn, migration *Migration) error {
+	if _, err := conn.ExecContext(ctx, "UPDATE schema_migrations SET dirty = 1 WHERE version = ?", migration.Version); err != nil {
+		return errors.Wrapf(err, "failed to record version %d", migration.Version)
+	}
+
+	if err := execStatements(ctx, conn, migration.Down); err != nil {
+		return errors.Wrapf(err, "failed to revert version %d", migration.Version)
+	}
+
+	if _, err := conn.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", migration.Version); err != nil {
+		return errors.Wrapf(err, "failed to record version %d", migration.Version)
+	}
+
+	logger.FromContext(ctx).Info("reverted migration", zap.Uint64("version", migration.Version), zap.String("name", migration.Name))
+	return nil
+}
+
+// execStatements executes the statements in order.
+func execStatements(ctx context.Context, conn *sql.Conn, statements []string) error {
+	for _, statement := range statements {
+		if _, err := conn.ExecContext(ctx, statement); err != nil {
+			return errors.Wrapf(err, "failed to execute %s", statement)
+		}
+	}
+	return nil
+}
+
+// readRecords reads the applied versions by their versions.
+func readRecords(ctx context.Context, conn *sql.Conn) (map[uint64]*record, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, dirty, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query schema_migrations")
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.FromContext(ctx).Error("rows.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	records := make(map[uint64]*record)
+	for rows.Next() {
+		r := &record{}
+		if err := rows.Scan(&r.version, &r.dirty, &r.appliedAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan schema_migrations")
+		}
+		records[r.version] = r
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate schema_migrations")
+	}
+
+	return records, nil
+}
+
+// checkDirty returns DirtyError if any version is dirty.
+func checkDirty(records map[uint64]*record) error {
+	for _, r := range records {
+		if r.dirty {
+			return errors.WithStack(&DirtyError{Version: r.version})
+		}
+	}
+	return nil
+}
+
+// closeConn returns the connection to the pool.
+func closeConn(conn *sql.Conn) {
+	if err := conn.Close(); err != nil {
+		logger.Logger.Error("conn.Close", zap.String("error message", err.Error()))
+	}
+}
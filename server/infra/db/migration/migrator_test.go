@@ -0,0 +1,163 @@
+package migration
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+// migrationsForTest returns the migrations of two versions.
+func migrationsForTest() []*Migration {
+	return []*Migration{
+		{Version: 1, Name: "init", Up: []string{"CREATE TABLE t (a INT)"}, Down: []string{"DROP TABLE t"}},
+		{Version: 2, Name: "add_b", Up: []string{"ALTER TABLE t ADD COLUMN b INT"}, Down: []string{"ALTER TABLE t DROP COLUMN b"}},
+	}
+}
+
+// expectLockForTest sets the expectations of getting the lock and creating schema_migrations.
+func expectLockForTest(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT GET_LOCK(?, ?)")).WithArgs(lockName, 60).
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+// expectUnlockForTest sets the expectation of releasing the lock.
+func expectUnlockForTest(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT RELEASE_LOCK(?)")).WithArgs(lockName).
+		WillReturnRows(sqlmock.NewRows([]string{"released"}).AddRow(1))
+}
+
+// recordsRowsForTest returns the rows of schema_migrations.
+func recordsRowsForTest(versions map[uint64]bool) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"version", "dirty", "applied_at"})
+	for version, dirty := range versions {
+		rows.AddRow(version, dirty, time.Now())
+	}
+	return rows
+}
+
+func TestMigrator_Up(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	expectLockForTest(mock)
+	mock.ExpectQuery("SELECT version, dirty, applied_at FROM schema_migrations").WillReturnRows(recordsRowsForTest(map[uint64]bool{1: false}))
+	mock.ExpectExec("INSERT INTO schema_migrations").WithArgs(2, "add_b", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("ALTER TABLE t ADD COLUMN b INT").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("UPDATE schema_migrations SET dirty = 0").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectUnlockForTest(mock)
+
+	applied, err := NewMigrator(db, migrationsForTest(), time.Minute).Up(context.Background())
+	if err != nil {
+		t.Fatalf("Migrator.Up() error = %v", err)
+	}
+	if len(applied) != 1 || applied[0].Version != 2 {
+		t.Errorf("Migrator.Up() = %v, want version 2", applied)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMigrator_Up_dirty(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	expectLockForTest(mock)
+	mock.ExpectQuery("SELECT version, dirty, applied_at FROM schema_migrations").WillReturnRows(recordsRowsForTest(map[uint64]bool{1: true}))
+	expectUnlockForTest(mock)
+
+	_, err = NewMigrator(db, migrationsForTest(), time.Minute).Up(context.Background())
+	if e, ok := errors.Cause(err).(*DirtyError); !ok || e.Version != 1 {
+		t.Errorf("Migrator.Up() error = %#v, want DirtyError of version 1", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMigrator_Up_locked(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT GET_LOCK(?, ?)")).WithArgs(lockName, 60).
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(0))
+
+	if _, err := NewMigrator(db, migrationsForTest(), time.Minute).Up(context.Background()); err == nil {
+		t.Error("Migrator.Up() error = nil, want error when the lock is held by other instance")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMigrator_Down(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	expectLockForTest(mock)
+	mock.ExpectQuery("SELECT version, dirty, applied_at FROM schema_migrations").WillReturnRows(recordsRowsForTest(map[uint64]bool{1: false, 2: false}))
+	mock.ExpectExec("UPDATE schema_migrations SET dirty = 1").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("ALTER TABLE t DROP COLUMN b").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM schema_migrations").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectUnlockForTest(mock)
+
+	reverted, err := NewMigrator(db, migrationsForTest(), time.Minute).Down(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Migrator.Down() error = %v", err)
+	}
+	if len(reverted) != 1 || reverted[0].Version != 2 {
+		t.Errorf("Migrator.Down() = %v, want version 2", reverted)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMigrator_Status(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version, dirty, applied_at FROM schema_migrations").WillReturnRows(recordsRowsForTest(map[uint64]bool{1: false}))
+
+	statuses, err := NewMigrator(db, migrationsForTest(), time.Minute).Status(context.Background())
+	if err != nil {
+		t.Fatalf("Migrator.Status() error = %v", err)
+	}
+	if len(statuses) != 2 || !statuses[0].Applied || statuses[1].Applied {
+		t.Errorf("Migrator.Status() = %v, want version 1 applied and version 2 pending", statuses)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS comments;
+DROP TABLE IF EXISTS threads;
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS users;
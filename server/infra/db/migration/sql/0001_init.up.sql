@@ -1,4 +1,5 @@
-USE  nuxt_vue_go_chat;
+-- the schema which was created by mysql/init/setup.sql.
+-- IF NOT EXISTS adopts the databases which have been created by it, so that it must not be changed.
 
 CREATE TABLE IF NOT EXISTS users (
   id INT UNSIGNED NOT NULL AUTO_INCREMENT,
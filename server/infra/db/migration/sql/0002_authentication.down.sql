@@ -0,0 +1,19 @@
+-- users.password is not narrowed back, because the hashes of argon2id would not fit in it.
+
+DROP TABLE IF EXISTS login_attempts;
+DROP TABLE IF EXISTS api_tokens;
+DROP TABLE IF EXISTS password_reset_tokens;
+
+ALTER TABLE sessions
+  DROP KEY last_accessed_at,
+  DROP KEY expires_at,
+  DROP KEY user_id,
+  DROP COLUMN last_accessed_at,
+  DROP COLUMN expires_at,
+  DROP COLUMN ip,
+  DROP COLUMN user_agent;
+
+ALTER TABLE users
+  DROP COLUMN banned,
+  DROP COLUMN role,
+  ADD COLUMN session_id VARCHAR(36) NOT NULL DEFAULT '' AFTER name;
@@ -0,0 +1,49 @@
+-- the users, sessions and tokens for the authentication and the administration.
+
+-- the hashes of argon2id are longer than those of bcrypt.
+ALTER TABLE users
+  DROP COLUMN session_id,
+  MODIFY COLUMN password VARCHAR(255) NOT NULL,
+  ADD COLUMN role VARCHAR(16) NOT NULL DEFAULT 'user' AFTER password,
+  ADD COLUMN banned TINYINT(1) NOT NULL DEFAULT 0 AFTER role;
+
+-- the sessions which have been created before do not expire, so that they are removed and the users log in again.
+DELETE FROM sessions;
+
+ALTER TABLE sessions
+  ADD COLUMN user_agent VARCHAR(255) NOT NULL DEFAULT '' AFTER user_id,
+  ADD COLUMN ip VARCHAR(45) NOT NULL DEFAULT '' AFTER user_agent,
+  ADD COLUMN expires_at DATETIME NOT NULL,
+  ADD COLUMN last_accessed_at DATETIME NOT NULL,
+  ADD KEY (user_id, last_accessed_at),
+  ADD KEY (expires_at),
+  ADD KEY (last_accessed_at);
+
+CREATE TABLE password_reset_tokens (
+  token_hash CHAR(64) NOT NULL,
+  user_id INT UNSIGNED NOT NULL,
+  used TINYINT(1) NOT NULL DEFAULT 0,
+  created_at DATETIME NOT NULL,
+  expires_at DATETIME NOT NULL,
+  PRIMARY KEY (token_hash),
+  KEY (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE api_tokens (
+  id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+  user_id INT UNSIGNED NOT NULL,
+  name VARCHAR(64) NOT NULL,
+  token_hash CHAR(64) NOT NULL,
+  created_at DATETIME NOT NULL,
+  last_used_at DATETIME NOT NULL,
+  PRIMARY KEY (id),
+  UNIQUE KEY (token_hash),
+  KEY (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE login_attempts (
+  attempt_key CHAR(64) NOT NULL,
+  failures INT UNSIGNED NOT NULL,
+  last_failed_at DATETIME NOT NULL,
+  PRIMARY KEY (attempt_key)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
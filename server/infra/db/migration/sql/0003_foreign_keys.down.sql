@@ -0,0 +1,9 @@
+ALTER TABLE api_tokens DROP FOREIGN KEY fk_api_tokens_user_id;
+ALTER TABLE password_reset_tokens DROP FOREIGN KEY fk_password_reset_tokens_user_id;
+ALTER TABLE sessions DROP FOREIGN KEY fk_sessions_user_id;
+ALTER TABLE threads DROP FOREIGN KEY fk_threads_user_id;
+ALTER TABLE comments DROP FOREIGN KEY fk_comments_user_id, DROP FOREIGN KEY fk_comments_thread_id;
+
+DROP INDEX threads_user_id ON threads;
+DROP INDEX comments_user_id ON comments;
+DROP INDEX comments_thread_id_id ON comments;
@@ -0,0 +1,31 @@
+-- the records which refer to the deleted ones are removed first, otherwise the foreign keys can not be added.
+
+DELETE c FROM comments c LEFT JOIN threads t ON t.id = c.thread_id WHERE t.id IS NULL;
+DELETE c FROM comments c LEFT JOIN users u ON u.id = c.user_id WHERE u.id IS NULL;
+DELETE t FROM threads t LEFT JOIN users u ON u.id = t.user_id WHERE u.id IS NULL;
+DELETE s FROM sessions s LEFT JOIN users u ON u.id = s.user_id WHERE u.id IS NULL;
+DELETE p FROM password_reset_tokens p LEFT JOIN users u ON u.id = p.user_id WHERE u.id IS NULL;
+DELETE a FROM api_tokens a LEFT JOIN users u ON u.id = a.user_id WHERE u.id IS NULL;
+
+-- comments are listed by thread_id in the order of id.
+CREATE INDEX comments_thread_id_id ON comments (thread_id, id);
+CREATE INDEX comments_user_id ON comments (user_id);
+CREATE INDEX threads_user_id ON threads (user_id);
+
+-- the comments are deleted with their thread by the application in the same tx,
+-- and CASCADE guarantees that none of them is left even if a thread is deleted directly.
+ALTER TABLE comments
+  ADD CONSTRAINT fk_comments_thread_id FOREIGN KEY (thread_id) REFERENCES threads (id) ON DELETE CASCADE,
+  ADD CONSTRAINT fk_comments_user_id FOREIGN KEY (user_id) REFERENCES users (id);
+
+ALTER TABLE threads
+  ADD CONSTRAINT fk_threads_user_id FOREIGN KEY (user_id) REFERENCES users (id);
+
+ALTER TABLE sessions
+  ADD CONSTRAINT fk_sessions_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE;
+
+ALTER TABLE password_reset_tokens
+  ADD CONSTRAINT fk_password_reset_tokens_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE;
+
+ALTER TABLE api_tokens
+  ADD CONSTRAINT fk_api_tokens_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE;
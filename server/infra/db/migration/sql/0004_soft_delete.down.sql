@@ -0,0 +1,16 @@
+-- the soft-deleted records are deleted, otherwise they would appear again.
+
+DELETE FROM comments WHERE deleted_at IS NOT NULL;
+DELETE FROM threads WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE comments
+  DROP FOREIGN KEY fk_comments_deleted_by,
+  DROP INDEX comments_deleted_at,
+  DROP COLUMN deleted_by,
+  DROP COLUMN deleted_at;
+
+ALTER TABLE threads
+  DROP FOREIGN KEY fk_threads_deleted_by,
+  DROP INDEX threads_deleted_at,
+  DROP COLUMN deleted_by,
+  DROP COLUMN deleted_at;
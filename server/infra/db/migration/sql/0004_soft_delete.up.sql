@@ -0,0 +1,13 @@
+-- the soft-deleted threads and comments are hidden until they are restored or purged.
+
+ALTER TABLE threads
+  ADD COLUMN deleted_at DATETIME DEFAULT NULL,
+  ADD COLUMN deleted_by INT UNSIGNED DEFAULT NULL,
+  ADD INDEX threads_deleted_at (deleted_at),
+  ADD CONSTRAINT fk_threads_deleted_by FOREIGN KEY (deleted_by) REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE comments
+  ADD COLUMN deleted_at DATETIME DEFAULT NULL,
+  ADD COLUMN deleted_by INT UNSIGNED DEFAULT NULL,
+  ADD INDEX comments_deleted_at (deleted_at),
+  ADD CONSTRAINT fk_comments_deleted_by FOREIGN KEY (deleted_by) REFERENCES users (id) ON DELETE SET NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE comments DROP COLUMN edit_count;
+
+DROP TABLE IF EXISTS comment_revisions;
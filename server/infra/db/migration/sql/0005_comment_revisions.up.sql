@@ -0,0 +1,16 @@
+-- the contents of comments before they were edited.
+
+CREATE TABLE comment_revisions (
+  id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+  comment_id INT UNSIGNED NOT NULL,
+  content VARCHAR(200) NOT NULL,
+  edited_by INT UNSIGNED NOT NULL,
+  created_at DATETIME NOT NULL,
+  edited_at DATETIME NOT NULL,
+  PRIMARY KEY (id),
+  KEY comment_revisions_comment_id_id (comment_id, id),
+  CONSTRAINT fk_comment_revisions_comment_id FOREIGN KEY (comment_id) REFERENCES comments (id) ON DELETE CASCADE,
+  CONSTRAINT fk_comment_revisions_edited_by FOREIGN KEY (edited_by) REFERENCES users (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+ALTER TABLE comments ADD COLUMN edit_count INT UNSIGNED NOT NULL DEFAULT 0;
@@ -0,0 +1,151 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+)
+
+// passwordResetTokenRepository is repository of password reset token.
+type passwordResetTokenRepository struct {
+}
+
+// NewPasswordResetTokenRepository generates and returns PasswordResetTokenRepository.
+func NewPasswordResetTokenRepository() repository.PasswordResetTokenRepository {
+	return &passwordResetTokenRepository{}
+}
+
+// ErrorMsg generates and returns error message.
+func (repo *passwordResetTokenRepository) ErrorMsg(method model.RepositoryMethod, err error) error {
+	return &model.RepositoryError{
+		BaseErr:          err,
+		RepositoryMethod: method,
+		DomainModelName:  model.DomainModelNamePasswordResetToken,
+	}
+}
+
+// GetPasswordResetTokenByHash gets and returns a record specified by the hash of the token.
+func (repo *passwordResetTokenRepository) GetPasswordResetTokenByHash(ctx context.Context, m query.SQLManager, tokenHash string) (*model.PasswordResetToken, error) {
+	q := "SELECT token_hash, user_id, used, created_at, expires_at FROM password_reset_tokens WHERE token_hash=?"
+
+	stmt, err := m.PrepareContext(ctx, q)
+	if err != nil {
+		err = errors.Wrap(err, "failed to prepare context")
+		return nil, repo.ErrorMsg(model.RepositoryMethodREAD, err)
+	}
+	defer func() {
+		err = stmt.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	rows, err := stmt.QueryContext(ctx, tokenHash)
+	if err != nil {
+		err = errors.Wrap(err, "failed to execute context")
+		return nil, repo.ErrorMsg(model.RepositoryMethodREAD, err)
+	}
+	defer func() {
+		err = rows.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("rows.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	if !rows.Next() {
+		err = &model.NoSuchDataError{
+			PropertyName:    model.TokenProperty,
+			DomainModelName: model.DomainModelNamePasswordResetToken,
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	token := &model.PasswordResetToken{}
+	if err := rows.Scan(&token.TokenHash, &token.UserID, &token.Used, &token.CreatedAt, &token.ExpiresAt); err != nil {
+		err = errors.Wrap(err, "failed to scan rows")
+		return nil, repo.ErrorMsg(model.RepositoryMethodREAD, err)
+	}
+
+	return token, nil
+}
+
+// InsertPasswordResetToken insert a record.
+func (repo *passwordResetTokenRepository) InsertPasswordResetToken(ctx context.Context, m query.SQLManager, token *model.PasswordResetToken) error {
+	q := "INSERT INTO password_reset_tokens (token_hash, user_id, used, created_at, expires_at) VALUES (?, ?, ?, ?, ?)"
+
+	stmt, err := m.PrepareContext(ctx, q)
+	if err != nil {
+		err = errors.Wrap(err, "failed to prepare context")
+		return repo.ErrorMsg(model.RepositoryMethodInsert, err)
+	}
+	defer func() {
+		err = stmt.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	result, err := stmt.ExecContext(ctx, token.TokenHash, token.UserID, token.Used, token.CreatedAt, token.ExpiresAt)
+	if err != nil {
+		err = errors.Wrap(err, "failed to execute context")
+		return repo.ErrorMsg(model.RepositoryMethodInsert, err)
+	}
+
+	affect, err := result.RowsAffected()
+	if err != nil {
+		err = errors.Wrap(err, "failed to get rows affected")
+		return repo.ErrorMsg(model.RepositoryMethodInsert, err)
+	}
+	if affect != 1 {
+		err = errors.Errorf("total affected: %d ", affect)
+		return repo.ErrorMsg(model.RepositoryMethodInsert, err)
+	}
+
+	return nil
+}
+
+// UsePasswordResetToken marks a record as used.
+// The record which has been already used or has expired is not updated, and NoSuchDataError is returned,
+// so that the token can not be redeemed twice even if requests race.
+func (repo *passwordResetTokenRepository) UsePasswordResetToken(ctx context.Context, m query.SQLManager, tokenHash string, now time.Time) error {
+	q := "UPDATE password_reset_tokens SET used=1 WHERE token_hash=? AND used=0 AND expires_at > ?"
+
+	stmt, err := m.PrepareContext(ctx, q)
+	if err != nil {
+		err = errors.Wrap(err, "failed to prepare context")
+		return repo.ErrorMsg(model.RepositoryMethodUPDATE, err)
+	}
+	defer func() {
+		err = stmt.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	result, err := stmt.ExecContext(ctx, tokenHash, now)
+	if err != nil {
+		err = errors.Wrap(err, "failed to execute context")
+		return repo.ErrorMsg(model.RepositoryMethodUPDATE, err)
+	}
+
+	affect, err := result.RowsAffected()
+	if err != nil {
+		err = errors.Wrap(err, "failed to get rows affected")
+		return repo.ErrorMsg(model.RepositoryMethodUPDATE, err)
+	}
+	if affect != 1 {
+		err = &model.NoSuchDataError{
+			PropertyName:    model.TokenProperty,
+			DomainModelName: model.DomainModelNamePasswordResetToken,
+		}
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/testutil"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func Test_passwordResetTokenRepository_GetPasswordResetTokenByHash(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	testutil.SetFakeTime(time.Now())
+
+	want := &model.PasswordResetToken{
+		TokenHash: "hash",
+		UserID:    model.UserValidIDForTest,
+		CreatedAt: testutil.TimeNow(),
+		ExpiresAt: testutil.TimeNow().Add(model.DefaultPasswordResetTokenTTL),
+	}
+
+	q := "SELECT token_hash, user_id, used, created_at, expires_at FROM password_reset_tokens WHERE token_hash=\\?"
+
+	rows := sqlmock.NewRows([]string{"token_hash", "user_id", "used", "created_at", "expires_at"}).
+		AddRow(want.TokenHash, want.UserID, want.Used, want.CreatedAt, want.ExpiresAt)
+	mock.ExpectPrepare(q).ExpectQuery().WithArgs(want.TokenHash).WillReturnRows(rows)
+
+	repo := &passwordResetTokenRepository{}
+	got, err := repo.GetPasswordResetTokenByHash(context.Background(), db, want.TokenHash)
+	if err != nil {
+		t.Fatalf("passwordResetTokenRepository.GetPasswordResetTokenByHash() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("passwordResetTokenRepository.GetPasswordResetTokenByHash() = %v, want %v", got, want)
+	}
+
+	mock.ExpectPrepare(q).ExpectQuery().WithArgs("unknown").WillReturnRows(sqlmock.NewRows([]string{"token_hash", "user_id", "used", "created_at", "expires_at"}))
+	_, err = repo.GetPasswordResetTokenByHash(context.Background(), db, "unknown")
+	if _, ok := errors.Cause(err).(*model.NoSuchDataError); !ok {
+		t.Errorf("passwordResetTokenRepository.GetPasswordResetTokenByHash() error = %#v, want NoSuchDataError", err)
+	}
+}
+
+func Test_passwordResetTokenRepository_UsePasswordResetToken(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	testutil.SetFakeTime(time.Now())
+
+	tests := []struct {
+		name        string
+		rowAffected int64
+		err         error
+		wantErr     error
+	}{
+		{
+			name:        "When the token is redeemable, returns nil",
+			rowAffected: 1,
+			wantErr:     nil,
+		},
+		{
+			name:        "When the token has been used or has expired, returns NoSuchDataError",
+			rowAffected: 0,
+			wantErr:     &model.NoSuchDataError{},
+		},
+		{
+			name:    "when DB error has occurred、returns RepositoryError",
+			err:     errors.New(model.ErrorMessageForTest),
+			wantErr: &model.RepositoryError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := "UPDATE password_reset_tokens SET used=1 WHERE token_hash=\\? AND used=0 AND expires_at > \\?"
+			prep := mock.ExpectPrepare(q)
+
+			if tt.err != nil {
+				prep.ExpectExec().WithArgs("hash", testutil.TimeNow()).WillReturnError(tt.err)
+			} else {
+				prep.ExpectExec().WithArgs("hash", testutil.TimeNow()).WillReturnResult(sqlmock.NewResult(0, tt.rowAffected))
+			}
+
+			repo := &passwordResetTokenRepository{}
+			err := repo.UsePasswordResetToken(context.Background(), db, "hash", testutil.TimeNow())
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("passwordResetTokenRepository.UsePasswordResetToken() error = %v", err)
+				}
+				return
+			}
+
+			if reflect.TypeOf(errors.Cause(err)) != reflect.TypeOf(tt.wantErr) {
+				t.Errorf("passwordResetTokenRepository.UsePasswordResetToken() error = %#v, wantErr %#v", errors.Cause(err), tt.wantErr)
+			}
+		})
+	}
+}
@@ -9,6 +9,9 @@ import (
 type DBManager interface {
 	SQLManager
 	Beginner
+	PingContext(ctx context.Context) error
+	Stats() sql.DBStats
+	Close() error
 }
 
 // TxManager is the manager of Tx.
@@ -160,6 +160,48 @@ func (mr *MockDBManagerMockRecorder) Begin() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockDBManager)(nil).Begin))
 }
 
+// PingContext mocks base method
+func (m *MockDBManager) PingContext(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PingContext", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PingContext indicates an expected call of PingContext
+func (mr *MockDBManagerMockRecorder) PingContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingContext", reflect.TypeOf((*MockDBManager)(nil).PingContext), ctx)
+}
+
+// Stats mocks base method
+func (m *MockDBManager) Stats() sql.DBStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(sql.DBStats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats
+func (mr *MockDBManagerMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockDBManager)(nil).Stats))
+}
+
+// Close mocks base method
+func (m *MockDBManager) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close
+func (mr *MockDBManagerMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDBManager)(nil).Close))
+}
+
 // MockTxManager is a mock of TxManager interface
 type MockTxManager struct {
 	ctrl     *gomock.Controller
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
@@ -33,7 +34,7 @@ func (repo *sessionRepository) ErrorMsg(method model.RepositoryMethod, err error
 
 // GetSessionByID gets and returns a record specified by id.
 func (repo *sessionRepository) GetSessionByID(ctx context.Context, m query.SQLManager, id string) (*model.Session, error) {
-	q := "SELECT id, user_id, created_at FROM sessions WHERE id=?"
+	q := "SELECT id, user_id, user_agent, ip, created_at, expires_at, last_accessed_at FROM sessions WHERE id=?"
 
 	list, err := repo.list(ctx, m, model.RepositoryMethodREAD, q, id)
 
@@ -55,6 +56,19 @@ func (repo *sessionRepository) GetSessionByID(ctx context.Context, m query.SQLMa
 	return list[0], nil
 }
 
+// ListSessionsByUserID lists records of the user, ordered by the last access.
+func (repo *sessionRepository) ListSessionsByUserID(ctx context.Context, m query.SQLManager, userID uint32) ([]*model.Session, error) {
+	q := "SELECT id, user_id, user_agent, ip, created_at, expires_at, last_accessed_at FROM sessions WHERE user_id=? ORDER BY last_accessed_at DESC"
+
+	list, err := repo.list(ctx, m, model.RepositoryMethodLIST, q, userID)
+	if err != nil {
+		err = errors.Wrap(err, "failed to list session")
+		return nil, repo.ErrorMsg(model.RepositoryMethodLIST, err)
+	}
+
+	return list, nil
+}
+
 // list gets and returns list of records.
 func (repo *sessionRepository) list(ctx context.Context, m query.SQLManager, method model.RepositoryMethod, q string, args ...interface{}) (sessions []*model.Session, err error) {
 	stmt, err := m.PrepareContext(ctx, q)
@@ -65,7 +79,7 @@ func (repo *sessionRepository) list(ctx context.Context, m query.SQLManager, met
 	defer func() {
 		err = stmt.Close()
 		if err != nil {
-			logger.Logger.Error("stmt.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
@@ -77,7 +91,7 @@ func (repo *sessionRepository) list(ctx context.Context, m query.SQLManager, met
 	defer func() {
 		err = rows.Close()
 		if err != nil {
-			logger.Logger.Error("rows.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("rows.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
@@ -88,7 +102,11 @@ func (repo *sessionRepository) list(ctx context.Context, m query.SQLManager, met
 		err = rows.Scan(
 			&session.ID,
 			&session.UserID,
+			&session.UserAgent,
+			&session.IP,
 			&session.CreatedAt,
+			&session.ExpiresAt,
+			&session.LastAccessedAt,
 		)
 
 		if err != nil {
@@ -104,7 +122,7 @@ func (repo *sessionRepository) list(ctx context.Context, m query.SQLManager, met
 
 // InsertSession insert a record.
 func (repo *sessionRepository) InsertSession(ctx context.Context, m query.SQLManager, session *model.Session) error {
-	q := "INSERT INTO sessions (id, user_id, created_at) VALUES (?, ?, NOW())"
+	q := "INSERT INTO sessions (id, user_id, user_agent, ip, created_at, expires_at, last_accessed_at) VALUES (?, ?, ?, ?, ?, ?, ?)"
 	stmt, err := m.PrepareContext(ctx, q)
 	if err != nil {
 		err = errors.Wrap(err, "failed to prepare context")
@@ -113,11 +131,11 @@ func (repo *sessionRepository) InsertSession(ctx context.Context, m query.SQLMan
 	defer func() {
 		err = stmt.Close()
 		if err != nil {
-			logger.Logger.Error("stmt.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
-	result, err := stmt.ExecContext(ctx, session.ID, session.UserID)
+	result, err := stmt.ExecContext(ctx, session.ID, session.UserID, session.UserAgent, session.IP, session.CreatedAt, session.ExpiresAt, session.LastAccessedAt)
 	if err != nil {
 		err = errors.Wrap(err, "failed to execute context")
 		return repo.ErrorMsg(model.RepositoryMethodInsert, err)
@@ -144,7 +162,7 @@ func (repo *sessionRepository) DeleteSession(ctx context.Context, m query.SQLMan
 	defer func() {
 		err = stmt.Close()
 		if err != nil {
-			logger.Logger.Error("stmt.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
@@ -166,3 +184,99 @@ func (repo *sessionRepository) DeleteSession(ctx context.Context, m query.SQLMan
 
 	return nil
 }
+
+// DeleteSessionsByUserID deletes all records of the user, and returns the number of deleted records.
+func (repo *sessionRepository) DeleteSessionsByUserID(ctx context.Context, m query.SQLManager, userID uint32) (int64, error) {
+	q := "DELETE FROM sessions WHERE user_id=?"
+	return repo.deleteMany(ctx, m, q, userID)
+}
+
+// DeleteOtherSessions deletes records of the user except for keepID, and returns the number of deleted records.
+func (repo *sessionRepository) DeleteOtherSessions(ctx context.Context, m query.SQLManager, userID uint32, keepID string) (int64, error) {
+	q := "DELETE FROM sessions WHERE user_id=? AND id<>?"
+	return repo.deleteMany(ctx, m, q, userID, keepID)
+}
+
+// deleteMany deletes records, and returns the number of deleted records.
+func (repo *sessionRepository) deleteMany(ctx context.Context, m query.SQLManager, q string, args ...interface{}) (int64, error) {
+	stmt, err := m.PrepareContext(ctx, q)
+	if err != nil {
+		err = errors.Wrap(err, "failed to prepare context")
+		return 0, repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+	}
+	defer func() {
+		err = stmt.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	result, err := stmt.ExecContext(ctx, args...)
+	if err != nil {
+		err = errors.Wrap(err, "failed to execute context")
+		return 0, repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+	}
+
+	affect, err := result.RowsAffected()
+	if err != nil {
+		err = errors.Wrap(err, "failed to get rows affected")
+		return 0, repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+	}
+
+	return affect, nil
+}
+
+// RenewSession updates the last access of a record.
+func (repo *sessionRepository) RenewSession(ctx context.Context, m query.SQLManager, id string, lastAccessedAt time.Time) error {
+	q := "UPDATE sessions SET last_accessed_at=? WHERE id=?"
+
+	stmt, err := m.PrepareContext(ctx, q)
+	if err != nil {
+		err = errors.Wrap(err, "failed to prepare context")
+		return repo.ErrorMsg(model.RepositoryMethodUPDATE, err)
+	}
+	defer func() {
+		err = stmt.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	if _, err := stmt.ExecContext(ctx, lastAccessedAt, id); err != nil {
+		err = errors.Wrap(err, "failed to execute context")
+		return repo.ErrorMsg(model.RepositoryMethodUPDATE, err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredSessions deletes expired records up to limit, and returns the number of deleted records.
+func (repo *sessionRepository) DeleteExpiredSessions(ctx context.Context, m query.SQLManager, now time.Time, expiry model.SessionExpiry, limit int) (int64, error) {
+	q := "DELETE FROM sessions WHERE expires_at <= ? OR last_accessed_at <= ? LIMIT ?"
+
+	stmt, err := m.PrepareContext(ctx, q)
+	if err != nil {
+		err = errors.Wrap(err, "failed to prepare context")
+		return 0, repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+	}
+	defer func() {
+		err = stmt.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	result, err := stmt.ExecContext(ctx, now, now.Add(-expiry.IdleTimeout), limit)
+	if err != nil {
+		err = errors.Wrap(err, "failed to execute context")
+		return 0, repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+	}
+
+	affect, err := result.RowsAffected()
+	if err != nil {
+		err = errors.Wrap(err, "failed to get rows affected")
+		return 0, repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+	}
+
+	return affect, nil
+}
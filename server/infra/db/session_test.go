@@ -81,9 +81,13 @@ func Test_sessionRepository_GetSessionByID(t *testing.T) {
 				id:  model.SessionValidIDForTest,
 			},
 			want: &model.Session{
-				ID:        model.SessionValidIDForTest,
-				UserID:    model.UserValidIDForTest,
-				CreatedAt: testutil.TimeNow(),
+				ID:             model.SessionValidIDForTest,
+				UserID:         model.UserValidIDForTest,
+				UserAgent:      model.UserAgentForTest,
+				IP:             model.IPForTest,
+				CreatedAt:      testutil.TimeNow(),
+				ExpiresAt:      testutil.TimeNow().Add(model.DefaultSessionAbsoluteTimeout),
+				LastAccessedAt: testutil.TimeNow(),
 			},
 			wantErr: nil,
 		},
@@ -104,14 +108,14 @@ func Test_sessionRepository_GetSessionByID(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			q := "SELECT id, user_id, created_at FROM sessions WHERE id=?"
+			q := "SELECT id, user_id, user_agent, ip, created_at, expires_at, last_accessed_at FROM sessions WHERE id=?"
 			prep := mock.ExpectPrepare(q)
 
 			if tt.wantErr != nil {
 				prep.ExpectQuery().WillReturnError(tt.wantErr)
 			} else {
-				rows := sqlmock.NewRows([]string{"id", "user_id", "created_at"}).
-					AddRow(tt.want.ID, tt.want.UserID, tt.want.CreatedAt)
+				rows := sqlmock.NewRows([]string{"id", "user_id", "user_agent", "ip", "created_at", "expires_at", "last_accessed_at"}).
+					AddRow(tt.want.ID, tt.want.UserID, tt.want.UserAgent, tt.want.IP, tt.want.CreatedAt, tt.want.ExpiresAt, tt.want.LastAccessedAt)
 				prep.ExpectQuery().WithArgs(tt.want.ID).WillReturnRows(rows)
 			}
 
@@ -230,9 +234,9 @@ func Test_sessionRepository_InsertSession(t *testing.T) {
 			prep := mock.ExpectPrepare(query)
 
 			if tt.args.err != nil {
-				prep.ExpectExec().WithArgs(tt.args.session.ID, tt.args.session.UserID, tt.args.session.CreatedAt).WillReturnError(tt.args.err)
+				prep.ExpectExec().WithArgs(tt.args.session.ID, tt.args.session.UserID, tt.args.session.UserAgent, tt.args.session.IP, tt.args.session.CreatedAt, tt.args.session.ExpiresAt, tt.args.session.LastAccessedAt).WillReturnError(tt.args.err)
 			} else {
-				prep.ExpectExec().WithArgs(tt.args.session.ID, tt.args.session.UserID, tt.args.session.CreatedAt).WillReturnResult(sqlmock.NewResult(1, tt.rowAffected))
+				prep.ExpectExec().WithArgs(tt.args.session.ID, tt.args.session.UserID, tt.args.session.UserAgent, tt.args.session.IP, tt.args.session.CreatedAt, tt.args.session.ExpiresAt, tt.args.session.LastAccessedAt).WillReturnResult(sqlmock.NewResult(1, tt.rowAffected))
 			}
 
 			repo := &sessionRepository{}
@@ -343,3 +347,215 @@ func Test_sessionRepository_DeleteSession(t *testing.T) {
 		})
 	}
 }
+
+func Test_sessionRepository_DeleteExpiredSessions(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	testutil.SetFakeTime(time.Now())
+
+	type args struct {
+		ctx    context.Context
+		m      query.SQLManager
+		now    time.Time
+		expiry model.SessionExpiry
+		limit  int
+		err    error
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		rowAffected int64
+		want        int64
+		wantErr     *model.RepositoryError
+	}{
+		{
+			name: "When expired sessions exist, returns the number of deleted sessions",
+			args: args{
+				ctx:    context.Background(),
+				m:      db,
+				now:    testutil.TimeNow(),
+				expiry: model.DefaultSessionExpiry(),
+				limit:  100,
+			},
+			rowAffected: 3,
+			want:        3,
+			wantErr:     nil,
+		},
+		{
+			name: "When no expired session exists, returns 0",
+			args: args{
+				ctx:    context.Background(),
+				m:      db,
+				now:    testutil.TimeNow(),
+				expiry: model.DefaultSessionExpiry(),
+				limit:  100,
+			},
+			rowAffected: 0,
+			want:        0,
+			wantErr:     nil,
+		},
+		{
+			name: "when DB error has occurred、returns error",
+			args: args{
+				ctx:    context.Background(),
+				m:      db,
+				now:    testutil.TimeNow(),
+				expiry: model.DefaultSessionExpiry(),
+				limit:  100,
+				err:    errors.New(model.ErrorMessageForTest),
+			},
+			want: 0,
+			wantErr: &model.RepositoryError{
+				RepositoryMethod: model.RepositoryMethodDELETE,
+				DomainModelName:  model.DomainModelNameSession,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := "DELETE FROM sessions WHERE expires_at <= \\? OR last_accessed_at <= \\? LIMIT \\?"
+			prep := mock.ExpectPrepare(query)
+
+			idleBefore := tt.args.now.Add(-tt.args.expiry.IdleTimeout)
+			if tt.args.err != nil {
+				prep.ExpectExec().WithArgs(tt.args.now, idleBefore, tt.args.limit).WillReturnError(tt.args.err)
+			} else {
+				prep.ExpectExec().WithArgs(tt.args.now, idleBefore, tt.args.limit).WillReturnResult(sqlmock.NewResult(0, tt.rowAffected))
+			}
+
+			repo := &sessionRepository{}
+
+			got, err := repo.DeleteExpiredSessions(tt.args.ctx, tt.args.m, tt.args.now, tt.args.expiry, tt.args.limit)
+			if tt.wantErr != nil {
+				if errors.Cause(err).Error() != tt.wantErr.Error() {
+					t.Errorf("sessionRepository.DeleteExpiredSessions() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("sessionRepository.DeleteExpiredSessions() error = %v", err)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("sessionRepository.DeleteExpiredSessions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sessionRepository_ListSessionsByUserID(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	testutil.SetFakeTime(time.Now())
+
+	want := []*model.Session{
+		{
+			ID:             model.SessionValidIDForTest,
+			UserID:         model.UserValidIDForTest,
+			UserAgent:      model.UserAgentForTest,
+			IP:             model.IPForTest,
+			CreatedAt:      testutil.TimeNow(),
+			ExpiresAt:      testutil.TimeNow().Add(model.DefaultSessionAbsoluteTimeout),
+			LastAccessedAt: testutil.TimeNow(),
+		},
+		{
+			ID:             model.SessionInValidIDForTest,
+			UserID:         model.UserValidIDForTest,
+			CreatedAt:      testutil.TimeNow(),
+			ExpiresAt:      testutil.TimeNow().Add(model.DefaultSessionAbsoluteTimeout),
+			LastAccessedAt: testutil.TimeNow(),
+		},
+	}
+
+	q := "SELECT (.+) FROM sessions WHERE user_id=\\? ORDER BY last_accessed_at DESC"
+	rows := sqlmock.NewRows([]string{"id", "user_id", "user_agent", "ip", "created_at", "expires_at", "last_accessed_at"})
+	for _, s := range want {
+		rows.AddRow(s.ID, s.UserID, s.UserAgent, s.IP, s.CreatedAt, s.ExpiresAt, s.LastAccessedAt)
+	}
+	mock.ExpectPrepare(q).ExpectQuery().WithArgs(model.UserValidIDForTest).WillReturnRows(rows)
+
+	repo := &sessionRepository{}
+	got, err := repo.ListSessionsByUserID(context.Background(), db, model.UserValidIDForTest)
+	if err != nil {
+		t.Fatalf("sessionRepository.ListSessionsByUserID() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sessionRepository.ListSessionsByUserID() = %v, want %v", got, want)
+	}
+}
+
+func Test_sessionRepository_DeleteSessionsByUserID(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	tests := []struct {
+		name        string
+		rowAffected int64
+		err         error
+		want        int64
+		wantErr     *model.RepositoryError
+	}{
+		{
+			name:        "When the user has sessions, returns the number of deleted sessions",
+			rowAffected: 3,
+			want:        3,
+		},
+		{
+			name:        "When the user has no session, returns 0",
+			rowAffected: 0,
+			want:        0,
+		},
+		{
+			name: "when DB error has occurred、returns error",
+			err:  errors.New(model.ErrorMessageForTest),
+			wantErr: &model.RepositoryError{
+				RepositoryMethod: model.RepositoryMethodDELETE,
+				DomainModelName:  model.DomainModelNameSession,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prep := mock.ExpectPrepare("DELETE FROM sessions WHERE user_id=\\?")
+			if tt.err != nil {
+				prep.ExpectExec().WithArgs(model.UserValidIDForTest).WillReturnError(tt.err)
+			} else {
+				prep.ExpectExec().WithArgs(model.UserValidIDForTest).WillReturnResult(sqlmock.NewResult(0, tt.rowAffected))
+			}
+
+			repo := &sessionRepository{}
+			got, err := repo.DeleteSessionsByUserID(context.Background(), db, model.UserValidIDForTest)
+			if tt.wantErr != nil {
+				if errors.Cause(err).Error() != tt.wantErr.Error() {
+					t.Errorf("sessionRepository.DeleteSessionsByUserID() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil || got != tt.want {
+				t.Errorf("sessionRepository.DeleteSessionsByUserID() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
 
@@ -37,6 +38,7 @@ func (repo *threadRepository) ListThreads(ctx context.Context, m query.SQLManage
 	INNER JOIN users AS u
 	ON t.user_id = u.id
 	WHERE t.id >= ?
+	AND t.deleted_at IS NULL
 	ORDER BY t.id ASC
 	LIMIT ?;`
 
@@ -80,8 +82,27 @@ func (repo *threadRepository) GetThreadByID(ctx context.Context, m query.SQLMana
 	INNER JOIN users AS u
 	ON t.user_id = u.id
 	WHERE t.id=?
+	AND t.deleted_at IS NULL
 	LIMIT 1;`
 
+	return repo.getByID(ctx, m, q, id)
+}
+
+// GetDeletedThreadByID gets and returns a soft-deleted record specified by id.
+func (repo *threadRepository) GetDeletedThreadByID(ctx context.Context, m query.SQLManager, id uint32) (*model.Thread, error) {
+	q := `SELECT t.id, t.title, u.id, u.name, t.created_at, t.updated_at
+	FROM threads AS t
+	INNER JOIN users AS u
+	ON t.user_id = u.id
+	WHERE t.id=?
+	AND t.deleted_at IS NOT NULL
+	LIMIT 1;`
+
+	return repo.getByID(ctx, m, q, id)
+}
+
+// getByID gets and returns a record specified by id with q.
+func (repo *threadRepository) getByID(ctx context.Context, m query.SQLManager, q string, id uint32) (*model.Thread, error) {
 	list, err := repo.list(ctx, m, model.RepositoryMethodREAD, q, id)
 
 	if len(list) == 0 {
@@ -103,6 +124,7 @@ func (repo *threadRepository) GetThreadByID(ctx context.Context, m query.SQLMana
 }
 
 // GetThreadByTitle gets and returns a record specified by title.
+// The soft-deleted records are included, because their titles are still unique until they are purged.
 func (repo *threadRepository) GetThreadByTitle(ctx context.Context, m query.SQLManager, name string) (*model.Thread, error) {
 	q := `SELECT t.id, t.title, u.id, u.name, t.created_at, t.updated_at
 	FROM threads AS t
@@ -141,7 +163,7 @@ func (repo *threadRepository) list(ctx context.Context, m query.SQLManager, meth
 	defer func() {
 		err = stmt.Close()
 		if err != nil {
-			logger.Logger.Error("stmt.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
@@ -154,7 +176,7 @@ func (repo *threadRepository) list(ctx context.Context, m query.SQLManager, meth
 	defer func() {
 		err = rows.Close()
 		if err != nil {
-			logger.Logger.Error("rows.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("rows.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
@@ -195,7 +217,7 @@ func (repo *threadRepository) InsertThread(ctx context.Context, m query.SQLManag
 	defer func() {
 		err = stmt.Close()
 		if err != nil {
-			logger.Logger.Error("stmt.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
@@ -232,7 +254,7 @@ func (repo *threadRepository) UpdateThread(ctx context.Context, m query.SQLManag
 	defer func() {
 		err = stmt.Close()
 		if err != nil {
-			logger.Logger.Error("stmt.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
@@ -256,37 +278,70 @@ func (repo *threadRepository) UpdateThread(ctx context.Context, m query.SQLManag
 	return nil
 }
 
-// DeleteThread delete a record.
-func (repo *threadRepository) DeleteThread(ctx context.Context, m query.SQLManager, id uint32) error {
-	q := "DELETE FROM threads WHERE id=?"
+// DeleteThread soft-deletes a record, which is hidden until it is restored or purged.
+func (repo *threadRepository) DeleteThread(ctx context.Context, m query.SQLManager, id, deletedBy uint32, deletedAt time.Time) error {
+	q := "UPDATE threads SET deleted_at=?, deleted_by=? WHERE id=? AND deleted_at IS NULL"
+
+	affect, err := repo.exec(ctx, m, model.RepositoryMethodDELETE, q, deletedAt, deletedBy, id)
+	if err != nil {
+		return err
+	}
+	if affect != 1 {
+		err = errors.Errorf("total affected is: %d", affect)
+		return repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+	}
+
+	return nil
+}
+
+// RestoreThread restores a soft-deleted record.
+func (repo *threadRepository) RestoreThread(ctx context.Context, m query.SQLManager, id uint32) error {
+	q := "UPDATE threads SET deleted_at=NULL, deleted_by=NULL WHERE id=? AND deleted_at IS NOT NULL"
+
+	affect, err := repo.exec(ctx, m, model.RepositoryMethodUPDATE, q, id)
+	if err != nil {
+		return err
+	}
+	if affect != 1 {
+		err = errors.Errorf("total affected is: %d", affect)
+		return repo.ErrorMsg(model.RepositoryMethodUPDATE, err)
+	}
+
+	return nil
+}
+
+// PurgeDeletedThreads hard-deletes records which have been soft-deleted before deletedBefore up to limit,
+// and returns the number of purged records. Their comments are deleted by the foreign key.
+func (repo *threadRepository) PurgeDeletedThreads(ctx context.Context, m query.SQLManager, deletedBefore time.Time, limit int) (int64, error) {
+	q := "DELETE FROM threads WHERE deleted_at <= ? LIMIT ?"
+	return repo.exec(ctx, m, model.RepositoryMethodDELETE, q, deletedBefore, limit)
+}
 
+// exec executes q, and returns the number of affected records.
+func (repo *threadRepository) exec(ctx context.Context, m query.SQLManager, method model.RepositoryMethod, q string, args ...interface{}) (int64, error) {
 	stmt, err := m.PrepareContext(ctx, q)
 	if err != nil {
 		err = errors.Wrap(err, "failed to prepare context")
-		return repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+		return 0, repo.ErrorMsg(method, err)
 	}
 	defer func() {
 		err = stmt.Close()
 		if err != nil {
-			logger.Logger.Error("stmt.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
-	result, err := stmt.ExecContext(ctx, id)
+	result, err := stmt.ExecContext(ctx, args...)
 	if err != nil {
 		err = errors.Wrap(err, "failed to execute context")
-		return repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+		return 0, repo.ErrorMsg(method, err)
 	}
 
 	affect, err := result.RowsAffected()
 	if err != nil {
 		err = errors.Wrap(err, "failed to get rows affected")
-		return repo.ErrorMsg(model.RepositoryMethodDELETE, err)
-	}
-	if affect != 1 {
-		err = errors.Errorf("total affected is: %d", affect)
-		return repo.ErrorMsg(model.RepositoryMethodDELETE, err)
+		return 0, repo.ErrorMsg(method, err)
 	}
 
-	return nil
+	return affect, nil
 }
@@ -9,8 +9,11 @@ import (
 	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
 
 	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/application"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/service"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/eventbus"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/testutil"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
 )
@@ -741,18 +744,18 @@ func Test_threadRepository_DeleteThread(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			query := "DELETE FROM threads WHERE id=\\?"
+			query := "UPDATE threads SET deleted_at=\\?, deleted_by=\\? WHERE id=\\? AND deleted_at IS NULL"
 			prep := mock.ExpectPrepare(query)
 
 			if tt.args.err != nil {
-				prep.ExpectExec().WithArgs(tt.args.id).WillReturnError(tt.args.err)
+				prep.ExpectExec().WithArgs(testutil.TimeNow(), model.UserValidIDForTest, tt.args.id).WillReturnError(tt.args.err)
 			} else {
-				prep.ExpectExec().WithArgs(tt.args.id).WillReturnResult(sqlmock.NewResult(1, tt.rowAffected))
+				prep.ExpectExec().WithArgs(testutil.TimeNow(), model.UserValidIDForTest, tt.args.id).WillReturnResult(sqlmock.NewResult(1, tt.rowAffected))
 			}
 
 			repo := &threadRepository{}
 
-			err := repo.DeleteThread(tt.args.ctx, tt.args.m, tt.args.id)
+			err := repo.DeleteThread(tt.args.ctx, tt.args.m, tt.args.id, model.UserValidIDForTest, testutil.TimeNow())
 			if tt.wantErr != nil {
 				if errors.Cause(err).Error() != tt.wantErr.Error() {
 					t.Errorf("threadRepository.DeleteThread() error = %v, wantErr %v", err, tt.wantErr)
@@ -762,3 +765,224 @@ func Test_threadRepository_DeleteThread(t *testing.T) {
 		})
 	}
 }
+
+func Test_threadRepository_DeleteThread_withComments(t *testing.T) {
+	// set sqlmock, which expects the statements in the order of them.
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	testutil.SetFakeTime(time.Now())
+
+	user := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+		Role: model.RoleUser,
+	}
+
+	// the comments must be deleted before the thread in the same tx.
+	mock.ExpectBegin()
+	mock.ExpectPrepare("WHERE t.id=\\?\\s+AND t.deleted_at IS NULL").ExpectQuery().WithArgs(model.ThreadValidIDForTest).
+		WillReturnRows(sqlmock.NewRows([]string{"t.id", "t.title", "u.id", "u.name", "t.created_at", "t.updated_at"}).
+			AddRow(model.ThreadValidIDForTest, model.TitleForTest, user.ID, user.Name, testutil.TimeNow(), testutil.TimeNow()))
+	mock.ExpectPrepare("UPDATE comments SET deleted_at=\\?, deleted_by=\\? WHERE thread_id=\\? AND deleted_at IS NULL").ExpectExec().
+		WithArgs(sqlmock.AnyArg(), user.ID, model.ThreadValidIDForTest).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectPrepare("UPDATE threads SET deleted_at=\\?, deleted_by=\\? WHERE id=\\? AND deleted_at IS NULL").ExpectExec().
+		WithArgs(sqlmock.AnyArg(), user.ID, model.ThreadValidIDForTest).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	tRepo := NewThreadRepository()
+	a := application.NewThreadService(&dbManager{Conn: db}, service.NewThreadService(tRepo), tRepo, NewCommentRepository(), service.NewAuthorizationPolicy(), eventbus.NewMemoryBus(), CloseTransaction)
+
+	if err := a.DeleteThread(model.WithUser(context.Background(), user), model.ThreadValidIDForTest); err != nil {
+		t.Fatalf("threadService.DeleteThread() error = %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("the statements are not executed in the same tx in the order, %v", err)
+	}
+}
+
+func Test_threadRepository_GetDeletedThreadByID(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	testutil.SetFakeTime(time.Now())
+
+	want := &model.Thread{
+		ID:    model.ThreadValidIDForTest,
+		Title: model.TitleForTest,
+		User: &model.User{
+			ID:   model.UserValidIDForTest,
+			Name: model.UserNameForTest,
+		},
+		CreatedAt: testutil.TimeNow(),
+		UpdatedAt: testutil.TimeNow(),
+	}
+
+	tests := []struct {
+		name    string
+		exists  bool
+		want    *model.Thread
+		wantErr bool
+	}{
+		{
+			name:   "When a deleted thread specified by id exists, returns the thread",
+			exists: true,
+			want:   want,
+		},
+		{
+			name:    "When a deleted thread specified by id does not exist, returns NoSuchDataError",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := sqlmock.NewRows([]string{"t.id", "t.title", "u.id", "u.name", "t.created_at", "t.updated_at"})
+			if tt.exists {
+				rows.AddRow(want.ID, want.Title, want.User.ID, want.User.Name, want.CreatedAt, want.UpdatedAt)
+			}
+			mock.ExpectPrepare("WHERE t.id=\\?\\s+AND t.deleted_at IS NOT NULL").
+				ExpectQuery().WithArgs(model.ThreadValidIDForTest).WillReturnRows(rows)
+
+			repo := &threadRepository{}
+			got, err := repo.GetDeletedThreadByID(context.Background(), db, model.ThreadValidIDForTest)
+			if tt.wantErr {
+				if _, ok := errors.Cause(err).(*model.NoSuchDataError); !ok {
+					t.Errorf("threadRepository.GetDeletedThreadByID() error = %v, want NoSuchDataError", err)
+				}
+				return
+			}
+
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("threadRepository.GetDeletedThreadByID() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_threadRepository_RestoreThread(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	tests := []struct {
+		name        string
+		rowAffected int64
+		err         error
+		wantErr     *model.RepositoryError
+	}{
+		{
+			name:        "When a deleted thread specified by id exists, returns nil",
+			rowAffected: 1,
+		},
+		{
+			name:        "when RowAffected is 0、returns error",
+			rowAffected: 0,
+			wantErr: &model.RepositoryError{
+				RepositoryMethod: model.RepositoryMethodUPDATE,
+				DomainModelName:  model.DomainModelNameThread,
+			},
+		},
+		{
+			name: "when DB error has occurred、returns error",
+			err:  errors.New(model.ErrorMessageForTest),
+			wantErr: &model.RepositoryError{
+				RepositoryMethod: model.RepositoryMethodUPDATE,
+				DomainModelName:  model.DomainModelNameThread,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prep := mock.ExpectPrepare("UPDATE threads SET deleted_at=NULL, deleted_by=NULL WHERE id=\\? AND deleted_at IS NOT NULL")
+			if tt.err != nil {
+				prep.ExpectExec().WithArgs(model.ThreadValidIDForTest).WillReturnError(tt.err)
+			} else {
+				prep.ExpectExec().WithArgs(model.ThreadValidIDForTest).WillReturnResult(sqlmock.NewResult(0, tt.rowAffected))
+			}
+
+			repo := &threadRepository{}
+			err := repo.RestoreThread(context.Background(), db, model.ThreadValidIDForTest)
+			if tt.wantErr != nil {
+				if errors.Cause(err).Error() != tt.wantErr.Error() {
+					t.Errorf("threadRepository.RestoreThread() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("threadRepository.RestoreThread() error = %v", err)
+			}
+		})
+	}
+}
+
+func Test_threadRepository_PurgeDeletedThreads(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	testutil.SetFakeTime(time.Now())
+
+	tests := []struct {
+		name        string
+		rowAffected int64
+		err         error
+		want        int64
+		wantErr     *model.RepositoryError
+	}{
+		{
+			name:        "When some threads have been deleted before the time, returns the number of purged threads",
+			rowAffected: 3,
+			want:        3,
+		},
+		{
+			name: "when DB error has occurred、returns error",
+			err:  errors.New(model.ErrorMessageForTest),
+			wantErr: &model.RepositoryError{
+				RepositoryMethod: model.RepositoryMethodDELETE,
+				DomainModelName:  model.DomainModelNameThread,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prep := mock.ExpectPrepare("DELETE FROM threads WHERE deleted_at <= \\? LIMIT \\?")
+			if tt.err != nil {
+				prep.ExpectExec().WithArgs(testutil.TimeNow(), 100).WillReturnError(tt.err)
+			} else {
+				prep.ExpectExec().WithArgs(testutil.TimeNow(), 100).WillReturnResult(sqlmock.NewResult(0, tt.rowAffected))
+			}
+
+			repo := &threadRepository{}
+			got, err := repo.PurgeDeletedThreads(context.Background(), db, testutil.TimeNow(), 100)
+			if tt.wantErr != nil {
+				if errors.Cause(err).Error() != tt.wantErr.Error() {
+					t.Errorf("threadRepository.PurgeDeletedThreads() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil || got != tt.want {
+				t.Errorf("threadRepository.PurgeDeletedThreads() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -31,9 +31,50 @@ func (repo *userRepository) ErrorMsg(method model.RepositoryMethod, err error) e
 	}
 }
 
+// ListUsers lists UserList.
+func (repo *userRepository) ListUsers(ctx context.Context, m query.SQLManager, cursor uint32, limit int) (*model.UserList, error) {
+	q := `SELECT id, name, password, role, banned, created_at, updated_at
+	FROM users
+	WHERE id >= ?
+	ORDER BY id ASC
+	LIMIT ?;`
+
+	limitForCheckHasNext := readyLimitForHasNext(limit)
+	users, err := repo.list(ctx, m, model.RepositoryMethodLIST, q, cursor, limitForCheckHasNext)
+
+	length := len(users)
+
+	if length == 0 {
+		err = &model.NoSuchDataError{
+			BaseErr:         err,
+			DomainModelName: model.DomainModelNameUser,
+		}
+		return nil, err
+	}
+
+	if err != nil {
+		err = errors.Wrap(err, "failed to list users")
+		return nil, repo.ErrorMsg(model.RepositoryMethodLIST, err)
+	}
+
+	hasNext := checkHasNext(length, limit)
+	if hasNext {
+		cursor = users[limitForCheckHasNext-1].ID
+	} else {
+		cursor = 0
+	}
+
+	if length == limitForCheckHasNext {
+		// exclude user for cursor
+		return &model.UserList{Users: users[:limitForCheckHasNext-1], HasNext: hasNext, Cursor: cursor}, nil
+	}
+
+	return &model.UserList{Users: users, HasNext: hasNext, Cursor: cursor}, nil
+}
+
 // GetUserByID gets and returns a record specified by id.
 func (repo *userRepository) GetUserByID(ctx context.Context, m query.SQLManager, id uint32) (*model.User, error) {
-	q := "SELECT id, name, session_id, password, created_at, updated_at FROM users WHERE id=?"
+	q := "SELECT id, name, password, role, banned, created_at, updated_at FROM users WHERE id=?"
 
 	list, err := repo.list(ctx, m, model.RepositoryMethodREAD, q, id)
 
@@ -57,7 +98,7 @@ func (repo *userRepository) GetUserByID(ctx context.Context, m query.SQLManager,
 
 // GetUserByName gets and returns a record specified by name.
 func (repo *userRepository) GetUserByName(ctx context.Context, m query.SQLManager, name string) (*model.User, error) {
-	q := "SELECT id, name, session_id, password, created_at, updated_at FROM users WHERE name=?"
+	q := "SELECT id, name, password, role, banned, created_at, updated_at FROM users WHERE name=?"
 	list, err := repo.list(ctx, m, model.RepositoryMethodREAD, q, name)
 
 	if len(list) == 0 {
@@ -88,7 +129,7 @@ func (repo *userRepository) list(ctx context.Context, m query.SQLManager, method
 	defer func() {
 		err = stmt.Close()
 		if err != nil {
-			logger.Logger.Error("stmt.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
@@ -100,7 +141,7 @@ func (repo *userRepository) list(ctx context.Context, m query.SQLManager, method
 	defer func() {
 		err = rows.Close()
 		if err != nil {
-			logger.Logger.Error("rows.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("rows.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
@@ -111,8 +152,9 @@ func (repo *userRepository) list(ctx context.Context, m query.SQLManager, method
 		err = rows.Scan(
 			&user.ID,
 			&user.Name,
-			&user.SessionID,
 			&user.Password,
+			&user.Role,
+			&user.Banned,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		)
@@ -130,7 +172,7 @@ func (repo *userRepository) list(ctx context.Context, m query.SQLManager, method
 
 // InsertUser insert a record.
 func (repo *userRepository) InsertUser(ctx context.Context, m query.SQLManager, user *model.User) (uint32, error) {
-	q := "INSERT INTO users (name, session_id, password, created_at, updated_at) VALUES (?, ?, ?, NOW(), NOW())"
+	q := "INSERT INTO users (name, password, role, created_at, updated_at) VALUES (?, ?, ?, NOW(), NOW())"
 	stmt, err := m.PrepareContext(ctx, q)
 	if err != nil {
 		err = errors.Wrap(err, "failed to prepare context")
@@ -139,11 +181,11 @@ func (repo *userRepository) InsertUser(ctx context.Context, m query.SQLManager,
 	defer func() {
 		err = stmt.Close()
 		if err != nil {
-			logger.Logger.Error("stmt.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
-	result, err := stmt.ExecContext(ctx, user.Name, user.SessionID, user.Password)
+	result, err := stmt.ExecContext(ctx, user.Name, user.Password, user.Role)
 	if err != nil {
 		err = errors.Wrap(err, "failed to execute context")
 		return model.InvalidID, repo.ErrorMsg(model.RepositoryMethodInsert, err)
@@ -170,8 +212,54 @@ func (repo *userRepository) InsertUser(ctx context.Context, m query.SQLManager,
 
 // UpdateUser updates a record.
 func (repo *userRepository) UpdateUser(ctx context.Context, m query.SQLManager, id uint32, user *model.User) error {
-	q := "UPDATE users SET session_id=?, password=?, updated_at=NOW() WHERE id=?"
+	q := "UPDATE users SET password=?, updated_at=NOW() WHERE id=?"
+
+	stmt, err := m.PrepareContext(ctx, q)
+	if err != nil {
+		err = errors.Wrap(err, "failed to prepare context")
+		return repo.ErrorMsg(model.RepositoryMethodUPDATE, err)
+	}
+
+	defer func() {
+		err = stmt.Close()
+		if err != nil {
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	result, err := stmt.ExecContext(ctx, user.Password, id)
+	if err != nil {
+		err = errors.Wrap(err, "failed to execute context")
+		return repo.ErrorMsg(model.RepositoryMethodUPDATE, err)
+	}
+
+	affect, err := result.RowsAffected()
+	if err != nil {
+		err = errors.Wrap(err, "failed to get rows affected")
+		return repo.ErrorMsg(model.RepositoryMethodUPDATE, err)
+	}
+	if affect != 1 {
+		err = errors.Errorf("total affected: %d ", affect)
+		return repo.ErrorMsg(model.RepositoryMethodUPDATE, err)
+	}
+
+	return nil
+}
+
+// UpdateUserRole updates role of a record.
+func (repo *userRepository) UpdateUserRole(ctx context.Context, m query.SQLManager, id uint32, role model.Role) error {
+	q := "UPDATE users SET role=?, updated_at=NOW() WHERE id=?"
+	return repo.update(ctx, m, q, role, id)
+}
+
+// UpdateUserBanned updates whether a record has been banned or not.
+func (repo *userRepository) UpdateUserBanned(ctx context.Context, m query.SQLManager, id uint32, banned bool) error {
+	q := "UPDATE users SET banned=?, updated_at=NOW() WHERE id=?"
+	return repo.update(ctx, m, q, banned, id)
+}
 
+// update executes the query which updates a record.
+func (repo *userRepository) update(ctx context.Context, m query.SQLManager, q string, args ...interface{}) error {
 	stmt, err := m.PrepareContext(ctx, q)
 	if err != nil {
 		err = errors.Wrap(err, "failed to prepare context")
@@ -181,11 +269,11 @@ func (repo *userRepository) UpdateUser(ctx context.Context, m query.SQLManager,
 	defer func() {
 		err = stmt.Close()
 		if err != nil {
-			logger.Logger.Error("stmt.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
-	result, err := stmt.ExecContext(ctx, user.SessionID, user.Password, id)
+	result, err := stmt.ExecContext(ctx, args...)
 	if err != nil {
 		err = errors.Wrap(err, "failed to execute context")
 		return repo.ErrorMsg(model.RepositoryMethodUPDATE, err)
@@ -216,7 +304,7 @@ func (repo *userRepository) DeleteUser(ctx context.Context, m query.SQLManager,
 	defer func() {
 		err = stmt.Close()
 		if err != nil {
-			logger.Logger.Error("stmt.Close", zap.String("error message", err.Error()))
+			logger.FromContext(ctx).Error("stmt.Close", zap.String("error message", err.Error()))
 		}
 	}()
 
@@ -84,6 +84,113 @@ func Test_userRepository_ErrorMsg(t *testing.T) {
 	}
 }
 
+func Test_userRepository_ListUsers(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer db.Close()
+
+	testutil.SetFakeTime(time.Now())
+
+	type args struct {
+		ctx    context.Context
+		m      query.SQLManager
+		limit  int
+		cursor uint32
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		want       *model.UserList
+		returnMock []*model.User
+		wantErr    error
+	}{
+		{
+			name: "When limit = 20, cursor = 1 are given and there are over 21 data, ListUsers returns UserList which has Users(ID: 1~20), HasNext = yes, Cursor = 21",
+			args: args{
+				ctx:    context.Background(),
+				m:      db,
+				limit:  20,
+				cursor: 1,
+			},
+			want: &model.UserList{
+				Users:   testutil.GenerateUserHelper(1, 20),
+				HasNext: true,
+				Cursor:  21,
+			},
+			returnMock: testutil.GenerateUserHelper(1, 21),
+			wantErr:    nil,
+		},
+		{
+			name: "When limit = 20, cursor = 1 are given and there are 10 data, ListUsers returns UserList which has Users(ID: 1~10), HasNext = no, Cursor = 0",
+			args: args{
+				ctx:    context.Background(),
+				m:      db,
+				limit:  20,
+				cursor: 1,
+			},
+			want: &model.UserList{
+				Users:   testutil.GenerateUserHelper(1, 10),
+				HasNext: false,
+				Cursor:  0,
+			},
+			returnMock: testutil.GenerateUserHelper(1, 10),
+			wantErr:    nil,
+		},
+		{
+			name: "When limit = 20, cursor = 1 are given and there are no data, ListUsers returns error",
+			args: args{
+				ctx:    context.Background(),
+				m:      db,
+				limit:  20,
+				cursor: 1,
+			},
+			want: nil,
+			wantErr: &model.NoSuchDataError{
+				DomainModelName: model.DomainModelNameUser,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := `SELECT (.+)
+	FROM users
+	(.+);`
+			prep := mock.ExpectPrepare(q)
+
+			if tt.wantErr != nil {
+				prep.ExpectQuery().WithArgs(tt.args.cursor, readyLimitForHasNext(tt.args.limit)).WillReturnError(tt.wantErr)
+			} else {
+				rows := sqlmock.NewRows([]string{"id", "name", "password", "role", "banned", "created_at", "updated_at"})
+
+				for _, user := range tt.returnMock {
+					rows.AddRow(user.ID, user.Name, user.Password, user.Role, user.Banned, user.CreatedAt, user.UpdatedAt)
+				}
+
+				prep.ExpectQuery().WithArgs(tt.args.cursor, readyLimitForHasNext(tt.args.limit)).WillReturnRows(rows)
+			}
+
+			repo := &userRepository{}
+			got, err := repo.ListUsers(tt.args.ctx, tt.args.m, tt.args.cursor, tt.args.limit)
+			if tt.wantErr != nil {
+				if err.Error() != tt.wantErr.Error() {
+					t.Errorf("userRepository.ListUsers() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("userRepository.ListUsers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_userRepository_GetUserByID(t *testing.T) {
 	// set sqlmock
 	db, mock, err := sqlmock.New()
@@ -118,8 +225,8 @@ func Test_userRepository_GetUserByID(t *testing.T) {
 			want: &model.User{
 				ID:        model.UserValidIDForTest,
 				Name:      model.UserNameForTest,
-				SessionID: model.SessionValidIDForTest,
 				Password:  model.PasswordForTest,
+				Role:      model.RoleUser,
 				CreatedAt: testutil.TimeNow(),
 				UpdatedAt: testutil.TimeNow(),
 			},
@@ -142,14 +249,14 @@ func Test_userRepository_GetUserByID(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			q := "SELECT id, name, session_id, password, created_at, updated_at FROM users WHERE id=?"
+			q := "SELECT id, name, password, role, banned, created_at, updated_at FROM users WHERE id=?"
 			prep := mock.ExpectPrepare(q)
 
 			if tt.wantErr != nil {
 				prep.ExpectQuery().WillReturnError(tt.wantErr)
 			} else {
-				rows := sqlmock.NewRows([]string{"id", "name", "session_id", "password", "created_at", "updated_at"}).
-					AddRow(tt.want.ID, tt.want.Name, tt.want.SessionID, tt.want.Password, tt.want.CreatedAt, tt.want.UpdatedAt)
+				rows := sqlmock.NewRows([]string{"id", "name", "password", "role", "banned", "created_at", "updated_at"}).
+					AddRow(tt.want.ID, tt.want.Name, tt.want.Password, tt.want.Role, tt.want.Banned, tt.want.CreatedAt, tt.want.UpdatedAt)
 				prep.ExpectQuery().WithArgs(tt.want.ID).WillReturnRows(rows)
 			}
 
@@ -201,8 +308,8 @@ func Test_userRepository_GetUserByName(t *testing.T) {
 			},
 			want: &model.User{
 				Name:      model.UserNameForTest,
-				SessionID: model.SessionValidIDForTest,
 				Password:  model.PasswordForTest,
+				Role:      model.RoleUser,
 				CreatedAt: testutil.TimeNow(),
 				UpdatedAt: testutil.TimeNow(),
 			},
@@ -226,14 +333,14 @@ func Test_userRepository_GetUserByName(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			q := "SELECT id, name, session_id, password, created_at, updated_at FROM users WHERE name=?"
+			q := "SELECT id, name, password, role, banned, created_at, updated_at FROM users WHERE name=?"
 			prep := mock.ExpectPrepare(q)
 
 			if tt.wantErr != nil {
 				prep.ExpectQuery().WillReturnError(tt.wantErr)
 			} else {
-				rows := sqlmock.NewRows([]string{"id", "name", "session_id", "password", "created_at", "updated_at"}).
-					AddRow(tt.want.ID, tt.want.Name, tt.want.SessionID, tt.want.Password, tt.want.CreatedAt, tt.want.UpdatedAt)
+				rows := sqlmock.NewRows([]string{"id", "name", "password", "role", "banned", "created_at", "updated_at"}).
+					AddRow(tt.want.ID, tt.want.Name, tt.want.Password, tt.want.Role, tt.want.Banned, tt.want.CreatedAt, tt.want.UpdatedAt)
 				prep.ExpectQuery().WithArgs(tt.want.Name).WillReturnRows(rows)
 			}
 
@@ -361,9 +468,9 @@ func Test_userRepository_InsertUser(t *testing.T) {
 			prep := mock.ExpectPrepare(query)
 
 			if tt.args.err != nil {
-				prep.ExpectExec().WithArgs(tt.args.user.ID, tt.args.user.Name, tt.args.user.SessionID, tt.args.user.Password, tt.args.user.CreatedAt, tt.args.user.UpdatedAt).WillReturnError(tt.args.err)
+				prep.ExpectExec().WithArgs(tt.args.user.Name, tt.args.user.Password, tt.args.user.Role).WillReturnError(tt.args.err)
 			} else {
-				prep.ExpectExec().WithArgs(tt.args.user.ID, tt.args.user.Name, tt.args.user.SessionID, tt.args.user.Password, tt.args.user.CreatedAt, tt.args.user.UpdatedAt).WillReturnResult(sqlmock.NewResult(1, tt.rowAffected))
+				prep.ExpectExec().WithArgs(tt.args.user.Name, tt.args.user.Password, tt.args.user.Role).WillReturnResult(sqlmock.NewResult(1, tt.rowAffected))
 			}
 
 			repo := &userRepository{}
@@ -486,13 +593,13 @@ func Test_userRepository_UpdateUser(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			query := "UPDATE users SET session_id=\\?, password=\\?, created_at=\\?, updated_at=\\? WHERE id=\\?"
+			query := "UPDATE users SET password=\\?, updated_at=NOW\\(\\) WHERE id=\\?"
 			prep := mock.ExpectPrepare(query)
 
 			if tt.args.err != nil {
-				prep.ExpectExec().WithArgs(tt.args.user.SessionID, tt.args.user.Password, tt.args.user.CreatedAt, tt.args.user.UpdatedAt, tt.args.id).WillReturnError(tt.args.err)
+				prep.ExpectExec().WithArgs(tt.args.user.Password, tt.args.id).WillReturnError(tt.args.err)
 			} else {
-				prep.ExpectExec().WithArgs(tt.args.user.SessionID, tt.args.user.Password, tt.args.user.CreatedAt, tt.args.user.UpdatedAt, tt.args.id).WillReturnResult(sqlmock.NewResult(1, tt.rowAffected))
+				prep.ExpectExec().WithArgs(tt.args.user.Password, tt.args.id).WillReturnResult(sqlmock.NewResult(1, tt.rowAffected))
 			}
 
 			repo := &userRepository{}
@@ -507,6 +614,185 @@ func Test_userRepository_UpdateUser(t *testing.T) {
 	}
 }
 
+func Test_userRepository_UpdateUserRole(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	type args struct {
+		ctx  context.Context
+		m    query.SQLManager
+		id   uint32
+		role model.Role
+		err  error
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		rowAffected int64
+		wantErr     *model.RepositoryError
+	}{
+		{
+			name: "When a role is given, returns nil",
+			args: args{
+				ctx:  context.Background(),
+				m:    db,
+				id:   model.UserValidIDForTest,
+				role: model.RoleModerator,
+			},
+			rowAffected: 1,
+			wantErr:     nil,
+		},
+		{
+			name: "when RowAffected is 0、returns error",
+			args: args{
+				ctx:  context.Background(),
+				m:    db,
+				id:   model.UserInValidIDForTest,
+				role: model.RoleModerator,
+			},
+			rowAffected: 0,
+			wantErr: &model.RepositoryError{
+				RepositoryMethod: model.RepositoryMethodUPDATE,
+				DomainModelName:  model.DomainModelNameUser,
+			},
+		},
+		{
+			name: "when DB error has occurred、returns error",
+			args: args{
+				ctx:  context.Background(),
+				m:    db,
+				id:   model.UserValidIDForTest,
+				role: model.RoleModerator,
+				err:  errors.New(model.ErrorMessageForTest),
+			},
+			rowAffected: 0,
+			wantErr: &model.RepositoryError{
+				RepositoryMethod: model.RepositoryMethodUPDATE,
+				DomainModelName:  model.DomainModelNameUser,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := "UPDATE users SET role=\\?, updated_at=NOW\\(\\) WHERE id=\\?"
+			prep := mock.ExpectPrepare(query)
+
+			if tt.args.err != nil {
+				prep.ExpectExec().WithArgs(tt.args.role, tt.args.id).WillReturnError(tt.args.err)
+			} else {
+				prep.ExpectExec().WithArgs(tt.args.role, tt.args.id).WillReturnResult(sqlmock.NewResult(1, tt.rowAffected))
+			}
+
+			repo := &userRepository{}
+			err := repo.UpdateUserRole(tt.args.ctx, tt.args.m, tt.args.id, tt.args.role)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("userRepository.UpdateUserRole() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if errors.Cause(err).Error() != tt.wantErr.Error() {
+				t.Errorf("userRepository.UpdateUserRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_userRepository_UpdateUserBanned(t *testing.T) {
+	// set sqlmock
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	type args struct {
+		ctx    context.Context
+		m      query.SQLManager
+		id     uint32
+		banned bool
+		err    error
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		rowAffected int64
+		wantErr     *model.RepositoryError
+	}{
+		{
+			name: "When banned is given, returns nil",
+			args: args{
+				ctx:    context.Background(),
+				m:      db,
+				id:     model.UserValidIDForTest,
+				banned: true,
+			},
+			rowAffected: 1,
+			wantErr:     nil,
+		},
+		{
+			name: "When unbanned is given, returns nil",
+			args: args{
+				ctx:    context.Background(),
+				m:      db,
+				id:     model.UserValidIDForTest,
+				banned: false,
+			},
+			rowAffected: 1,
+			wantErr:     nil,
+		},
+		{
+			name: "when DB error has occurred、returns error",
+			args: args{
+				ctx:    context.Background(),
+				m:      db,
+				id:     model.UserValidIDForTest,
+				banned: true,
+				err:    errors.New(model.ErrorMessageForTest),
+			},
+			rowAffected: 0,
+			wantErr: &model.RepositoryError{
+				RepositoryMethod: model.RepositoryMethodUPDATE,
+				DomainModelName:  model.DomainModelNameUser,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := "UPDATE users SET banned=\\?, updated_at=NOW\\(\\) WHERE id=\\?"
+			prep := mock.ExpectPrepare(query)
+
+			if tt.args.err != nil {
+				prep.ExpectExec().WithArgs(tt.args.banned, tt.args.id).WillReturnError(tt.args.err)
+			} else {
+				prep.ExpectExec().WithArgs(tt.args.banned, tt.args.id).WillReturnResult(sqlmock.NewResult(1, tt.rowAffected))
+			}
+
+			repo := &userRepository{}
+			err := repo.UpdateUserBanned(tt.args.ctx, tt.args.m, tt.args.id, tt.args.banned)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("userRepository.UpdateUserBanned() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if errors.Cause(err).Error() != tt.wantErr.Error() {
+				t.Errorf("userRepository.UpdateUserBanned() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func Test_userRepository_DeleteUser(t *testing.T) {
 	// set sqlmock
 	db, mock, err := sqlmock.New()
@@ -0,0 +1,104 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/event"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+)
+
+// Broker is the interface of the message broker such as Redis Pub/Sub
+// which delivers messages to all server instances.
+type Broker interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+	Subscribe(channel string, handler func(payload []byte)) error
+}
+
+// envelope is the message which is sent via Broker.
+type envelope struct {
+	Name event.Name      `json:"name"`
+	Data json.RawMessage `json:"data"`
+}
+
+// brokerBus is the domain event bus which fans out events via Broker.
+// Events are delivered to local handlers when they come back from Broker,
+// so every instance including the publisher receives them exactly once.
+type brokerBus struct {
+	broker  Broker
+	channel string
+	local   event.Bus
+}
+
+// NewBrokerBus generates and returns Bus which publishes events to the channel of Broker.
+func NewBrokerBus(broker Broker, channel string) (event.Bus, error) {
+	b := &brokerBus{
+		broker:  broker,
+		channel: channel,
+		local:   NewMemoryBus(),
+	}
+
+	if err := broker.Subscribe(channel, b.receive); err != nil {
+		return nil, errors.Wrapf(err, "failed to subscribe channel %s", channel)
+	}
+
+	return b, nil
+}
+
+// Publish encodes events and publishes them to Broker.
+func (b *brokerBus) Publish(ctx context.Context, events ...event.Event) error {
+	for _, e := range events {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal event %s", e.EventName())
+		}
+
+		payload, err := json.Marshal(&envelope{
+			Name: e.EventName(),
+			Data: data,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal envelope of event %s", e.EventName())
+		}
+
+		if err := b.broker.Publish(ctx, b.channel, payload); err != nil {
+			return errors.Wrapf(err, "failed to publish event %s", e.EventName())
+		}
+	}
+	return nil
+}
+
+// Subscribe registers the handler of the event which comes from Broker.
+func (b *brokerBus) Subscribe(name event.Name, handler event.Handler) func() {
+	return b.local.Subscribe(name, handler)
+}
+
+// receive decodes the payload which comes from Broker and delivers it to local handlers.
+func (b *brokerBus) receive(payload []byte) {
+	env := &envelope{}
+	if err := json.Unmarshal(payload, env); err != nil {
+		logger.Logger.Error("failed to unmarshal envelope", zap.String("error message", err.Error()))
+		return
+	}
+
+	e, ok := event.New(env.Name)
+	if !ok {
+		logger.Logger.Warn("unknown event", zap.String("event", string(env.Name)))
+		return
+	}
+
+	if err := json.Unmarshal(env.Data, e); err != nil {
+		logger.Logger.Error("failed to unmarshal event",
+			zap.String("event", string(env.Name)),
+			zap.String("error message", err.Error()))
+		return
+	}
+
+	if err := b.local.Publish(context.Background(), e); err != nil {
+		logger.Logger.Error("failed to deliver event",
+			zap.String("event", string(env.Name)),
+			zap.String("error message", err.Error()))
+	}
+}
@@ -0,0 +1,105 @@
+package eventbus
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/event"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+)
+
+// fakeBroker is Broker which delivers messages to subscribers in the same process.
+type fakeBroker struct {
+	handlers map[string][]func(payload []byte)
+	err      error
+}
+
+func newFakeBroker() *fakeBroker {
+	return &fakeBroker{
+		handlers: make(map[string][]func(payload []byte)),
+	}
+}
+
+func (b *fakeBroker) Publish(ctx context.Context, channel string, payload []byte) error {
+	if b.err != nil {
+		return b.err
+	}
+	for _, h := range b.handlers[channel] {
+		h(payload)
+	}
+	return nil
+}
+
+func (b *fakeBroker) Subscribe(channel string, handler func(payload []byte)) error {
+	b.handlers[channel] = append(b.handlers[channel], handler)
+	return nil
+}
+
+func Test_brokerBus_Publish(t *testing.T) {
+	comment := &model.Comment{
+		ID:       model.CommentValidIDForTest,
+		ThreadID: model.ThreadValidIDForTest,
+		User: &model.User{
+			ID:   model.UserValidIDForTest,
+			Name: model.UserNameForTest,
+		},
+		Content: model.CommentContentForTest,
+	}
+
+	tests := []struct {
+		name      string
+		brokerErr error
+		want      []event.Event
+		wantErr   bool
+	}{
+		{
+			name: "When Broker delivers the message, every instance receives the event once",
+			want: []event.Event{
+				&event.CommentCreated{Comment: comment},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "When Broker fails to publish, Publish returns error",
+			brokerErr: errors.New(model.ErrorMessageForTest),
+			want:      []event.Event{},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := newFakeBroker()
+			broker.err = tt.brokerErr
+
+			publisher, err := NewBrokerBus(broker, "events")
+			if err != nil {
+				t.Fatal(err)
+			}
+			other, err := NewBrokerBus(broker, "events")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotPublisher := []event.Event{}
+			publisher.Subscribe(event.NameCommentCreated, func(ctx context.Context, e event.Event) {
+				gotPublisher = append(gotPublisher, e)
+			})
+			gotOther := []event.Event{}
+			other.Subscribe(event.NameCommentCreated, func(ctx context.Context, e event.Event) {
+				gotOther = append(gotOther, e)
+			})
+
+			if err := publisher.Publish(context.Background(), &event.CommentCreated{Comment: comment}); (err != nil) != tt.wantErr {
+				t.Errorf("brokerBus.Publish() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(gotPublisher, tt.want) {
+				t.Errorf("events received by publisher = %v, want %v", gotPublisher, tt.want)
+			}
+			if !reflect.DeepEqual(gotOther, tt.want) {
+				t.Errorf("events received by other instance = %v, want %v", gotOther, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/event"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+)
+
+// memoryBus is the in-memory domain event bus.
+// It delivers events only to handlers in the same process.
+type memoryBus struct {
+	mu       sync.RWMutex
+	nextID   uint64
+	handlers map[event.Name]map[uint64]event.Handler
+}
+
+// NewMemoryBus generates and returns the in-memory Bus.
+func NewMemoryBus() event.Bus {
+	return &memoryBus{
+		handlers: make(map[event.Name]map[uint64]event.Handler),
+	}
+}
+
+// Publish delivers events to the handlers subscribing them synchronously.
+func (b *memoryBus) Publish(ctx context.Context, events ...event.Event) error {
+	for _, e := range events {
+		for _, h := range b.handlersOf(e.EventName()) {
+			dispatch(ctx, h, e)
+		}
+	}
+	return nil
+}
+
+// Subscribe registers the handler of the event and returns the function which unregisters it.
+func (b *memoryBus) Subscribe(name event.Name, handler event.Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+
+	handlers, ok := b.handlers[name]
+	if !ok {
+		handlers = make(map[uint64]event.Handler)
+		b.handlers[name] = handlers
+	}
+	handlers[id] = handler
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		delete(b.handlers[name], id)
+	}
+}
+
+// handlersOf returns a snapshot of the handlers of the event,
+// so that handlers can subscribe or unsubscribe while being dispatched.
+func (b *memoryBus) handlersOf(name event.Name) []event.Handler {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	handlers := make([]event.Handler, 0, len(b.handlers[name]))
+	for _, h := range b.handlers[name] {
+		handlers = append(handlers, h)
+	}
+	return handlers
+}
+
+// dispatch calls the handler. A panic in the handler is logged
+// so that it does not affect the publisher whose tx has been already committed.
+func dispatch(ctx context.Context, h event.Handler, e event.Event) {
+	defer func() {
+		if p := recover(); p != nil {
+			logger.Logger.Error("event handler panicked",
+				zap.String("event", string(e.EventName())),
+				zap.String("error message", fmt.Sprint(p)))
+		}
+	}()
+
+	h(ctx, e)
+}
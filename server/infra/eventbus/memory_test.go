@@ -0,0 +1,81 @@
+package eventbus
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/event"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+)
+
+func Test_memoryBus_Publish(t *testing.T) {
+	tests := []struct {
+		name        string
+		subscribe   event.Name
+		unsubscribe bool
+		publish     event.Event
+		want        []event.Event
+	}{
+		{
+			name:      "When the handler subscribes the event, the handler receives it",
+			subscribe: event.NameThreadDeleted,
+			publish:   &event.ThreadDeleted{ThreadID: model.ThreadValidIDForTest},
+			want: []event.Event{
+				&event.ThreadDeleted{ThreadID: model.ThreadValidIDForTest},
+			},
+		},
+		{
+			name:      "When the handler subscribes other event, the handler does not receive it",
+			subscribe: event.NameThreadCreated,
+			publish:   &event.ThreadDeleted{ThreadID: model.ThreadValidIDForTest},
+			want:      []event.Event{},
+		},
+		{
+			name:        "When the handler has unsubscribed the event, the handler does not receive it",
+			subscribe:   event.NameThreadDeleted,
+			unsubscribe: true,
+			publish:     &event.ThreadDeleted{ThreadID: model.ThreadValidIDForTest},
+			want:        []event.Event{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewMemoryBus()
+
+			got := []event.Event{}
+			unsubscribe := b.Subscribe(tt.subscribe, func(ctx context.Context, e event.Event) {
+				got = append(got, e)
+			})
+			if tt.unsubscribe {
+				unsubscribe()
+			}
+
+			if err := b.Publish(context.Background(), tt.publish); err != nil {
+				t.Fatalf("memoryBus.Publish() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("received events = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_memoryBus_Publish_panic(t *testing.T) {
+	b := NewMemoryBus()
+
+	received := 0
+	b.Subscribe(event.NameThreadCreated, func(ctx context.Context, e event.Event) {
+		panic("handler panicked")
+	})
+	b.Subscribe(event.NameThreadCreated, func(ctx context.Context, e event.Event) {
+		received++
+	})
+
+	if err := b.Publish(context.Background(), &event.ThreadCreated{}); err != nil {
+		t.Fatalf("memoryBus.Publish() error = %v", err)
+	}
+	if received != 1 {
+		t.Errorf("received = %d, want 1", received)
+	}
+}
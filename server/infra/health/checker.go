@@ -0,0 +1,136 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// statuses of checks.
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+)
+
+// Check checks a dependency, and returns error if it is not available.
+type Check func(ctx context.Context) error
+
+// CheckResult is the result of a check.
+type CheckResult struct {
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// Report is the result of all checks.
+type Report struct {
+	Status string                  `json:"status"`
+	Checks map[string]*CheckResult `json:"checks"`
+}
+
+// OK returns whether all checks have passed.
+func (r *Report) OK() bool {
+	return r.Status == StatusOK
+}
+
+// namedCheck is Check with its name.
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker checks the readiness of the server, i.e. whether its dependencies are available and it is not shutting down.
+type Checker struct {
+	timeout  time.Duration
+	checks   []namedCheck
+	draining int32
+}
+
+// NewChecker generates and returns Checker whose each check times out after timeout.
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout: timeout,
+	}
+}
+
+// Add adds the check of the dependency named name. It must be called before Check is called.
+func (c *Checker) Add(name string, check Check) {
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// Drain makes the server not ready, so that the load balancer stops sending requests before shutdown.
+func (c *Checker) Drain() {
+	atomic.StoreInt32(&c.draining, 1)
+}
+
+// Check runs the checks concurrently, and returns the report of them.
+func (c *Checker) Check(ctx context.Context) *Report {
+	report := &Report{
+		Status: StatusOK,
+		Checks: make(map[string]*CheckResult, len(c.checks)+1),
+	}
+
+	if atomic.LoadInt32(&c.draining) == 1 {
+		report.Status = StatusUnavailable
+		report.Checks["shutdown"] = &CheckResult{
+			Status: StatusUnavailable,
+			Error:  "server is shutting down",
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, nc := range c.checks {
+		wg.Add(1)
+		go func(nc namedCheck) {
+			defer wg.Done()
+
+			result := c.run(ctx, nc.check)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			report.Checks[nc.name] = result
+			if result.Status != StatusOK {
+				report.Status = StatusUnavailable
+			}
+		}(nc)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// run runs the check with the timeout.
+func (c *Checker) run(ctx context.Context, check Check) *CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		// the check which ignores ctx is abandoned.
+		err = errors.Wrap(ctx.Err(), "check has timed out")
+	}
+
+	result := &CheckResult{
+		Status:     StatusOK,
+		DurationMs: int64(time.Since(start) / time.Millisecond),
+	}
+	if err != nil {
+		result.Status = StatusUnavailable
+		result.Error = err.Error()
+	}
+
+	return result
+}
@@ -0,0 +1,104 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestChecker_Check(t *testing.T) {
+	ok := func(ctx context.Context) error {
+		return nil
+	}
+	fail := func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}
+	hang := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	ignore := func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}
+
+	tests := []struct {
+		name       string
+		checks     map[string]Check
+		drain      bool
+		wantStatus string
+		wantChecks map[string]string
+	}{
+		{
+			name:       "When all checks pass, returns ok",
+			checks:     map[string]Check{"db": ok, "migrations": ok},
+			wantStatus: StatusOK,
+			wantChecks: map[string]string{"db": StatusOK, "migrations": StatusOK},
+		},
+		{
+			name:       "When a check fails, returns fail",
+			checks:     map[string]Check{"db": fail, "migrations": ok},
+			wantStatus: StatusUnavailable,
+			wantChecks: map[string]string{"db": StatusUnavailable, "migrations": StatusOK},
+		},
+		{
+			name:       "When a check times out, returns fail",
+			checks:     map[string]Check{"db": hang},
+			wantStatus: StatusUnavailable,
+			wantChecks: map[string]string{"db": StatusUnavailable},
+		},
+		{
+			name:       "When a check ignores the timeout, returns fail without waiting for it",
+			checks:     map[string]Check{"db": ignore},
+			wantStatus: StatusUnavailable,
+			wantChecks: map[string]string{"db": StatusUnavailable},
+		},
+		{
+			name:       "When the server is draining, returns fail",
+			checks:     map[string]Check{"db": ok},
+			drain:      true,
+			wantStatus: StatusUnavailable,
+			wantChecks: map[string]string{"db": StatusOK, "shutdown": StatusUnavailable},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChecker(50 * time.Millisecond)
+			for name, check := range tt.checks {
+				c.Add(name, check)
+			}
+			if tt.drain {
+				c.Drain()
+			}
+
+			start := time.Now()
+			got := c.Check(context.Background())
+			if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+				t.Errorf("Checker.Check() took %v, want it to time out", elapsed)
+			}
+
+			if got.Status != tt.wantStatus {
+				t.Errorf("Checker.Check().Status = %s, want %s", got.Status, tt.wantStatus)
+			}
+			if len(got.Checks) != len(tt.wantChecks) {
+				t.Errorf("Checker.Check().Checks = %v, want %v", got.Checks, tt.wantChecks)
+			}
+			for name, want := range tt.wantChecks {
+				result, ok := got.Checks[name]
+				if !ok {
+					t.Errorf("Checker.Check().Checks[%s] is missing", name)
+					continue
+				}
+				if result.Status != want {
+					t.Errorf("Checker.Check().Checks[%s].Status = %s, want %s", name, result.Status, want)
+				}
+				if (result.Error != "") != (want == StatusUnavailable) {
+					t.Errorf("Checker.Check().Checks[%s].Error = %q", name, result.Error)
+				}
+			}
+		})
+	}
+}
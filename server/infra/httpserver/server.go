@@ -0,0 +1,104 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/config"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+)
+
+// New generates and returns http.Server configured by c.
+func New(c config.Server, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              c.Addr,
+		Handler:           handler,
+		ReadTimeout:       c.ReadTimeout,
+		ReadHeaderTimeout: c.ReadHeaderTimeout,
+		WriteTimeout:      c.WriteTimeout,
+		IdleTimeout:       c.IdleTimeout,
+		MaxHeaderBytes:    c.MaxHeaderBytes,
+	}
+}
+
+// Run serves HTTP until ctx is done, and then shuts down the server gracefully.
+// drain is called first so that the readiness fails, and the server keeps serving for c.ShutdownDelay
+// until the load balancer stops sending requests.
+// The requests in flight are waited for until c.ShutdownTimeout, after which the connections are closed.
+// The functions registered by RegisterOnShutdown are called when it starts to shut down.
+func Run(ctx context.Context, srv *http.Server, c config.Server, drain func()) error {
+	served := make(chan error, 1)
+	go func() {
+		if c.TLS.Enabled() {
+			served <- srv.ListenAndServeTLS(c.TLS.CertFile, c.TLS.KeyFile)
+			return
+		}
+		served <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-served:
+		// the server has stopped before shutdown, e.g. the address is in use.
+		return errors.Wrap(err, "failed to serve")
+	case <-ctx.Done():
+	}
+
+	if drain != nil {
+		drain()
+	}
+
+	if c.ShutdownDelay > 0 {
+		logger.Logger.Info("draining server", zap.Duration("delay", c.ShutdownDelay))
+
+		select {
+		case err := <-served:
+			return errors.Wrap(err, "failed to serve")
+		case <-time.After(c.ShutdownDelay):
+		}
+	}
+
+	logger.Logger.Info("shutting down server", zap.Duration("timeout", c.ShutdownTimeout))
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), c.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		if closeErr := srv.Close(); closeErr != nil {
+			logger.Logger.Error("failed to close server", zap.String("error message", closeErr.Error()))
+		}
+		return errors.Wrap(err, "failed to shut down server")
+	}
+
+	if err := <-served; err != http.ErrServerClosed {
+		return errors.Wrap(err, "failed to serve")
+	}
+
+	return nil
+}
+
+// SignalContext returns the context which is done when the process receives SIGINT or SIGTERM.
+func SignalContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		defer signal.Stop(signals)
+
+		select {
+		case sig := <-signals:
+			logger.Logger.Info("received signal", zap.String("signal", sig.String()))
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
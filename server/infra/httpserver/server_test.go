@@ -0,0 +1,133 @@
+package httpserver
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sekky0905/nuxt-vue-go-chat/server/config"
+)
+
+// freeAddrForTest returns the address of a free port.
+func freeAddrForTest(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := l.Addr().String()
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return addr
+}
+
+func TestRun(t *testing.T) {
+	addr := freeAddrForTest(t)
+
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		if _, err := w.Write([]byte("done")); err != nil {
+			t.Error(err)
+		}
+	})
+
+	c := config.Default().Server
+	c.Addr = addr
+	c.ShutdownDelay = 50 * time.Millisecond
+	c.ShutdownTimeout = time.Second
+	srv := New(c, handler)
+
+	drained := make(chan struct{})
+	drain := func() {
+		close(drained)
+	}
+
+	hooked := make(chan struct{})
+	srv.RegisterOnShutdown(func() {
+		close(hooked)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ran := make(chan error, 1)
+	go func() {
+		ran <- Run(ctx, srv, c, drain)
+	}()
+
+	// wait for the server to listen.
+	var res *http.Response
+	responded := make(chan error, 1)
+	go func() {
+		for i := 0; i < 50; i++ {
+			r, err := http.Get("http://" + addr)
+			if err == nil {
+				res = r
+				responded <- nil
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		responded <- context.DeadlineExceeded
+	}()
+
+	<-started
+	cancel()
+
+	if err := <-responded; err != nil {
+		t.Fatalf("the request in flight has failed, %v", err)
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	if closeErr := res.Body.Close(); closeErr != nil {
+		t.Error(closeErr)
+	}
+	if err != nil || string(body) != "done" {
+		t.Errorf("body = %s, %v, want done", body, err)
+	}
+
+	if err := <-ran; err != nil {
+		t.Errorf("Run() error = %v", err)
+	}
+
+	select {
+	case <-drained:
+	default:
+		t.Error("drain has not been called")
+	}
+
+	select {
+	case <-hooked:
+	default:
+		t.Error("the function registered by RegisterOnShutdown has not been called")
+	}
+
+	if res, err := http.Get("http://" + addr); err == nil {
+		t.Error("the server still accepts requests after shutdown")
+		if err := res.Body.Close(); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestRun_listenError(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := l.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	c := config.Default().Server
+	c.Addr = l.Addr().String()
+
+	if err := Run(context.Background(), New(c, http.NotFoundHandler()), c, nil); err == nil {
+		t.Error("Run() error = nil, want error when the address is in use")
+	}
+}
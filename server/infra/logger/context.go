@@ -0,0 +1,32 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// contextKey is the type of keys of values which are bound into context.Context.
+type contextKey int
+
+// loggerContextKey is the key of the logger bound into context.Context.
+const loggerContextKey contextKey = iota
+
+// WithContext returns the copy of ctx which holds l, e.g. the logger which has the fields of the request.
+func WithContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey, l)
+}
+
+// FromContext returns the logger bound into ctx, or Logger if it is not bound,
+// so that the functions called outside of requests can use it as well.
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(loggerContextKey).(*zap.Logger); ok && l != nil {
+		return l
+	}
+	return Logger
+}
+
+// With returns the copy of ctx which holds the logger bound into ctx with the fields.
+func With(ctx context.Context, fields ...zap.Field) context.Context {
+	return WithContext(ctx, FromContext(ctx).With(fields...))
+}
@@ -8,8 +8,15 @@ import (
 // Logger is Log object.
 var Logger *zap.Logger
 
+// level is the level of Logger, which can be changed after Logger is built.
+var level = zap.NewAtomicLevel()
+
+// SetLevel sets the level of Logger.
+func SetLevel(l zapcore.Level) {
+	level.SetLevel(l)
+}
+
 func init() {
-	level := zap.NewAtomicLevel()
 	level.SetLevel(zapcore.DebugLevel)
 
 	myConfig := zap.Config{
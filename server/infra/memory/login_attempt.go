@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+)
+
+// loginAttemptSweepSize is the number of records at which forgotten records are swept.
+const loginAttemptSweepSize = 10000
+
+// loginAttemptRepository is repository of login attempt in memory, which is not shared among instances.
+// The arguments of query.SQLManager are ignored.
+type loginAttemptRepository struct {
+	mu       sync.Mutex
+	attempts map[string]*model.LoginAttempt
+}
+
+// NewLoginAttemptRepository generates and returns LoginAttemptRepository.
+func NewLoginAttemptRepository() repository.LoginAttemptRepository {
+	return &loginAttemptRepository{
+		attempts: make(map[string]*model.LoginAttempt),
+	}
+}
+
+// GetLoginAttempt gets and returns a record specified by key.
+func (repo *loginAttemptRepository) GetLoginAttempt(ctx context.Context, m query.SQLManager, key string) (*model.LoginAttempt, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	attempt, ok := repo.attempts[key]
+	if !ok {
+		err := &model.NoSuchDataError{
+			PropertyName:    model.KeyProperty,
+			PropertyValue:   key,
+			DomainModelName: model.DomainModelNameLoginAttempt,
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	copied := *attempt
+	return &copied, nil
+}
+
+// IncrementLoginAttempt increments the failures of a record, or inserts a record if it does not exist.
+// The failures before forgetBefore are forgotten, and counted from 1 again.
+func (repo *loginAttemptRepository) IncrementLoginAttempt(ctx context.Context, m query.SQLManager, key string, now, forgetBefore time.Time) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if len(repo.attempts) >= loginAttemptSweepSize {
+		repo.sweep(forgetBefore)
+	}
+
+	attempt, ok := repo.attempts[key]
+	if !ok || !attempt.LastFailedAt.After(forgetBefore) {
+		attempt = &model.LoginAttempt{Key: key}
+		repo.attempts[key] = attempt
+	}
+
+	attempt.Failures++
+	attempt.LastFailedAt = now
+
+	return nil
+}
+
+// DeleteLoginAttempt deletes a record specified by key.
+func (repo *loginAttemptRepository) DeleteLoginAttempt(ctx context.Context, m query.SQLManager, key string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	delete(repo.attempts, key)
+	return nil
+}
+
+// sweep deletes the records which have been forgotten, so that the memory does not grow without limit.
+func (repo *loginAttemptRepository) sweep(forgetBefore time.Time) {
+	for key, attempt := range repo.attempts {
+		if !attempt.LastFailedAt.After(forgetBefore) {
+			delete(repo.attempts, key)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+)
+
+func Test_loginAttemptRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewLoginAttemptRepository()
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	forgetBefore := now.Add(-time.Hour)
+
+	if _, err := repo.GetLoginAttempt(ctx, nil, "key"); err == nil {
+		t.Fatal("GetLoginAttempt() error = nil, want NoSuchDataError")
+	} else if _, ok := errors.Cause(err).(*model.NoSuchDataError); !ok {
+		t.Fatalf("GetLoginAttempt() error = %#v, want NoSuchDataError", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := repo.IncrementLoginAttempt(ctx, nil, "key", now, forgetBefore); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := repo.GetLoginAttempt(ctx, nil, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Failures != 3 || !got.LastFailedAt.Equal(now) {
+		t.Errorf("GetLoginAttempt() = %#v, want 3 failures at %v", got, now)
+	}
+
+	// the failures are forgotten when the last failure is before forgetBefore.
+	later := now.Add(2 * time.Hour)
+	if err := repo.IncrementLoginAttempt(ctx, nil, "key", later, later.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	got, err = repo.GetLoginAttempt(ctx, nil, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Failures != 1 {
+		t.Errorf("failures = %d, want 1", got.Failures)
+	}
+
+	if err := repo.DeleteLoginAttempt(ctx, nil, "key"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetLoginAttempt(ctx, nil, "key"); err == nil {
+		t.Error("GetLoginAttempt() error = nil after DeleteLoginAttempt()")
+	}
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
+)
+
+const (
+	// rateLimitSweepSize is the number of buckets at which full buckets are swept.
+	rateLimitSweepSize = 10000
+	// rateLimitSweepInterval is the min interval of the sweeps, so that a request does not sweep every time
+	// when there are many buckets which are not full.
+	rateLimitSweepInterval = time.Minute
+	// rateLimitMaxBuckets is the max number of buckets, which bounds the memory between the sweeps.
+	rateLimitMaxBuckets = 100000
+)
+
+// rateLimitStore is the store of the token buckets in memory, which is not shared among instances.
+type rateLimitStore struct {
+	mu            sync.Mutex
+	buckets       map[string]*rateLimitEntry
+	sweepSize     int
+	sweepInterval time.Duration
+	maxBuckets    int
+	lastSweptAt   time.Time
+}
+
+// rateLimitEntry is the bucket with the limit of it, which is needed to sweep it.
+type rateLimitEntry struct {
+	bucket *model.RateLimitBucket
+	limit  model.RateLimit
+}
+
+// NewRateLimitStore generates and returns RateLimitStore.
+func NewRateLimitStore() repository.RateLimitStore {
+	return &rateLimitStore{
+		buckets:       make(map[string]*rateLimitEntry),
+		sweepSize:     rateLimitSweepSize,
+		sweepInterval: rateLimitSweepInterval,
+		maxBuckets:    rateLimitMaxBuckets,
+	}
+}
+
+// Take takes a token from the bucket of key, and returns the status.
+func (s *rateLimitStore) Take(ctx context.Context, key string, limit model.RateLimit, now time.Time) (*model.RateLimitStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.buckets) >= s.sweepSize && now.Sub(s.lastSweptAt) >= s.sweepInterval {
+		s.sweep(now)
+	}
+
+	entry, ok := s.buckets[key]
+	if !ok {
+		if len(s.buckets) >= s.maxBuckets {
+			s.evict()
+		}
+		entry = &rateLimitEntry{bucket: model.NewRateLimitBucket(limit, now)}
+		s.buckets[key] = entry
+	}
+	entry.limit = limit
+
+	return entry.bucket.Take(limit, now), nil
+}
+
+// sweep deletes the buckets which are full, because they are the same as new ones.
+func (s *rateLimitStore) sweep(now time.Time) {
+	for key, entry := range s.buckets {
+		if entry.bucket.IsFull(entry.limit, now) {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweptAt = now
+}
+
+// evict deletes an arbitrary bucket to make room for a new one.
+// The key of it is limited from a full bucket again, which is the cost of bounding the memory.
+func (s *rateLimitStore) evict() {
+	for key := range s.buckets {
+		delete(s.buckets, key)
+		return
+	}
+}
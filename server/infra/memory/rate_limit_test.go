@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+)
+
+func Test_rateLimitStore_sweep(t *testing.T) {
+	ctx := context.Background()
+	limit := model.RateLimit{Limit: 1, Period: time.Minute}
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s := &rateLimitStore{
+		buckets:       make(map[string]*rateLimitEntry),
+		sweepSize:     2,
+		sweepInterval: time.Minute,
+		maxBuckets:    10,
+	}
+
+	take := func(key string, at time.Time) {
+		if _, err := s.Take(ctx, key, limit, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	take("a", now)
+	take("b", now)
+
+	// the buckets of a and b are full again, so that they are swept.
+	later := now.Add(2 * time.Minute)
+	take("c", later)
+	if len(s.buckets) != 1 {
+		t.Fatalf("the number of buckets = %d, want 1", len(s.buckets))
+	}
+	if !s.lastSweptAt.Equal(later) {
+		t.Errorf("lastSweptAt = %v, want %v", s.lastSweptAt, later)
+	}
+
+	// the buckets are not swept again within the interval even if they are full.
+	take("d", later.Add(time.Minute-time.Second))
+	take("e", later.Add(time.Minute-time.Second))
+	if len(s.buckets) != 3 {
+		t.Errorf("the number of buckets = %d, want 3", len(s.buckets))
+	}
+	if !s.lastSweptAt.Equal(later) {
+		t.Errorf("lastSweptAt = %v, want %v", s.lastSweptAt, later)
+	}
+}
+
+func Test_rateLimitStore_maxBuckets(t *testing.T) {
+	ctx := context.Background()
+	limit := model.RateLimit{Limit: 1, Period: time.Hour}
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s := &rateLimitStore{
+		buckets:       make(map[string]*rateLimitEntry),
+		sweepSize:     3,
+		sweepInterval: time.Minute,
+		maxBuckets:    3,
+	}
+
+	// none of the buckets is full, so that the sweeps can not make room for them.
+	for i := 0; i < 10; i++ {
+		if _, err := s.Take(ctx, strconv.Itoa(i), limit, now.Add(time.Duration(i)*time.Second)); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(s.buckets) > s.maxBuckets {
+			t.Fatalf("the number of buckets = %d, want at most %d", len(s.buckets), s.maxBuckets)
+		}
+	}
+
+	if _, ok := s.buckets["9"]; !ok {
+		t.Error("the bucket of the last key is evicted")
+	}
+}
@@ -0,0 +1,184 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+)
+
+// operations of SQL.
+const (
+	operationExec    = "exec"
+	operationQuery   = "query"
+	operationPrepare = "prepare"
+)
+
+// actions of transactions.
+const (
+	actionCommit   = "commit"
+	actionRollback = "rollback"
+)
+
+// observeQuery records the SQL operation which has started at start.
+func observeQuery(operation string, start time.Time, err error) {
+	dbQueryDuration.WithLabelValues(operation, resultOf(err)).Observe(time.Since(start).Seconds())
+}
+
+// sqlManager is the decorator of query.SQLManager which records the latency of SQL.
+// The statements made by Prepare are measured when they are prepared, because *sql.Stmt cannot be decorated.
+type sqlManager struct {
+	next query.SQLManager
+}
+
+// Exec executes SQL.
+func (m *sqlManager) Exec(q string, args ...interface{}) (res sql.Result, err error) {
+	defer func(start time.Time) { observeQuery(operationExec, start, err) }(time.Now())
+	return m.next.Exec(q, args...)
+}
+
+// ExecContext executes SQL with context.
+func (m *sqlManager) ExecContext(ctx context.Context, q string, args ...interface{}) (res sql.Result, err error) {
+	defer func(start time.Time) { observeQuery(operationExec, start, err) }(time.Now())
+	return m.next.ExecContext(ctx, q, args...)
+}
+
+// Query executes query which return row.
+func (m *sqlManager) Query(q string, args ...interface{}) (rows *sql.Rows, err error) {
+	defer func(start time.Time) { observeQuery(operationQuery, start, err) }(time.Now())
+	return m.next.Query(q, args...)
+}
+
+// QueryContext executes query which return row with context.
+func (m *sqlManager) QueryContext(ctx context.Context, q string, args ...interface{}) (rows *sql.Rows, err error) {
+	defer func(start time.Time) { observeQuery(operationQuery, start, err) }(time.Now())
+	return m.next.QueryContext(ctx, q, args...)
+}
+
+// Prepare prepares statement for Query and Exec later.
+func (m *sqlManager) Prepare(q string) (stmt *sql.Stmt, err error) {
+	defer func(start time.Time) { observeQuery(operationPrepare, start, err) }(time.Now())
+	return m.next.Prepare(q)
+}
+
+// PrepareContext prepares statement for Query and Exec later with context.
+func (m *sqlManager) PrepareContext(ctx context.Context, q string) (stmt *sql.Stmt, err error) {
+	defer func(start time.Time) { observeQuery(operationPrepare, start, err) }(time.Now())
+	return m.next.PrepareContext(ctx, q)
+}
+
+// dbManager is the decorator of query.DBManager which records the latency of SQL and the results of transactions.
+type dbManager struct {
+	sqlManager
+	next query.DBManager
+}
+
+// NewDBManager generates and returns query.DBManager which records the metrics of m.
+func NewDBManager(m query.DBManager) query.DBManager {
+	return &dbManager{
+		sqlManager: sqlManager{next: m},
+		next:       m,
+	}
+}
+
+// Begin begins tx whose commit and rollback are counted.
+func (m *dbManager) Begin() (query.TxManager, error) {
+	tx, err := m.next.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	return &txManager{
+		sqlManager: sqlManager{next: tx},
+		next:       tx,
+	}, nil
+}
+
+// PingContext verifies the connection to the database is alive.
+func (m *dbManager) PingContext(ctx context.Context) error {
+	return m.next.PingContext(ctx)
+}
+
+// Stats returns the statistics of the connection pool.
+func (m *dbManager) Stats() sql.DBStats {
+	return m.next.Stats()
+}
+
+// Close closes the connection pool.
+func (m *dbManager) Close() error {
+	return m.next.Close()
+}
+
+// txManager is the decorator of query.TxManager which records the latency of SQL and the results of the tx.
+type txManager struct {
+	sqlManager
+	next query.TxManager
+}
+
+// Commit commits the tx.
+func (m *txManager) Commit() error {
+	err := m.next.Commit()
+	dbTransactions.WithLabelValues(actionCommit, resultOf(err)).Inc()
+	return err
+}
+
+// Rollback rollbacks the tx.
+func (m *txManager) Rollback() error {
+	err := m.next.Rollback()
+	dbTransactions.WithLabelValues(actionRollback, resultOf(err)).Inc()
+	return err
+}
+
+// dbStatsCollector is prometheus.Collector which collects the statistics of the connection pool.
+type dbStatsCollector struct {
+	stats func() sql.DBStats
+
+	maxOpen      *prometheus.Desc
+	open         *prometheus.Desc
+	inUse        *prometheus.Desc
+	idle         *prometheus.Desc
+	waitCount    *prometheus.Desc
+	waitDuration *prometheus.Desc
+}
+
+// NewDBStatsCollector generates and returns prometheus.Collector which collects the statistics returned by stats,
+// e.g. query.DBManager.Stats.
+func NewDBStatsCollector(stats func() sql.DBStats) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db", name), help, nil, nil)
+	}
+
+	return &dbStatsCollector{
+		stats:        stats,
+		maxOpen:      desc("max_open_connections", "Maximum number of open connections to the database."),
+		open:         desc("open_connections", "Number of established connections both in use and idle."),
+		inUse:        desc("in_use_connections", "Number of connections currently in use."),
+		idle:         desc("idle_connections", "Number of idle connections."),
+		waitCount:    desc("wait_count_total", "Total number of connections waited for."),
+		waitDuration: desc("wait_duration_seconds_total", "Total time blocked waiting for a new connection."),
+	}
+}
+
+// Describe sends the descriptors of the metrics.
+func (c *dbStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.maxOpen
+	ch <- c.open
+	ch <- c.inUse
+	ch <- c.idle
+	ch <- c.waitCount
+	ch <- c.waitDuration
+}
+
+// Collect sends the metrics of the current statistics.
+func (c *dbStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.stats()
+
+	ch <- prometheus.MustNewConstMetric(c.maxOpen, prometheus.GaugeValue, float64(stats.MaxOpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.open, prometheus.GaugeValue, float64(stats.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.inUse, prometheus.GaugeValue, float64(stats.InUse))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stats.Idle))
+	ch <- prometheus.MustNewConstMetric(c.waitCount, prometheus.CounterValue, float64(stats.WaitCount))
+	ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, stats.WaitDuration.Seconds())
+}
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	mock_query "github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query/mock"
+)
+
+// sampleCountForTest returns the number of the observations of the histogram.
+func sampleCountForTest(t *testing.T, o prometheus.Observer) uint64 {
+	m := &dto.Metric{}
+	if err := o.(prometheus.Metric).Write(m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestDBManager(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	q := "SELECT 1"
+
+	m := mock_query.NewMockDBManager(ctrl)
+	m.EXPECT().ExecContext(ctx, q).Return(nil, nil)
+	m.EXPECT().QueryContext(ctx, q).Return(nil, errors.New("failed"))
+
+	tx1 := mock_query.NewMockTxManager(ctrl)
+	tx1.EXPECT().PrepareContext(ctx, q).Return(nil, nil)
+	tx1.EXPECT().Commit().Return(nil)
+
+	tx2 := mock_query.NewMockTxManager(ctrl)
+	tx2.EXPECT().Rollback().Return(sql.ErrTxDone)
+
+	gomock.InOrder(
+		m.EXPECT().Begin().Return(tx1, nil),
+		m.EXPECT().Begin().Return(tx2, nil),
+	)
+
+	execOK := dbQueryDuration.WithLabelValues(operationExec, resultOK)
+	queryError := dbQueryDuration.WithLabelValues(operationQuery, resultError)
+	prepareOK := dbQueryDuration.WithLabelValues(operationPrepare, resultOK)
+	commitOK := dbTransactions.WithLabelValues(actionCommit, resultOK)
+	rollbackError := dbTransactions.WithLabelValues(actionRollback, resultError)
+
+	wantExec := sampleCountForTest(t, execOK) + 1
+	wantQuery := sampleCountForTest(t, queryError) + 1
+	wantPrepare := sampleCountForTest(t, prepareOK) + 1
+	wantCommit := testutil.ToFloat64(commitOK) + 1
+	wantRollback := testutil.ToFloat64(rollbackError) + 1
+
+	dbm := NewDBManager(m)
+
+	if _, err := dbm.ExecContext(ctx, q); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dbm.QueryContext(ctx, q); err == nil {
+		t.Fatal("QueryContext() error = nil, want error")
+	}
+
+	tx, err := dbm.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.PrepareContext(ctx, q); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err = dbm.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != sql.ErrTxDone {
+		t.Fatalf("Rollback() error = %v, want %v", err, sql.ErrTxDone)
+	}
+
+	if got := sampleCountForTest(t, execOK); got != wantExec {
+		t.Errorf("exec ok = %d, want %d", got, wantExec)
+	}
+	if got := sampleCountForTest(t, queryError); got != wantQuery {
+		t.Errorf("query error = %d, want %d", got, wantQuery)
+	}
+	if got := sampleCountForTest(t, prepareOK); got != wantPrepare {
+		t.Errorf("prepare ok = %d, want %d", got, wantPrepare)
+	}
+	if got := testutil.ToFloat64(commitOK); got != wantCommit {
+		t.Errorf("commit ok = %v, want %v", got, wantCommit)
+	}
+	if got := testutil.ToFloat64(rollbackError); got != wantRollback {
+		t.Errorf("rollback error = %v, want %v", got, wantRollback)
+	}
+}
+
+func TestDBStatsCollector(t *testing.T) {
+	c := NewDBStatsCollector(func() sql.DBStats {
+		return sql.DBStats{MaxOpenConnections: 25, OpenConnections: 3, InUse: 1, Idle: 2}
+	})
+
+	r := prometheus.NewRegistry()
+	r.MustRegister(c)
+	mfs, err := r.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := 0
+	for _, mf := range mfs {
+		got += len(mf.GetMetric())
+	}
+	if got != 6 {
+		t.Errorf("the number of metrics = %d, want 6", got)
+	}
+}
@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/event"
+)
+
+// SubscribeDomainEvents counts the domain events of creation published to sub.
+func SubscribeDomainEvents(sub event.Subscriber) (unsubscribe func()) {
+	unsubscribeThread := sub.Subscribe(event.NameThreadCreated, func(ctx context.Context, e event.Event) {
+		ThreadsCreated.Inc()
+	})
+	unsubscribeComment := sub.Subscribe(event.NameCommentCreated, func(ctx context.Context, e event.Event) {
+		CommentsCreated.Inc()
+	})
+
+	return func() {
+		unsubscribeThread()
+		unsubscribeComment()
+	}
+}
@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace is the namespace of the metrics.
+const namespace = "nvgc"
+
+// results of the operations.
+const (
+	resultOK    = "ok"
+	resultError = "error"
+)
+
+// Registry is the registry of the metrics of the server.
+var Registry = prometheus.NewRegistry()
+
+// metrics of HTTP.
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+)
+
+// metrics of DB.
+var (
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Latency of SQL by operation and result.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "result"})
+
+	dbTransactions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "transactions_total",
+		Help:      "Number of finished transactions by action, i.e. commit or rollback, and result.",
+	}, []string{"action", "result"})
+)
+
+// metrics of the domain.
+var (
+	// SignUps is the number of the users who have signed up.
+	SignUps = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "signups_total",
+		Help:      "Number of users who have signed up.",
+	})
+
+	// Logins is the number of the succeeded logins.
+	Logins = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "logins_total",
+		Help:      "Number of succeeded logins.",
+	})
+
+	// LoginFailures is the number of the logins which have failed because of the invalid name or password.
+	LoginFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "login_failures_total",
+		Help:      "Number of logins which have failed because of the invalid name or password.",
+	})
+
+	// ThreadsCreated is the number of the created threads.
+	ThreadsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "threads_created_total",
+		Help:      "Number of created threads.",
+	})
+
+	// CommentsCreated is the number of the created comments.
+	CommentsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "comments_created_total",
+		Help:      "Number of created comments.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		httpRequests,
+		httpRequestDuration,
+		dbQueryDuration,
+		dbTransactions,
+		SignUps,
+		Logins,
+		LoginFailures,
+		ThreadsCreated,
+		CommentsCreated,
+	)
+}
+
+// Handler returns the handler which exposes the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTPRequest records the HTTP request which has been handled in d.
+func ObserveHTTPRequest(method, route string, status int, d time.Duration) {
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpRequestDuration.WithLabelValues(method, route, code).Observe(d.Seconds())
+}
+
+// resultOf returns the label of the result of the operation which has returned err.
+func resultOf(err error) string {
+	if err != nil {
+		return resultError
+	}
+	return resultOK
+}
@@ -0,0 +1,32 @@
+package notifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/service"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+)
+
+// logNotifier writes notifications to the log instead of delivering them.
+// This is for local use, since the log contains credentials such as reset tokens.
+type logNotifier struct {
+}
+
+// NewLogNotifier generates and returns Notifier which only writes logs.
+func NewLogNotifier() service.Notifier {
+	return &logNotifier{}
+}
+
+// NotifyPasswordReset writes the password reset token to the log.
+func (n *logNotifier) NotifyPasswordReset(ctx context.Context, user *model.User, token string, expiresAt time.Time) error {
+	logger.FromContext(ctx).Info("password reset has been requested",
+		zap.Uint32("userID", user.ID),
+		zap.String("userName", user.Name),
+		zap.String("token", token),
+		zap.Time("expiresAt", expiresAt),
+	)
+	return nil
+}
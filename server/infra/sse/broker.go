@@ -0,0 +1,231 @@
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/event"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+)
+
+// EventType is type of the event which is sent to clients.
+type EventType string
+
+// types of event.
+const (
+	EventTypeThreadCreated  EventType = "thread.created"
+	EventTypeThreadUpdated  EventType = "thread.updated"
+	EventTypeThreadDeleted  EventType = "thread.deleted"
+	EventTypeThreadRestored EventType = "thread.restored"
+	// EventTypeReset tells the client that the events after its Last-Event-ID can not be replayed,
+	// so that it has to fetch the threads again.
+	EventTypeReset EventType = "reset"
+)
+
+const (
+	// defaultBufferSize is the number of events which are kept for replay.
+	defaultBufferSize = 256
+	// subscriberBufferSize is size of the buffer of each subscriber.
+	subscriberBufferSize = 64
+)
+
+// Event is the event which is sent to clients.
+// ID is "<epoch>-<seq>", so that the ids of the events before the restart of the process are not mistaken for new ones.
+type Event struct {
+	ID   string
+	Type EventType
+	Data []byte
+	seq  uint64
+}
+
+// Broker keeps recent events in a ring buffer and fans out new events to subscribers.
+type Broker struct {
+	mu          sync.Mutex
+	epoch       string
+	lastSeq     uint64
+	buffer      []*Event
+	head        int
+	size        int
+	subscribers map[chan *Event]bool
+	closed      bool
+}
+
+// NewBroker generates and returns Broker which keeps the given number of events.
+func NewBroker(bufferSize int) *Broker {
+	if bufferSize < 1 {
+		bufferSize = defaultBufferSize
+	}
+
+	return &Broker{
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		buffer:      make([]*Event, bufferSize),
+		subscribers: make(map[chan *Event]bool),
+	}
+}
+
+// Subscribe registers a subscriber and returns its channel and the buffered events after lastEventID.
+// When lastEventID is unknown or has been evicted from the ring buffer, a reset event is returned instead.
+func (b *Broker) Subscribe(lastEventID string) (<-chan *Event, []*Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan *Event, subscriberBufferSize)
+	if b.closed {
+		close(ch)
+		return ch, nil
+	}
+	b.subscribers[ch] = true
+
+	return ch, b.eventsAfter(lastEventID)
+}
+
+// Unsubscribe removes the subscriber.
+func (b *Broker) Unsubscribe(ch <-chan *Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for c := range b.subscribers {
+		if c == ch {
+			b.remove(c)
+			return
+		}
+	}
+}
+
+// Close closes the channels of all subscribers and the subscribers which subscribe later,
+// so that the streams end and the HTTP server can shut down.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for ch := range b.subscribers {
+		b.remove(ch)
+	}
+}
+
+// remove removes the subscriber and closes its channel. The caller must hold the lock.
+func (b *Broker) remove(ch chan *Event) {
+	if _, ok := b.subscribers[ch]; !ok {
+		return
+	}
+	delete(b.subscribers, ch)
+	close(ch)
+}
+
+// eventID returns the id of the event of the given seq.
+func (b *Broker) eventID(seq uint64) string {
+	return fmt.Sprintf("%s-%d", b.epoch, seq)
+}
+
+// parseEventID returns the seq of the event id, and false when the id was not issued by this process.
+func (b *Broker) parseEventID(id string) (uint64, bool) {
+	i := strings.LastIndex(id, "-")
+	if i < 0 || id[:i] != b.epoch {
+		return 0, false
+	}
+
+	seq, err := strconv.ParseUint(id[i+1:], 10, 64)
+	if err != nil || seq > b.lastSeq {
+		return 0, false
+	}
+	return seq, true
+}
+
+// eventsAfter returns the buffered events after the event of the id. The caller must hold the lock.
+func (b *Broker) eventsAfter(id string) []*Event {
+	events := make([]*Event, 0)
+	if id == "" {
+		return events
+	}
+
+	seq, ok := b.parseEventID(id)
+	capacity := len(b.buffer)
+	oldest := b.buffer[(b.head-b.size+capacity)%capacity]
+	if !ok || (b.size > 0 && oldest.seq > seq+1) {
+		return append(events, &Event{
+			ID:   b.eventID(b.lastSeq),
+			Type: EventTypeReset,
+			Data: []byte("{}"),
+			seq:  b.lastSeq,
+		})
+	}
+
+	for i := 0; i < b.size; i++ {
+		e := b.buffer[(b.head-b.size+i+capacity)%capacity]
+		if e.seq > seq {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// Publish stores the event in the ring buffer and sends it to subscribers.
+// Subscribers whose buffer is full are dropped, they can catch up by reconnecting with Last-Event-ID.
+func (b *Broker) Publish(eventType EventType, data []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastSeq++
+	e := &Event{
+		ID:   b.eventID(b.lastSeq),
+		Type: eventType,
+		Data: data,
+		seq:  b.lastSeq,
+	}
+
+	b.buffer[b.head] = e
+	b.head = (b.head + 1) % len(b.buffer)
+	if b.size < len(b.buffer) {
+		b.size++
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- e:
+		default:
+			logger.Logger.Warn("drop slow subscriber", zap.String("eventID", e.ID))
+			b.remove(ch)
+		}
+	}
+}
+
+// notify encodes the thread and publishes it.
+func (b *Broker) notify(eventType EventType, thread *model.Thread) {
+	data, err := json.Marshal(thread)
+	if err != nil {
+		logger.Logger.Error("failed to marshal thread", zap.String("error message", err.Error()))
+		return
+	}
+
+	b.Publish(eventType, data)
+}
+
+// Listen subscribes events of threads to publish them.
+func (b *Broker) Listen(s event.Subscriber) {
+	s.Subscribe(event.NameThreadCreated, b.handle)
+	s.Subscribe(event.NameThreadUpdated, b.handle)
+	s.Subscribe(event.NameThreadDeleted, b.handle)
+	s.Subscribe(event.NameThreadRestored, b.handle)
+}
+
+// handle publishes the event of the thread.
+func (b *Broker) handle(ctx context.Context, e event.Event) {
+	switch e := e.(type) {
+	case *event.ThreadCreated:
+		b.notify(EventTypeThreadCreated, e.Thread)
+	case *event.ThreadUpdated:
+		b.notify(EventTypeThreadUpdated, e.Thread)
+	case *event.ThreadDeleted:
+		b.notify(EventTypeThreadDeleted, &model.Thread{ID: e.ThreadID})
+	case *event.ThreadRestored:
+		b.notify(EventTypeThreadRestored, e.Thread)
+	}
+}
@@ -0,0 +1,177 @@
+package sse
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestBroker_Subscribe(t *testing.T) {
+	type args struct {
+		// lastSeq is the seq of Last-Event-ID, and -1 means that Last-Event-ID is not given.
+		lastSeq int
+		// otherEpoch means that Last-Event-ID was issued by another process.
+		otherEpoch bool
+	}
+	tests := []struct {
+		name       string
+		bufferSize int
+		published  int
+		args       args
+		wantSeqs   []uint64
+		wantReset  bool
+	}{
+		{
+			name:       "When Last-Event-ID is not given, no events are replayed",
+			bufferSize: 4,
+			published:  3,
+			args: args{
+				lastSeq: -1,
+			},
+			wantSeqs: []uint64{},
+		},
+		{
+			name:       "When Last-Event-ID is given, events after it are replayed",
+			bufferSize: 4,
+			published:  3,
+			args: args{
+				lastSeq: 1,
+			},
+			wantSeqs: []uint64{2, 3},
+		},
+		{
+			name:       "When the ring buffer has wrapped around and the next event is buffered, the buffered events are replayed in order",
+			bufferSize: 4,
+			published:  6,
+			args: args{
+				lastSeq: 2,
+			},
+			wantSeqs: []uint64{3, 4, 5, 6},
+		},
+		{
+			name:       "When the client has received all events, no events are replayed",
+			bufferSize: 4,
+			published:  6,
+			args: args{
+				lastSeq: 6,
+			},
+			wantSeqs: []uint64{},
+		},
+		{
+			name:       "When Last-Event-ID has been evicted from the ring buffer, a reset event is returned",
+			bufferSize: 4,
+			published:  6,
+			args: args{
+				lastSeq: 1,
+			},
+			wantSeqs:  []uint64{6},
+			wantReset: true,
+		},
+		{
+			name:       "When Last-Event-ID was issued before the restart, a reset event is returned",
+			bufferSize: 4,
+			published:  3,
+			args: args{
+				lastSeq:    5000,
+				otherEpoch: true,
+			},
+			wantSeqs:  []uint64{3},
+			wantReset: true,
+		},
+		{
+			name:       "When Last-Event-ID is greater than the last event, a reset event is returned",
+			bufferSize: 4,
+			published:  3,
+			args: args{
+				lastSeq: 5000,
+			},
+			wantSeqs:  []uint64{3},
+			wantReset: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBroker(tt.bufferSize)
+			for i := 0; i < tt.published; i++ {
+				b.Publish(EventTypeThreadCreated, []byte("{}"))
+			}
+
+			lastEventID := ""
+			if tt.args.lastSeq >= 0 {
+				epoch := b.epoch
+				if tt.args.otherEpoch {
+					epoch = "before-restart"
+				}
+				lastEventID = fmt.Sprintf("%s-%d", epoch, tt.args.lastSeq)
+			}
+
+			ch, replay := b.Subscribe(lastEventID)
+			defer b.Unsubscribe(ch)
+
+			gotSeqs := make([]uint64, 0, len(replay))
+			for _, e := range replay {
+				gotSeqs = append(gotSeqs, e.seq)
+				if e.ID != b.eventID(e.seq) {
+					t.Errorf("Broker.Subscribe() replay id = %s, want %s", e.ID, b.eventID(e.seq))
+				}
+			}
+			if !reflect.DeepEqual(gotSeqs, tt.wantSeqs) {
+				t.Errorf("Broker.Subscribe() replay = %v, want %v", gotSeqs, tt.wantSeqs)
+			}
+			if gotReset := len(replay) == 1 && replay[0].Type == EventTypeReset; gotReset != tt.wantReset {
+				t.Errorf("Broker.Subscribe() reset = %v, want %v", gotReset, tt.wantReset)
+			}
+		})
+	}
+}
+
+func TestBroker_Publish(t *testing.T) {
+	b := NewBroker(defaultBufferSize)
+	ch, _ := b.Subscribe("")
+
+	b.Publish(EventTypeThreadDeleted, []byte(`{"id":1}`))
+
+	e, ok := <-ch
+	if !ok {
+		t.Fatal("channel has been closed")
+	}
+	want := &Event{
+		ID:   b.epoch + "-1",
+		Type: EventTypeThreadDeleted,
+		Data: []byte(`{"id":1}`),
+		seq:  1,
+	}
+	if !reflect.DeepEqual(e, want) {
+		t.Errorf("event = %+v, want %+v", e, want)
+	}
+
+	// a subscriber which does not receive events is dropped when its buffer is full.
+	for i := 0; i < subscriberBufferSize+1; i++ {
+		b.Publish(EventTypeThreadCreated, []byte("{}"))
+	}
+	for range ch {
+	}
+
+	b.Unsubscribe(ch)
+}
+
+func TestBroker_Close(t *testing.T) {
+	b := NewBroker(4)
+	before, _ := b.Subscribe("")
+
+	b.Close()
+
+	after, replay := b.Subscribe("")
+	if len(replay) != 0 {
+		t.Errorf("replay = %v, want empty", replay)
+	}
+
+	for _, ch := range []<-chan *Event{before, after} {
+		if _, ok := <-ch; ok {
+			t.Error("channel has not been closed")
+		}
+	}
+
+	// the closed subscriber can be unsubscribed by the stream.
+	b.Unsubscribe(before)
+}
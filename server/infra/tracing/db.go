@@ -0,0 +1,131 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// dbSystem is the value of db.system attribute.
+const dbSystem = "mysql"
+
+// startQuery starts the span of the SQL operation with the statement.
+// The arguments are not recorded, because they can contain credentials.
+// The span is not recorded outside of traces, e.g. in the probes and the workers, so that they do not make the traces of their own.
+func startQuery(ctx context.Context, operation, q string) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+
+	return Start(ctx, "db."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", dbSystem),
+			attribute.String("db.operation", operation),
+			attribute.String("db.statement", q),
+		),
+	)
+}
+
+// sqlManager is the decorator of query.SQLManager which records a span per SQL operation.
+// The operations without context are not recorded, because their spans could not have parents.
+type sqlManager struct {
+	next query.SQLManager
+}
+
+// Exec executes SQL.
+func (m *sqlManager) Exec(q string, args ...interface{}) (sql.Result, error) {
+	return m.next.Exec(q, args...)
+}
+
+// ExecContext executes SQL with context.
+func (m *sqlManager) ExecContext(ctx context.Context, q string, args ...interface{}) (res sql.Result, err error) {
+	ctx, span := startQuery(ctx, "exec", q)
+	defer func() { End(span, err) }()
+	return m.next.ExecContext(ctx, q, args...)
+}
+
+// Query executes query which return row.
+func (m *sqlManager) Query(q string, args ...interface{}) (*sql.Rows, error) {
+	return m.next.Query(q, args...)
+}
+
+// QueryContext executes query which return row with context.
+func (m *sqlManager) QueryContext(ctx context.Context, q string, args ...interface{}) (rows *sql.Rows, err error) {
+	ctx, span := startQuery(ctx, "query", q)
+	defer func() { End(span, err) }()
+	return m.next.QueryContext(ctx, q, args...)
+}
+
+// Prepare prepares statement for Query and Exec later.
+func (m *sqlManager) Prepare(q string) (*sql.Stmt, error) {
+	return m.next.Prepare(q)
+}
+
+// PrepareContext prepares statement for Query and Exec later with context.
+func (m *sqlManager) PrepareContext(ctx context.Context, q string) (stmt *sql.Stmt, err error) {
+	ctx, span := startQuery(ctx, "prepare", q)
+	defer func() { End(span, err) }()
+	return m.next.PrepareContext(ctx, q)
+}
+
+// dbManager is the decorator of query.DBManager which records a span per SQL operation.
+type dbManager struct {
+	sqlManager
+	next query.DBManager
+}
+
+// NewDBManager generates and returns query.DBManager which records the spans of the SQL operations of m and its tx.
+func NewDBManager(m query.DBManager) query.DBManager {
+	return &dbManager{
+		sqlManager: sqlManager{next: m},
+		next:       m,
+	}
+}
+
+// Begin begins tx whose SQL operations are recorded.
+func (m *dbManager) Begin() (query.TxManager, error) {
+	tx, err := m.next.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	return &txManager{
+		sqlManager: sqlManager{next: tx},
+		next:       tx,
+	}, nil
+}
+
+// PingContext verifies the connection to the database is alive.
+func (m *dbManager) PingContext(ctx context.Context) error {
+	return m.next.PingContext(ctx)
+}
+
+// Stats returns the statistics of the connection pool.
+func (m *dbManager) Stats() sql.DBStats {
+	return m.next.Stats()
+}
+
+// Close closes the connection pool.
+func (m *dbManager) Close() error {
+	return m.next.Close()
+}
+
+// txManager is the decorator of query.TxManager which records a span per SQL operation.
+type txManager struct {
+	sqlManager
+	next query.TxManager
+}
+
+// Commit commits the tx.
+func (m *txManager) Commit() error {
+	return m.next.Commit()
+}
+
+// Rollback rollbacks the tx.
+func (m *txManager) Rollback() error {
+	return m.next.Rollback()
+}
@@ -0,0 +1,105 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	mock_query "github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// setRecorderForTest sets the global tracer provider which records the spans, and returns the recorder.
+func setRecorderForTest() *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	return recorder
+}
+
+// attributeOfForTest returns the value of the attribute of the span.
+func attributeOfForTest(span sdktrace.ReadOnlySpan, key attribute.Key) string {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestDBManager(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recorder := setRecorderForTest()
+
+	q := "SELECT id FROM threads WHERE id=?"
+
+	m := mock_query.NewMockDBManager(ctrl)
+	tx := mock_query.NewMockTxManager(ctrl)
+	m.EXPECT().Begin().Return(tx, nil)
+	m.EXPECT().QueryContext(gomock.Any(), q, 1).Return(nil, nil).Times(2)
+	tx.EXPECT().PrepareContext(gomock.Any(), q).Return(nil, errors.New("failed"))
+	tx.EXPECT().Commit().Return(nil)
+
+	dbm := NewDBManager(m)
+
+	// the operation outside of traces is not recorded.
+	if _, err := dbm.QueryContext(context.Background(), q, 1); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(recorder.Ended()); got != 0 {
+		t.Fatalf("the number of spans outside of traces = %d, want 0", got)
+	}
+
+	ctx, parent := Start(context.Background(), "parent")
+
+	if _, err := dbm.QueryContext(ctx, q, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	txm, err := dbm.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := txm.PrepareContext(ctx, q); err == nil {
+		t.Fatal("PrepareContext() error = nil, want error")
+	}
+	if err := txm.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("the number of spans = %d, want 3", len(spans))
+	}
+
+	tests := []struct {
+		name       string
+		wantStatus codes.Code
+	}{
+		{name: "db.query", wantStatus: codes.Unset},
+		{name: "db.prepare", wantStatus: codes.Error},
+	}
+	for i, tt := range tests {
+		span := spans[i]
+		if span.Name() != tt.name {
+			t.Errorf("span %d: name = %s, want %s", i, span.Name(), tt.name)
+		}
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("%s: parent = %s, want %s", tt.name, span.Parent().SpanID(), parent.SpanContext().SpanID())
+		}
+		if got := attributeOfForTest(span, "db.statement"); got != q {
+			t.Errorf("%s: db.statement = %s, want %s", tt.name, got, q)
+		}
+		if span.Status().Code != tt.wantStatus {
+			t.Errorf("%s: status = %v, want %v", tt.name, span.Status().Code, tt.wantStatus)
+		}
+	}
+}
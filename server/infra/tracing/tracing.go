@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the name of the tracer of the server.
+const instrumentationName = "github.com/sekky0905/nuxt-vue-go-chat/server"
+
+// Start starts the span named name as the child of the span in ctx.
+// The span does nothing until Setup is called.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// End records err on the span if it is not nil, and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Setup sets up the global tracer provider which exports the spans to the exporter of c,
+// and returns the function which flushes the spans and stops the provider.
+// The spans are written as JSON lines, so that they can be read without a collector.
+func Setup(c config.Tracing) (shutdown func(ctx context.Context) error, err error) {
+	if !c.Enabled() {
+		return func(ctx context.Context) error { return nil }, nil
+	}
+
+	var w io.Writer = os.Stdout
+	var file *os.File
+	if c.Exporter == config.ExporterFile {
+		file, err = os.OpenFile(c.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to open file of traces")
+		}
+		w = file
+	}
+
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+	if err != nil {
+		if file != nil {
+			if closeErr := file.Close(); closeErr != nil {
+				err = errors.Wrapf(err, "failed to close file of traces, %s", closeErr.Error())
+			}
+		}
+		return nil, errors.Wrap(err, "failed to create exporter")
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", c.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return func(ctx context.Context) error {
+		if err := provider.Shutdown(ctx); err != nil {
+			return errors.Wrap(err, "failed to shut down tracer provider")
+		}
+		if file != nil {
+			if err := file.Close(); err != nil {
+				return errors.Wrap(err, "failed to close file of traces")
+			}
+		}
+		return nil
+	}, nil
+}
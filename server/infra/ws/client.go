@@ -0,0 +1,130 @@
+package ws
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// writeWait is time allowed to write a message to the peer.
+	writeWait = 10 * time.Second
+	// pongWait is time allowed to read the next pong message from the peer.
+	pongWait = 60 * time.Second
+	// pingPeriod is period to send pings to peer. Must be less than pongWait.
+	pingPeriod = (pongWait * 9) / 10
+	// maxMessageSize is maximum message size allowed from peer.
+	maxMessageSize = 512
+	// sendBufferSize is size of the send buffer of each client.
+	sendBufferSize = 256
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// Client is a middleman between the websocket connection and the hub.
+// userID and sessionID are who connects, so that the connection is closed when the user is banned or logs out.
+// sessionID is empty when the user is authenticated by API token.
+type Client struct {
+	hub       *Hub
+	conn      *websocket.Conn
+	threadID  uint32
+	userID    uint32
+	sessionID string
+	send      chan []byte
+}
+
+// Serve upgrades the HTTP connection to the websocket and subscribes it to the thread.
+func Serve(hub *Hub, w http.ResponseWriter, r *http.Request, threadID, userID uint32, sessionID string) error {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to upgrade connection")
+	}
+
+	c := &Client{
+		hub:       hub,
+		conn:      conn,
+		threadID:  threadID,
+		userID:    userID,
+		sessionID: sessionID,
+		send:      make(chan []byte, sendBufferSize),
+	}
+	hub.register(c)
+
+	go c.writePump()
+	go c.readPump()
+
+	return nil
+}
+
+// readPump reads messages from the connection to handle pong and close.
+// Messages from clients are discarded because comments are posted via REST API.
+func (c *Client) readPump() {
+	defer func() {
+		c.hub.unregister(c)
+		if err := c.conn.Close(); err != nil {
+			logger.Logger.Debug("conn.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	if err := c.conn.SetReadDeadline(time.Now().Add(pongWait)); err != nil {
+		return
+	}
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				logger.Logger.Warn("unexpected close", zap.String("error message", err.Error()))
+			}
+			return
+		}
+	}
+}
+
+// writePump writes messages from the hub and pings to the connection.
+func (c *Client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		if err := c.conn.Close(); err != nil {
+			logger.Logger.Debug("conn.Close", zap.String("error message", err.Error()))
+		}
+	}()
+
+	for {
+		select {
+		case msg, ok := <-c.send:
+			if err := c.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+				return
+			}
+			if !ok {
+				// the hub closed the channel.
+				if err := c.conn.WriteMessage(websocket.CloseMessage, []byte{}); err != nil {
+					logger.Logger.Debug("conn.WriteMessage", zap.String("error message", err.Error()))
+				}
+				return
+			}
+
+			if err := c.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := c.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/event"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+)
+
+// MessageType is type of message which is sent to clients.
+type MessageType string
+
+// types of message.
+const (
+	MessageTypeCommentCreated  MessageType = "comment.created"
+	MessageTypeCommentUpdated  MessageType = "comment.updated"
+	MessageTypeCommentDeleted  MessageType = "comment.deleted"
+	MessageTypeCommentRestored MessageType = "comment.restored"
+)
+
+// Message is the message which is sent to clients.
+type Message struct {
+	Type    MessageType    `json:"type"`
+	Comment *model.Comment `json:"comment"`
+}
+
+// Hub holds clients subscribing each thread and broadcasts messages to them.
+type Hub struct {
+	mu      sync.RWMutex
+	threads map[uint32]map[*Client]bool
+	closed  bool
+}
+
+// NewHub generates and returns Hub.
+func NewHub() *Hub {
+	return &Hub{
+		threads: make(map[uint32]map[*Client]bool),
+	}
+}
+
+// register registers the client to the thread.
+// After the hub is closed, the client is closed at once.
+func (h *Hub) register(c *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		close(c.send)
+		return
+	}
+
+	clients, ok := h.threads[c.threadID]
+	if !ok {
+		clients = make(map[*Client]bool)
+		h.threads[c.threadID] = clients
+	}
+	clients[c] = true
+}
+
+// unregister removes the client from the thread and closes its send buffer.
+func (h *Hub) unregister(c *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.remove(c)
+}
+
+// remove removes the client. The caller must hold the write lock.
+func (h *Hub) remove(c *Client) {
+	clients, ok := h.threads[c.threadID]
+	if !ok {
+		return
+	}
+
+	if _, ok := clients[c]; !ok {
+		return
+	}
+
+	delete(clients, c)
+	close(c.send)
+
+	if len(clients) == 0 {
+		delete(h.threads, c.threadID)
+	}
+}
+
+// broadcast sends the message to all clients subscribing the thread.
+// Clients whose send buffer is full are evicted as slow consumers.
+func (h *Hub) broadcast(threadID uint32, msg []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for c := range h.threads[threadID] {
+		select {
+		case c.send <- msg:
+		default:
+			logger.Logger.Warn("evict slow consumer", zap.Uint32("threadID", threadID))
+			h.remove(c)
+		}
+	}
+}
+
+// Close closes all clients, which are sent the close message, and the clients which connect later.
+// The connections are hijacked from the HTTP server, so that they are not closed by shutting down it.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for _, clients := range h.threads {
+		for c := range clients {
+			h.remove(c)
+		}
+	}
+}
+
+// disconnect closes the clients which match, which are sent the close message.
+func (h *Hub) disconnect(match func(c *Client) bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, clients := range h.threads {
+		for c := range clients {
+			if match(c) {
+				h.remove(c)
+			}
+		}
+	}
+}
+
+// SubscriberCount returns the number of clients subscribing the thread.
+func (h *Hub) SubscriberCount(threadID uint32) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.threads[threadID])
+}
+
+// notify encodes the comment as the message and broadcasts it.
+func (h *Hub) notify(msgType MessageType, comment *model.Comment) {
+	if comment == nil {
+		return
+	}
+
+	msg, err := json.Marshal(&Message{
+		Type:    msgType,
+		Comment: comment,
+	})
+	if err != nil {
+		logger.Logger.Error("failed to marshal message", zap.String("error message", err.Error()))
+		return
+	}
+
+	h.broadcast(comment.ThreadID, msg)
+}
+
+// Listen subscribes events of comments to broadcast them,
+// and events of users to disconnect the clients of the banned users and the sessions which have logged out.
+func (h *Hub) Listen(s event.Subscriber) {
+	s.Subscribe(event.NameCommentCreated, h.handle)
+	s.Subscribe(event.NameCommentUpdated, h.handle)
+	s.Subscribe(event.NameCommentDeleted, h.handle)
+	s.Subscribe(event.NameCommentRestored, h.handle)
+	s.Subscribe(event.NameUserBanned, h.handle)
+	s.Subscribe(event.NameLoggedOut, h.handle)
+}
+
+// handle broadcasts the event to clients subscribing the thread of the comment,
+// or disconnects the clients of the user or the session.
+func (h *Hub) handle(ctx context.Context, e event.Event) {
+	switch e := e.(type) {
+	case *event.CommentCreated:
+		h.notify(MessageTypeCommentCreated, e.Comment)
+	case *event.CommentUpdated:
+		h.notify(MessageTypeCommentUpdated, e.Comment)
+	case *event.CommentDeleted:
+		h.notify(MessageTypeCommentDeleted, e.Comment)
+	case *event.CommentRestored:
+		h.notify(MessageTypeCommentRestored, e.Comment)
+	case *event.UserBanned:
+		h.disconnect(func(c *Client) bool {
+			return c.userID == e.UserID
+		})
+	case *event.LoggedOut:
+		h.disconnect(func(c *Client) bool {
+			return e.SessionID != "" && c.sessionID == e.SessionID
+		})
+	}
+}
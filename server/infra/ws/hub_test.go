@@ -0,0 +1,228 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/event"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/eventbus"
+)
+
+func TestHub_Listen(t *testing.T) {
+	comment := &model.Comment{
+		ID:       model.CommentValidIDForTest,
+		ThreadID: model.ThreadValidIDForTest,
+		User: &model.User{
+			ID:   model.UserValidIDForTest,
+			Name: model.UserNameForTest,
+		},
+		Content: model.CommentContentForTest,
+	}
+
+	tests := []struct {
+		name       string
+		threadID   uint32
+		wantNotify bool
+	}{
+		{
+			name:       "When the client subscribes the thread of the comment, the client receives the message",
+			threadID:   model.ThreadValidIDForTest,
+			wantNotify: true,
+		},
+		{
+			name:       "When the client subscribes other thread, the client does not receive the message",
+			threadID:   model.ThreadInValidIDForTest,
+			wantNotify: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHub()
+			c := &Client{
+				hub:      h,
+				threadID: tt.threadID,
+				send:     make(chan []byte, sendBufferSize),
+			}
+			h.register(c)
+
+			bus := eventbus.NewMemoryBus()
+			h.Listen(bus)
+
+			if err := bus.Publish(context.Background(), &event.CommentCreated{Comment: comment}); err != nil {
+				t.Fatal(err)
+			}
+
+			select {
+			case b := <-c.send:
+				if !tt.wantNotify {
+					t.Fatalf("unexpected message = %s", b)
+				}
+
+				got := &Message{}
+				if err := json.Unmarshal(b, got); err != nil {
+					t.Fatal(err)
+				}
+				want := &Message{
+					Type:    MessageTypeCommentCreated,
+					Comment: comment,
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("message = %+v, want %+v", got, want)
+				}
+			default:
+				if tt.wantNotify {
+					t.Error("message has not been sent")
+				}
+			}
+		})
+	}
+}
+
+func TestHub_broadcast(t *testing.T) {
+	tests := []struct {
+		name          string
+		bufferSize    int
+		wantEvicted   bool
+		wantSubscribe int
+	}{
+		{
+			name:          "When the send buffer has space, the client keeps subscribing",
+			bufferSize:    1,
+			wantEvicted:   false,
+			wantSubscribe: 1,
+		},
+		{
+			name:          "When the send buffer is full, the client is evicted",
+			bufferSize:    0,
+			wantEvicted:   true,
+			wantSubscribe: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHub()
+			c := &Client{
+				hub:      h,
+				threadID: model.ThreadValidIDForTest,
+				send:     make(chan []byte, tt.bufferSize),
+			}
+			h.register(c)
+
+			h.broadcast(model.ThreadValidIDForTest, []byte("test"))
+
+			if got := h.SubscriberCount(model.ThreadValidIDForTest); got != tt.wantSubscribe {
+				t.Errorf("SubscriberCount() = %d, want %d", got, tt.wantSubscribe)
+			}
+
+			if tt.wantEvicted {
+				if _, ok := <-c.send; ok {
+					t.Error("send buffer has not been closed")
+				}
+			}
+		})
+	}
+}
+
+func TestHub_Close(t *testing.T) {
+	h := NewHub()
+	before := &Client{
+		hub:      h,
+		threadID: model.ThreadValidIDForTest,
+		send:     make(chan []byte, 1),
+	}
+	h.register(before)
+
+	h.Close()
+
+	after := &Client{
+		hub:      h,
+		threadID: model.ThreadValidIDForTest,
+		send:     make(chan []byte, 1),
+	}
+	h.register(after)
+
+	for _, c := range []*Client{before, after} {
+		if _, ok := <-c.send; ok {
+			t.Error("send buffer has not been closed")
+		}
+	}
+
+	if got := h.SubscriberCount(model.ThreadValidIDForTest); got != 0 {
+		t.Errorf("SubscriberCount() = %d, want 0", got)
+	}
+}
+
+func TestHub_Listen_disconnect(t *testing.T) {
+	tests := []struct {
+		name           string
+		sessionID      string
+		event          event.Event
+		wantDisconnect bool
+	}{
+		{
+			name:           "When the user of the client is banned, the client is disconnected",
+			sessionID:      model.SessionValidIDForTest,
+			event:          &event.UserBanned{UserID: model.UserValidIDForTest},
+			wantDisconnect: true,
+		},
+		{
+			name:           "When other user is banned, the client keeps subscribing",
+			sessionID:      model.SessionValidIDForTest,
+			event:          &event.UserBanned{UserID: model.UserInValidIDForTest},
+			wantDisconnect: false,
+		},
+		{
+			name:           "When the session of the client logs out, the client is disconnected",
+			sessionID:      model.SessionValidIDForTest,
+			event:          &event.LoggedOut{SessionID: model.SessionValidIDForTest},
+			wantDisconnect: true,
+		},
+		{
+			name:           "When other session logs out, the client keeps subscribing",
+			sessionID:      model.SessionValidIDForTest,
+			event:          &event.LoggedOut{SessionID: model.SessionInValidIDForTest},
+			wantDisconnect: false,
+		},
+		{
+			name:           "When the client is authenticated by API token, logging out of a session does not disconnect it",
+			sessionID:      "",
+			event:          &event.LoggedOut{SessionID: ""},
+			wantDisconnect: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHub()
+			c := &Client{
+				hub:       h,
+				threadID:  model.ThreadValidIDForTest,
+				userID:    model.UserValidIDForTest,
+				sessionID: tt.sessionID,
+				send:      make(chan []byte, 1),
+			}
+			h.register(c)
+
+			bus := eventbus.NewMemoryBus()
+			h.Listen(bus)
+
+			if err := bus.Publish(context.Background(), tt.event); err != nil {
+				t.Fatal(err)
+			}
+
+			want := 1
+			if tt.wantDisconnect {
+				want = 0
+				if _, ok := <-c.send; ok {
+					t.Error("send buffer has not been closed")
+				}
+			}
+
+			if got := h.SubscriberCount(model.ThreadValidIDForTest); got != want {
+				t.Errorf("SubscriberCount() = %d, want %d", got, want)
+			}
+		})
+	}
+}
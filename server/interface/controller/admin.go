@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/application"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+)
+
+// AdminController is the interface of AdminController.
+type AdminController interface {
+	InitAdminAPI(g *gin.RouterGroup)
+	ListUsers(g *gin.Context)
+	ChangeRole(g *gin.Context)
+	BanUser(g *gin.Context)
+	UnbanUser(g *gin.Context)
+	RequestPasswordReset(g *gin.Context)
+}
+
+// adminController is the controller of administration.
+type adminController struct {
+	aApp application.AdminService
+	pApp application.PasswordService
+}
+
+// NewAdminController generates and returns AdminController.
+func NewAdminController(aApp application.AdminService, pApp application.PasswordService) AdminController {
+	return &adminController{
+		aApp: aApp,
+		pApp: pApp,
+	}
+}
+
+// InitAdminAPI initialize Admin API.
+func (c *adminController) InitAdminAPI(g *gin.RouterGroup) {
+	g.GET("/users", c.ListUsers)
+	g.PUT("/users/:id/role", c.ChangeRole)
+	g.PUT("/users/:id/ban", c.BanUser)
+	g.DELETE("/users/:id/ban", c.UnbanUser)
+	g.POST("/users/:id/passwordReset", c.RequestPasswordReset)
+}
+
+// ListUsers gets UserList.
+func (c *adminController) ListUsers(g *gin.Context) {
+	limit, err := strconv.Atoi(g.Query("limit"))
+	if err != nil {
+		limit = defaultLimit
+	}
+
+	cursorInt, err := strconv.Atoi(g.Query("cursor"))
+	if err != nil {
+		cursorInt = defaultCursor
+	}
+
+	cursor := uint32(cursorInt)
+
+	ctx := g.Request.Context()
+	list, err := c.aApp.ListUsers(ctx, limit, cursor)
+	if err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to list users"))
+		return
+	}
+
+	g.JSON(http.StatusOK, TranslateFromUserListToAdminUserListDTO(list))
+}
+
+// ChangeRole changes the role of the User.
+func (c *adminController) ChangeRole(g *gin.Context) {
+	dto := &RoleDTO{}
+	if err := g.BindJSON(dto); err != nil {
+		err = handleValidatorErr(err)
+		ResponseAndLogError(g, errors.Wrap(err, "failed to bind json"))
+		return
+	}
+
+	id, err := idParam(g)
+	if err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to change id from string to int"))
+		return
+	}
+
+	ctx := g.Request.Context()
+	user, err := c.aApp.ChangeRole(ctx, id, model.Role(dto.Role))
+	if err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to change role"))
+		return
+	}
+
+	g.JSON(http.StatusOK, TranslateFromUserToAdminUserDTO(user))
+}
+
+// BanUser bans the User.
+func (c *adminController) BanUser(g *gin.Context) {
+	id, err := idParam(g)
+	if err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to change id from string to int"))
+		return
+	}
+
+	ctx := g.Request.Context()
+	user, err := c.aApp.BanUser(ctx, id)
+	if err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to ban user"))
+		return
+	}
+
+	g.JSON(http.StatusOK, TranslateFromUserToAdminUserDTO(user))
+}
+
+// UnbanUser unbans the User.
+func (c *adminController) UnbanUser(g *gin.Context) {
+	id, err := idParam(g)
+	if err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to change id from string to int"))
+		return
+	}
+
+	ctx := g.Request.Context()
+	user, err := c.aApp.UnbanUser(ctx, id)
+	if err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to unban user"))
+		return
+	}
+
+	g.JSON(http.StatusOK, TranslateFromUserToAdminUserDTO(user))
+}
+
+// RequestPasswordReset issues the password reset token of the User, which is delivered to the User.
+func (c *adminController) RequestPasswordReset(g *gin.Context) {
+	id, err := idParam(g)
+	if err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to change id from string to int"))
+		return
+	}
+
+	ctx := g.Request.Context()
+	if err := c.pApp.RequestPasswordReset(ctx, id); err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to request password reset"))
+		return
+	}
+
+	g.JSON(http.StatusOK, nil)
+}
+
+// idParam returns the id of the path parameter.
+func idParam(g *gin.Context) (uint32, error) {
+	idInt, err := strconv.Atoi(g.Param("id"))
+	if err != nil {
+		err = &model.InvalidParamError{
+			BaseErr:       err,
+			PropertyName:  model.IDProperty,
+			PropertyValue: g.Param("id"),
+		}
+		return 0, err
+	}
+
+	return uint32(idInt), nil
+}
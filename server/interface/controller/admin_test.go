@@ -0,0 +1,391 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/application"
+	mock_application "github.com/sekky0905/nuxt-vue-go-chat/server/application/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/testutil"
+)
+
+func Test_adminController_ListUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		aApp application.AdminService
+	}
+
+	type errBody struct {
+		errCode ErrCode
+	}
+
+	type want struct {
+		statusCode int
+		body       *AdminUserListDTO
+		errBody
+	}
+
+	type mockReturns struct {
+		list *model.UserList
+		err  error
+	}
+
+	list := &model.UserList{
+		Users:   testutil.GenerateUserHelper(1, 2),
+		HasNext: false,
+		Cursor:  0,
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		mockReturns
+		want
+	}{
+		{
+			name: "When data exists, returns users without password and status code 200",
+			fields: fields{
+				aApp: mock_application.NewMockAdminService(ctrl),
+			},
+			mockReturns: mockReturns{
+				list: list,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       TranslateFromUserListToAdminUserListDTO(list),
+			},
+		},
+		{
+			name: "When some error occurs, returns status code 500",
+			fields: fields{
+				aApp: mock_application.NewMockAdminService(ctrl),
+			},
+			mockReturns: mockReturns{
+				err: &model.RepositoryError{},
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				errBody: errBody{
+					errCode: InternalDBFailure,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aa, ok := tt.fields.aApp.(*mock_application.MockAdminService)
+			if !ok {
+				t.Fatal("failed to assert MockAdminService")
+			}
+
+			aa.EXPECT().ListUsers(context.Background(), defaultLimit, uint32(defaultCursor)).Return(tt.mockReturns.list, tt.mockReturns.err)
+
+			ac := NewAdminController(tt.fields.aApp, mock_application.NewMockPasswordService(ctrl))
+			r := gin.New()
+			r.GET("/admin/users", ac.ListUsers)
+
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/admin/users", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want.statusCode {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.want.statusCode)
+				return
+			}
+
+			if tt.want.errBody.errCode == "" {
+				if strings.Contains(rec.Body.String(), "password") {
+					t.Errorf("body = %s, must not contain password", rec.Body.String())
+					return
+				}
+
+				got := &AdminUserListDTO{}
+				if err := json.Unmarshal(rec.Body.Bytes(), got); err != nil {
+					t.Fatal(err)
+				}
+
+				if !reflect.DeepEqual(got, tt.want.body) {
+					t.Errorf("body = %#v, want %#v", got, tt.want.body)
+				}
+			} else {
+				sBody := rec.Body.String()
+				if !strings.Contains(sBody, string(tt.want.errBody.errCode)) {
+					t.Errorf("body = %#v, want %#v", sBody, tt.want.errBody.errCode)
+				}
+			}
+		})
+	}
+}
+
+func Test_adminController_ChangeRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		aApp application.AdminService
+	}
+
+	type parameter struct {
+		id string
+	}
+
+	type args struct {
+		id   uint32
+		role *RoleDTO
+	}
+
+	type errBody struct {
+		errCode ErrCode
+	}
+
+	type want struct {
+		statusCode int
+		errBody
+	}
+
+	type mockReturns struct {
+		user *model.User
+		err  error
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		parameter
+		args
+		mockReturns
+		want
+	}{
+		{
+			name: "When appropriate role is given, returns user and status code 200",
+			fields: fields{
+				aApp: mock_application.NewMockAdminService(ctrl),
+			},
+			parameter: parameter{
+				id: "2",
+			},
+			args: args{
+				id:   model.UserInValidIDForTest,
+				role: &RoleDTO{Role: model.RoleModerator.String()},
+			},
+			mockReturns: mockReturns{
+				user: &model.User{ID: model.UserInValidIDForTest, Role: model.RoleModerator},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name: "When undefined role is given, returns status code 400",
+			fields: fields{
+				aApp: mock_application.NewMockAdminService(ctrl),
+			},
+			parameter: parameter{
+				id: "2",
+			},
+			args: args{
+				id:   model.UserInValidIDForTest,
+				role: &RoleDTO{Role: "root"},
+			},
+			mockReturns: mockReturns{
+				err: &model.InvalidParamError{
+					PropertyName:  model.RoleProperty,
+					PropertyValue: "root",
+				},
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errBody: errBody{
+					errCode: InvalidParameterValueFailure,
+				},
+			},
+		},
+		{
+			name: "When inappropriate id is given, returns status code 400",
+			fields: fields{
+				aApp: mock_application.NewMockAdminService(ctrl),
+			},
+			parameter: parameter{
+				id: "a",
+			},
+			args: args{
+				role: &RoleDTO{Role: model.RoleModerator.String()},
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				errBody: errBody{
+					errCode: InvalidParameterValueFailure,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aa, ok := tt.fields.aApp.(*mock_application.MockAdminService)
+			if !ok {
+				t.Fatal("failed to assert MockAdminService")
+			}
+
+			if tt.args.id != 0 {
+				aa.EXPECT().ChangeRole(context.Background(), tt.args.id, model.Role(tt.args.role.Role)).Return(tt.mockReturns.user, tt.mockReturns.err)
+			}
+
+			ac := NewAdminController(tt.fields.aApp, mock_application.NewMockPasswordService(ctrl))
+			r := gin.New()
+			r.PUT("/admin/users/:id/role", ac.ChangeRole)
+
+			b, err := json.Marshal(tt.args.role)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/admin/users/%s/role", tt.parameter.id), bytes.NewBuffer(b))
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want.statusCode {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.want.statusCode)
+				return
+			}
+
+			if tt.want.errBody.errCode != "" {
+				sBody := rec.Body.String()
+				if !strings.Contains(sBody, string(tt.want.errBody.errCode)) {
+					t.Errorf("body = %#v, want %#v", sBody, tt.want.errBody.errCode)
+				}
+			}
+		})
+	}
+}
+
+func Test_adminController_BanUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type fields struct {
+		aApp application.AdminService
+	}
+
+	type errBody struct {
+		errCode ErrCode
+	}
+
+	type want struct {
+		statusCode int
+		errBody
+	}
+
+	type mockReturns struct {
+		user *model.User
+		err  error
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		method string
+		mockReturns
+		want
+	}{
+		{
+			name: "When PUT is requested, bans the user and returns status code 200",
+			fields: fields{
+				aApp: mock_application.NewMockAdminService(ctrl),
+			},
+			method: http.MethodPut,
+			mockReturns: mockReturns{
+				user: &model.User{ID: model.UserInValidIDForTest, Banned: true},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name: "When DELETE is requested, unbans the user and returns status code 200",
+			fields: fields{
+				aApp: mock_application.NewMockAdminService(ctrl),
+			},
+			method: http.MethodDelete,
+			mockReturns: mockReturns{
+				user: &model.User{ID: model.UserInValidIDForTest},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name: "When the user does not exist, returns status code 404",
+			fields: fields{
+				aApp: mock_application.NewMockAdminService(ctrl),
+			},
+			method: http.MethodPut,
+			mockReturns: mockReturns{
+				err: &model.NoSuchDataError{},
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+				errBody: errBody{
+					errCode: NoSuchDataFailure,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aa, ok := tt.fields.aApp.(*mock_application.MockAdminService)
+			if !ok {
+				t.Fatal("failed to assert MockAdminService")
+			}
+
+			if tt.method == http.MethodPut {
+				aa.EXPECT().BanUser(context.Background(), model.UserInValidIDForTest).Return(tt.mockReturns.user, tt.mockReturns.err)
+			} else {
+				aa.EXPECT().UnbanUser(context.Background(), model.UserInValidIDForTest).Return(tt.mockReturns.user, tt.mockReturns.err)
+			}
+
+			ac := NewAdminController(tt.fields.aApp, mock_application.NewMockPasswordService(ctrl))
+			r := gin.New()
+			ac.InitAdminAPI(r.Group("/admin"))
+
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest(tt.method, "/admin/users/2/ban", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want.statusCode {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.want.statusCode)
+				return
+			}
+
+			if tt.want.errBody.errCode != "" {
+				sBody := rec.Body.String()
+				if !strings.Contains(sBody, string(tt.want.errBody.errCode)) {
+					t.Errorf("body = %#v, want %#v", sBody, tt.want.errBody.errCode)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/application"
+)
+
+// APITokenController is the interface of APITokenController.
+type APITokenController interface {
+	InitAPITokenAPI(g *gin.RouterGroup)
+	ListAPITokens(g *gin.Context)
+	CreateAPIToken(g *gin.Context)
+	RevokeAPIToken(g *gin.Context)
+}
+
+// apiTokenController is the controller of the API tokens of the user who requested.
+type apiTokenController struct {
+	atApp application.APITokenService
+}
+
+// NewAPITokenController generates and returns APITokenController.
+func NewAPITokenController(atApp application.APITokenService) APITokenController {
+	return &apiTokenController{
+		atApp: atApp,
+	}
+}
+
+// InitAPITokenAPI initialize API Token API.
+func (c *apiTokenController) InitAPITokenAPI(g *gin.RouterGroup) {
+	g.GET("", c.ListAPITokens)
+	g.POST("", c.CreateAPIToken)
+	g.DELETE("/:id", c.RevokeAPIToken)
+}
+
+// ListAPITokens lists the API tokens of the user who requested.
+func (c *apiTokenController) ListAPITokens(g *gin.Context) {
+	ctx := g.Request.Context()
+	tokens, err := c.atApp.ListAPITokens(ctx)
+	if err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to list api tokens"))
+		return
+	}
+
+	dtos := make([]*APITokenDTO, len(tokens), len(tokens))
+	for i, token := range tokens {
+		dtos[i] = TranslateFromAPITokenToAPITokenDTO(token, "")
+	}
+
+	g.JSON(http.StatusOK, dtos)
+}
+
+// CreateAPIToken mints the API token of the user who requested.
+// The token itself is included in the response only this time.
+func (c *apiTokenController) CreateAPIToken(g *gin.Context) {
+	dto := &CreateAPITokenDTO{}
+	if err := g.BindJSON(dto); err != nil {
+		err = handleValidatorErr(err)
+		ResponseAndLogError(g, errors.Wrap(err, "failed to bind json"))
+		return
+	}
+
+	ctx := g.Request.Context()
+	apiToken, token, err := c.atApp.CreateAPIToken(ctx, dto.Name)
+	if err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to create api token"))
+		return
+	}
+
+	g.JSON(http.StatusOK, TranslateFromAPITokenToAPITokenDTO(apiToken, token))
+}
+
+// RevokeAPIToken revokes the API token specified by id.
+func (c *apiTokenController) RevokeAPIToken(g *gin.Context) {
+	id, err := idParam(g)
+	if err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to get id"))
+		return
+	}
+
+	ctx := g.Request.Context()
+	if err := c.atApp.RevokeAPIToken(ctx, id); err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to revoke api token"))
+		return
+	}
+
+	g.JSON(http.StatusOK, nil)
+}
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mock_application "github.com/sekky0905/nuxt-vue-go-chat/server/application/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+)
+
+func Test_apiTokenController_CreateAPIToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiToken := &model.APIToken{
+		ID:        1,
+		UserID:    model.UserValidIDForTest,
+		Name:      "cli",
+		TokenHash: "hash",
+	}
+
+	ata := mock_application.NewMockAPITokenService(ctrl)
+	ata.EXPECT().CreateAPIToken(gomock.Any(), "cli").Return(apiToken, "nvgc_token", nil)
+
+	atc := NewAPITokenController(ata)
+	r := gin.New()
+	atc.InitAPITokenAPI(r.Group("/apiTokens"))
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodPost, "/apiTokens", strings.NewReader(`{"name":"cli"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code = %v, want %v", rec.Code, http.StatusOK)
+	}
+
+	if strings.Contains(rec.Body.String(), apiToken.TokenHash) {
+		t.Errorf("body = %s, must not contain the token hash", rec.Body.String())
+	}
+
+	got := &APITokenDTO{}
+	if err := json.Unmarshal(rec.Body.Bytes(), got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != apiToken.ID || got.Name != apiToken.Name || got.Token != "nvgc_token" {
+		t.Errorf("body = %#v, want the token", got)
+	}
+}
+
+func Test_apiTokenController_ListAPITokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ata := mock_application.NewMockAPITokenService(ctrl)
+	ata.EXPECT().ListAPITokens(gomock.Any()).Return([]*model.APIToken{{ID: 1, Name: "cli", TokenHash: "hash"}}, nil)
+
+	atc := NewAPITokenController(ata)
+	r := gin.New()
+	atc.InitAPITokenAPI(r.Group("/apiTokens"))
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/apiTokens", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code = %v, want %v", rec.Code, http.StatusOK)
+	}
+
+	if strings.Contains(rec.Body.String(), "hash") || strings.Contains(rec.Body.String(), `"token"`) {
+		t.Errorf("body = %s, must not contain tokens", rec.Body.String())
+	}
+}
+
+func Test_apiTokenController_RevokeAPIToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		path       string
+		err        error
+		expectCall bool
+		statusCode int
+	}{
+		{
+			name:       "When the token is revoked, returns status code 200",
+			path:       "/apiTokens/1",
+			expectCall: true,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "When the token is not found, returns status code 404",
+			path:       "/apiTokens/1",
+			err:        &model.NoSuchDataError{},
+			expectCall: true,
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "When inappropriate id is given, returns status code 400",
+			path:       "/apiTokens/abc",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ata := mock_application.NewMockAPITokenService(ctrl)
+			if tt.expectCall {
+				ata.EXPECT().RevokeAPIToken(gomock.Any(), uint32(1)).Return(tt.err)
+			}
+
+			atc := NewAPITokenController(ata)
+			r := gin.New()
+			atc.InitAPITokenAPI(r.Group("/apiTokens"))
+
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodDelete, tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.statusCode {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
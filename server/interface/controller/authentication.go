@@ -13,6 +13,7 @@ import (
 // AuthenticationController is the interface of AuthenticationController.
 type AuthenticationController interface {
 	InitAuthenticationAPI(g *gin.RouterGroup)
+	InitLogoutAPI(g *gin.RouterGroup)
 	SignUp(g *gin.Context)
 	Login(g *gin.Context)
 	Logout(g *gin.Context)
@@ -20,13 +21,17 @@ type AuthenticationController interface {
 
 // authenticationController is the controller of authentication.
 type authenticationController struct {
-	aApp application.AuthenticationService
+	aApp   application.AuthenticationService
+	expiry model.SessionExpiry
+	cookie CookieConfig
 }
 
 // NewAuthenticationController generates and returns AuthenticationController.
-func NewAuthenticationController(uAPP application.AuthenticationService) AuthenticationController {
+func NewAuthenticationController(uAPP application.AuthenticationService, expiry model.SessionExpiry, cookie CookieConfig) AuthenticationController {
 	return &authenticationController{
-		aApp: uAPP,
+		aApp:   uAPP,
+		expiry: expiry,
+		cookie: cookie,
 	}
 }
 
@@ -34,6 +39,12 @@ func NewAuthenticationController(uAPP application.AuthenticationService) Authent
 func (c *authenticationController) InitAuthenticationAPI(g *gin.RouterGroup) {
 	g.POST("/signUp", c.SignUp)
 	g.POST("/login", c.Login)
+}
+
+// InitLogoutAPI initialize Logout API.
+// It must be added to the group which checks the authentication and the CSRF token,
+// because the browsers send the session cookie of the request from other sites.
+func (c *authenticationController) InitLogoutAPI(g *gin.RouterGroup) {
 	g.DELETE("/logout", c.Logout)
 }
 
@@ -46,14 +57,17 @@ func (c *authenticationController) SignUp(g *gin.Context) {
 		return
 	}
 
-	ctx := g.Request.Context()
+	ctx := clientContext(g)
 	user, err := c.aApp.SignUp(ctx, param)
 	if err != nil {
 		ResponseAndLogError(g, errors.Wrap(err, "failed to sign up"))
 		return
 	}
 
-	g.SetCookie(model.SessionIDAtCookie, user.SessionID, 86400, "/", "", false, true)
+	if err := c.setCookies(g, user.SessionID); err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to set cookies"))
+		return
+	}
 
 	uDTO := TranslateFromUserToUserDTO(user)
 	g.JSON(http.StatusOK, uDTO)
@@ -68,14 +82,17 @@ func (c *authenticationController) Login(g *gin.Context) {
 		return
 	}
 
-	ctx := g.Request.Context()
+	ctx := clientContext(g)
 	user, err := c.aApp.Login(ctx, param)
 	if err != nil {
 		ResponseAndLogError(g, errors.Wrap(err, "failed to sign up"))
 		return
 	}
 
-	g.SetCookie(model.SessionIDAtCookie, user.SessionID, 86400, "/", "", false, true)
+	if err := c.setCookies(g, user.SessionID); err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to set cookies"))
+		return
+	}
 
 	uDTO := TranslateFromUserToUserDTO(user)
 	g.JSON(http.StatusOK, uDTO)
@@ -85,7 +102,7 @@ func (c *authenticationController) Login(g *gin.Context) {
 func (c *authenticationController) Logout(g *gin.Context) {
 	sessionID, err := g.Cookie(model.SessionIDAtCookie)
 	if err != nil && err != http.ErrNoCookie {
-		logger.Logger.Warn("failed to read session from Cookie")
+		logger.FromContext(g.Request.Context()).Warn("failed to read session from Cookie")
 		return
 	}
 
@@ -95,8 +112,17 @@ func (c *authenticationController) Logout(g *gin.Context) {
 		return
 	}
 
-	// empty cookie
-	g.SetCookie(model.SessionIDAtCookie, "", 0, "", "", false, true)
+	ClearSessionCookie(g, c.cookie)
 
 	g.JSON(http.StatusOK, nil)
 }
+
+// setCookies sets the session cookie and the CSRF token cookie which is verified with the session.
+func (c *authenticationController) setCookies(g *gin.Context, sessionID string) error {
+	if _, err := SetCSRFCookie(g, c.cookie); err != nil {
+		return err
+	}
+
+	SetSessionCookie(g, c.cookie, sessionID, c.expiry)
+	return nil
+}
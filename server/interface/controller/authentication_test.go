@@ -76,7 +76,7 @@ func Test_authenticationController_SignUp(t *testing.T) {
 			},
 			want: want{
 				statusCode: http.StatusOK,
-				cookie:     "SESSION_ID=testValidSessionID12345678; Path=/; Max-Age=86400; HttpOnly",
+				cookie:     "SESSION_ID=testValidSessionID12345678; Path=/; Max-Age=86400; HttpOnly; SameSite=Lax",
 				body: &UserDTO{
 					ID:        model.UserValidIDForTest,
 					Name:      model.UserNameForTest,
@@ -192,10 +192,10 @@ func Test_authenticationController_SignUp(t *testing.T) {
 			}
 
 			if tt.want.statusCode != http.StatusBadRequest {
-				aa.EXPECT().SignUp(tt.mockArgs.ctx, tt.mockArgs.user).Return(tt.mockReturns.user, tt.mockReturns.err)
+				aa.EXPECT().SignUp(gomock.Any(), tt.mockArgs.user).Return(tt.mockReturns.user, tt.mockReturns.err)
 			}
 
-			ac := NewAuthenticationController(tt.fields.aApp)
+			ac := NewAuthenticationController(tt.fields.aApp, model.DefaultSessionExpiry(), DefaultCookieConfig())
 			r := gin.New()
 
 			r.POST("/singUp", ac.SignUp)
@@ -217,9 +217,12 @@ func Test_authenticationController_SignUp(t *testing.T) {
 				return
 			}
 
-			gotCookieVal := rec.Header().Get("Set-Cookie")
+			gotCookieVal := setCookieHeader(rec, model.SessionIDAtCookie)
 			if gotCookieVal != tt.want.cookie {
 				t.Errorf("cookie = %v, want %v", gotCookieVal, tt.want.cookie)
+			}
+			if tt.want.cookie != "" && setCookieHeader(rec, model.CSRFTokenAtCookie) == "" {
+				t.Errorf("csrf token cookie is not set")
 				return
 			}
 
@@ -308,7 +311,7 @@ func Test_authenticationController_Login(t *testing.T) {
 			},
 			want: want{
 				statusCode: http.StatusOK,
-				cookie:     "SESSION_ID=testValidSessionID12345678; Path=/; Max-Age=86400; HttpOnly",
+				cookie:     "SESSION_ID=testValidSessionID12345678; Path=/; Max-Age=86400; HttpOnly; SameSite=Lax",
 				body: &UserDTO{
 					ID:        model.UserValidIDForTest,
 					Name:      model.UserNameForTest,
@@ -424,10 +427,10 @@ func Test_authenticationController_Login(t *testing.T) {
 			}
 
 			if tt.want.statusCode != http.StatusBadRequest {
-				aa.EXPECT().Login(tt.mockArgs.ctx, tt.mockArgs.user).Return(tt.mockReturns.user, tt.mockReturns.err)
+				aa.EXPECT().Login(gomock.Any(), tt.mockArgs.user).Return(tt.mockReturns.user, tt.mockReturns.err)
 			}
 
-			ac := NewAuthenticationController(tt.fields.aApp)
+			ac := NewAuthenticationController(tt.fields.aApp, model.DefaultSessionExpiry(), DefaultCookieConfig())
 			r := gin.New()
 
 			r.POST("/login", ac.Login)
@@ -449,9 +452,12 @@ func Test_authenticationController_Login(t *testing.T) {
 				return
 			}
 
-			gotCookieVal := rec.Header().Get("Set-Cookie")
+			gotCookieVal := setCookieHeader(rec, model.SessionIDAtCookie)
 			if gotCookieVal != tt.want.cookie {
 				t.Errorf("cookie = %v, want %v", gotCookieVal, tt.want.cookie)
+			}
+			if tt.want.cookie != "" && setCookieHeader(rec, model.CSRFTokenAtCookie) == "" {
+				t.Errorf("csrf token cookie is not set")
 				return
 			}
 
@@ -483,6 +489,56 @@ func Test_authenticationController_Login(t *testing.T) {
 	}
 }
 
+func Test_authenticationController_Login_spoofedClientIP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// failures counts the failed attempts by the client IP as the throttle of login attempts does.
+	failures := map[string]int{}
+
+	aa := mock_application.NewMockAuthenticationService(ctrl)
+	aa.EXPECT().Login(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, param *model.User) (*model.User, error) {
+		client, ok := model.ClientFromContext(ctx)
+		if !ok {
+			t.Fatal("client is not bound into the context")
+		}
+		failures[client.IP]++
+		return nil, &model.AuthenticationErr{}
+	}).Times(3)
+
+	ac := NewAuthenticationController(aa, model.DefaultSessionExpiry(), DefaultCookieConfig())
+	r := gin.New()
+	r.POST("/login", ac.Login)
+
+	for i, spoofed := range []string{"198.51.100.1", "198.51.100.2", "198.51.100.3"} {
+		b, err := json.Marshal(&model.User{Name: model.UserNameForTest, Password: model.PasswordForTest})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req, err := http.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.RemoteAddr = model.IPForTest + ":1234"
+		req.Header.Set("X-Forwarded-For", spoofed)
+		req.Header.Set("X-Real-Ip", spoofed)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("request %d: status code = %v, want %v", i, rec.Code, http.StatusUnauthorized)
+		}
+	}
+
+	// the spoofed headers must not reset the counter by giving a new IP.
+	want := map[string]int{model.IPForTest: 3}
+	if !reflect.DeepEqual(failures, want) {
+		t.Errorf("failures = %v, want %v", failures, want)
+	}
+}
+
 func Test_authenticationController_Logout(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -525,7 +581,7 @@ func Test_authenticationController_Logout(t *testing.T) {
 			},
 			want: want{
 				statusCode: 200,
-				cookie:     "SESSION_ID=; Path=/; HttpOnly",
+				cookie:     "SESSION_ID=; Path=/; Max-Age=0; HttpOnly; SameSite=Lax",
 				errBody: errBody{
 					errCode: "",
 					filed:   "",
@@ -549,7 +605,7 @@ func Test_authenticationController_Logout(t *testing.T) {
 
 			aa.EXPECT().Logout(tt.mockArgs.ctx, tt.mockArgs.sessionID).Return(tt.mockReturns.err)
 
-			ac := NewAuthenticationController(tt.fields.aApp)
+			ac := NewAuthenticationController(tt.fields.aApp, model.DefaultSessionExpiry(), DefaultCookieConfig())
 			r := gin.New()
 
 			r.POST("/logout", ac.Logout)
@@ -573,7 +629,7 @@ func Test_authenticationController_Logout(t *testing.T) {
 				return
 			}
 
-			gotCookieVal := rec.Header().Get("Set-Cookie")
+			gotCookieVal := setCookieHeader(rec, model.SessionIDAtCookie)
 			if gotCookieVal != tt.want.cookie {
 				t.Errorf("cookie = %v, want %v", gotCookieVal, tt.want.cookie)
 				return
@@ -592,3 +648,13 @@ func Test_authenticationController_Logout(t *testing.T) {
 		})
 	}
 }
+
+// setCookieHeader returns Set-Cookie header of the cookie specified by name.
+func setCookieHeader(rec *httptest.ResponseRecorder, name string) string {
+	for _, v := range rec.Header()["Set-Cookie"] {
+		if strings.HasPrefix(v, name+"=") {
+			return v
+		}
+	}
+	return ""
+}
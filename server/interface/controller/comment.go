@@ -20,6 +20,8 @@ type CommentController interface {
 	CreateComment(g *gin.Context)
 	UpdateComment(g *gin.Context)
 	DeleteComment(g *gin.Context)
+	RestoreComment(g *gin.Context)
+	ListCommentRevisions(g *gin.Context)
 }
 
 // commentController is the controller of comment.
@@ -31,9 +33,11 @@ type commentController struct {
 func (c *commentController) InitCommentAPI(g *gin.RouterGroup) {
 	g.GET("/:threadId/comments", c.ListComments)
 	g.GET("/:threadId/comments/:id", c.GetComment)
+	g.GET("/:threadId/comments/:id/revisions", c.ListCommentRevisions)
 	g.POST("/:threadId/comments", c.CreateComment)
 	g.PUT("/:threadId/comments/:id", c.UpdateComment)
 	g.DELETE("/:threadId/comments/:id", c.DeleteComment)
+	g.POST("/:threadId/comments/:id/restore", c.RestoreComment)
 }
 
 // NewCommentController generates and returns CommentController.
@@ -189,3 +193,55 @@ func (c commentController) DeleteComment(g *gin.Context) {
 
 	g.JSON(http.StatusOK, nil)
 }
+
+// RestoreComment restores the deleted Comment.
+func (c commentController) RestoreComment(g *gin.Context) {
+	idInt, err := strconv.Atoi(g.Param("id"))
+	if err != nil {
+		err = &model.InvalidParamError{
+			BaseErr:       err,
+			PropertyName:  model.IDProperty,
+			PropertyValue: g.Param("id"),
+		}
+		err = handleValidatorErr(err)
+		ResponseAndLogError(g, errors.Wrap(err, "failed to change id from string to int"))
+		return
+	}
+
+	id := uint32(idInt)
+
+	ctx := g.Request.Context()
+	comment, err := c.cApp.RestoreComment(ctx, id)
+	if err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to restore comment"))
+		return
+	}
+
+	g.JSON(http.StatusOK, comment)
+}
+
+// ListCommentRevisions gets the revisions of Comment.
+func (c commentController) ListCommentRevisions(g *gin.Context) {
+	idInt, err := strconv.Atoi(g.Param("id"))
+	if err != nil {
+		err = &model.InvalidParamError{
+			BaseErr:       err,
+			PropertyName:  model.IDProperty,
+			PropertyValue: g.Param("id"),
+		}
+		err = handleValidatorErr(err)
+		ResponseAndLogError(g, errors.Wrap(err, "failed to change id from string to int"))
+		return
+	}
+
+	id := uint32(idInt)
+
+	ctx := g.Request.Context()
+	revisions, err := c.cApp.ListCommentRevisions(ctx, id)
+	if err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to list comment revisions"))
+		return
+	}
+
+	g.JSON(http.StatusOK, revisions)
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/application"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/ws"
+	"go.uber.org/zap"
+)
+
+// CommentStreamController is the interface of CommentStreamController.
+type CommentStreamController interface {
+	InitCommentStreamAPI(g *gin.RouterGroup)
+	StreamComments(g *gin.Context)
+}
+
+// commentStreamController is the controller which streams comments over websocket.
+type commentStreamController struct {
+	hub  *ws.Hub
+	tApp application.ThreadService
+}
+
+// NewCommentStreamController generates and returns CommentStreamController.
+func NewCommentStreamController(hub *ws.Hub, tApp application.ThreadService) CommentStreamController {
+	return &commentStreamController{
+		hub:  hub,
+		tApp: tApp,
+	}
+}
+
+// InitCommentStreamAPI initialize Comment Stream API.
+func (c *commentStreamController) InitCommentStreamAPI(g *gin.RouterGroup) {
+	g.GET("/:threadId/stream", c.StreamComments)
+}
+
+// StreamComments upgrades the connection to websocket and pushes changes of comments in the thread.
+func (c *commentStreamController) StreamComments(g *gin.Context) {
+	threadIDInt, err := strconv.Atoi(g.Param("threadId"))
+	if err != nil || threadIDInt < 1 {
+		err = &model.InvalidParamError{
+			BaseErr:       err,
+			PropertyName:  model.ThreadIDProperty,
+			InvalidReason: "threadId should be number and over 0",
+		}
+
+		ResponseAndLogError(g, errors.Wrap(err, "failed to stream comments"))
+		return
+	}
+
+	threadID := uint32(threadIDInt)
+
+	// the thread is checked before upgrading the connection, so that the missing or deleted thread is responded as 404.
+	ctx := g.Request.Context()
+	if _, err := c.tApp.GetThread(ctx, threadID); err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to stream comments"))
+		return
+	}
+
+	user, ok := model.UserFromContext(ctx)
+	if !ok {
+		ResponseAndLogError(g, errors.WithStack(&model.AuthenticationErr{}))
+		return
+	}
+
+	// the session is absent when the user is authenticated by API token.
+	var sessionID string
+	if session, ok := model.SessionFromContext(ctx); ok {
+		sessionID = session.ID
+	}
+
+	// the upgrader has already responded to the client when it fails.
+	if err := ws.Serve(c.hub, g.Writer, g.Request, threadID, user.ID, sessionID); err != nil {
+		logger.FromContext(g.Request.Context()).Warn("failed to serve websocket", zap.String("error message", err.Error()))
+	}
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mock_application "github.com/sekky0905/nuxt-vue-go-chat/server/application/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/ws"
+)
+
+func Test_commentStreamController_StreamComments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &model.User{
+		ID:   model.UserValidIDForTest,
+		Name: model.UserNameForTest,
+	}
+
+	tests := []struct {
+		name         string
+		threadID     string
+		expectGet    bool
+		getThreadErr error
+		statusCode   int
+	}{
+		{
+			name:       "When threadId is not a number, returns status code 400",
+			threadID:   "abc",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:      "When the thread does not exist or has been deleted, returns status code 404 without upgrading",
+			threadID:  "1",
+			expectGet: true,
+			getThreadErr: &model.NoSuchDataError{
+				PropertyName:    model.IDProperty,
+				PropertyValue:   model.ThreadValidIDForTest,
+				DomainModelName: model.DomainModelNameThread,
+			},
+			statusCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ta := mock_application.NewMockThreadService(ctrl)
+			if tt.expectGet {
+				ta.EXPECT().GetThread(gomock.Any(), model.ThreadValidIDForTest).Return(nil, tt.getThreadErr)
+			}
+
+			hub := ws.NewHub()
+			c := NewCommentStreamController(hub, ta)
+
+			r := gin.New()
+			r.Use(func(g *gin.Context) {
+				// binds the user as CheckAuthentication does.
+				g.Request = g.Request.WithContext(model.WithUser(g.Request.Context(), user))
+				g.Next()
+			})
+			c.InitCommentStreamAPI(&r.RouterGroup)
+
+			req, err := http.NewRequest(http.MethodGet, "/"+tt.threadID+"/stream", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Connection", "upgrade")
+			req.Header.Set("Upgrade", "websocket")
+			req.Header.Set("Sec-Websocket-Version", "13")
+			req.Header.Set("Sec-Websocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.statusCode {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.statusCode)
+			}
+			if got := hub.SubscriberCount(model.ThreadValidIDForTest); got != 0 {
+				t.Errorf("SubscriberCount() = %d, want 0", got)
+			}
+		})
+	}
+}
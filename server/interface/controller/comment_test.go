@@ -869,6 +869,31 @@ func Test_commentController_DeleteComment(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "When the user is not the author, DeleteComment returns status code 403",
+			fields: fields{
+				cApp: mock_application.NewMockCommentService(ctrl),
+			},
+			args: args{
+				id: model.CommentValidIDForTest,
+			},
+			parameter: parameter{
+				id: "1",
+			},
+			mockReturns: mockReturns{
+				err: &model.ForbiddenError{
+					PropertyName:    model.IDProperty,
+					PropertyValue:   model.CommentValidIDForTest,
+					DomainModelName: model.DomainModelNameComment,
+				},
+			},
+			want: want{
+				statusCode: http.StatusForbidden,
+				errBody: errBody{
+					errCode: ForbiddenFailure,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -916,3 +941,195 @@ func Test_commentController_DeleteComment(t *testing.T) {
 		})
 	}
 }
+
+func Test_commentController_RestoreComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testutil.SetFakeTime(time.Now())
+
+	restored := &model.Comment{
+		ID:        model.CommentValidIDForTest,
+		Content:   model.CommentContentForTest,
+		ThreadID:  model.ThreadValidIDForTest,
+		User:      &model.User{ID: model.UserValidIDForTest, Name: model.UserNameForTest},
+		CreatedAt: testutil.TimeNow(),
+		UpdatedAt: testutil.TimeNow(),
+	}
+
+	type mockReturns struct {
+		comment *model.Comment
+		err     error
+	}
+
+	tests := []struct {
+		name        string
+		id          string
+		mockReturns mockReturns
+		statusCode  int
+		errCode     ErrCode
+	}{
+		{
+			name: "When the deleted comment is restored, returns it and status code 200",
+			id:   "1",
+			mockReturns: mockReturns{
+				comment: restored,
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "When inappropriate id is given, returns status code 400",
+			id:         "a",
+			statusCode: http.StatusBadRequest,
+			errCode:    InvalidParametersValueFailure,
+		},
+		{
+			name: "When the comment has not been deleted, returns status code 404",
+			id:   "1",
+			mockReturns: mockReturns{
+				err: &model.NoSuchDataError{
+					PropertyName:  model.IDProperty,
+					PropertyValue: "",
+				},
+			},
+			statusCode: http.StatusNotFound,
+			errCode:    NoSuchDataFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := mock_application.NewMockCommentService(ctrl)
+			if tt.statusCode != http.StatusBadRequest {
+				app.EXPECT().RestoreComment(context.Background(), model.CommentValidIDForTest).Return(tt.mockReturns.comment, tt.mockReturns.err)
+			}
+
+			c := NewCommentController(app)
+			r := gin.New()
+
+			r.POST("/threads/:threadId/comments/:id/restore", c.RestoreComment)
+
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/threads/1/comments/%s/restore", tt.id), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.statusCode {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.statusCode)
+				return
+			}
+
+			if tt.errCode == "" {
+				got := &model.Comment{}
+				if err := json.Unmarshal(rec.Body.Bytes(), got); err != nil {
+					t.Fatal(err)
+				}
+				if got.ID != restored.ID {
+					t.Errorf("body = %#v, want %#v", got, restored)
+				}
+			} else if body := rec.Body.String(); !strings.Contains(body, string(tt.errCode)) {
+				t.Errorf("body = %#v, want %#v", body, tt.errCode)
+			}
+		})
+	}
+}
+
+func Test_commentController_ListCommentRevisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testutil.SetFakeTime(time.Now())
+
+	list := &model.CommentRevisionList{
+		Revisions: []*model.CommentRevision{
+			{
+				ID:        model.CommentRevisionValidIDForTest,
+				CommentID: model.CommentValidIDForTest,
+				Content:   model.CommentContentForTest,
+				EditedBy:  &model.User{ID: model.UserValidIDForTest, Name: model.UserNameForTest},
+				CreatedAt: testutil.TimeNow(),
+				EditedAt:  testutil.TimeNow(),
+			},
+		},
+	}
+
+	type mockReturns struct {
+		list *model.CommentRevisionList
+		err  error
+	}
+
+	tests := []struct {
+		name        string
+		id          string
+		mockReturns mockReturns
+		statusCode  int
+		errCode     ErrCode
+	}{
+		{
+			name: "When the comment has been edited, returns its revisions and status code 200",
+			id:   "1",
+			mockReturns: mockReturns{
+				list: list,
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "When inappropriate id is given, returns status code 400",
+			id:         "a",
+			statusCode: http.StatusBadRequest,
+			errCode:    InvalidParametersValueFailure,
+		},
+		{
+			name: "When the comment does not exist, returns status code 404",
+			id:   "1",
+			mockReturns: mockReturns{
+				err: &model.NoSuchDataError{
+					PropertyName:  model.IDProperty,
+					PropertyValue: "",
+				},
+			},
+			statusCode: http.StatusNotFound,
+			errCode:    NoSuchDataFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := mock_application.NewMockCommentService(ctrl)
+			if tt.statusCode != http.StatusBadRequest {
+				app.EXPECT().ListCommentRevisions(context.Background(), model.CommentValidIDForTest).Return(tt.mockReturns.list, tt.mockReturns.err)
+			}
+
+			c := NewCommentController(app)
+			r := gin.New()
+
+			r.GET("/threads/:threadId/comments/:id/revisions", c.ListCommentRevisions)
+
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/threads/1/comments/%s/revisions", tt.id), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.statusCode {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.statusCode)
+				return
+			}
+
+			if tt.errCode == "" {
+				got := &model.CommentRevisionList{}
+				if err := json.Unmarshal(rec.Body.Bytes(), got); err != nil {
+					t.Fatal(err)
+				}
+				if len(got.Revisions) != 1 || got.Revisions[0].Content != model.CommentContentForTest {
+					t.Errorf("body = %#v, want %#v", got, list)
+				}
+			} else if body := rec.Body.String(); !strings.Contains(body, string(tt.errCode)) {
+				t.Errorf("body = %#v, want %#v", body, tt.errCode)
+			}
+		})
+	}
+}
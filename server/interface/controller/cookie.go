@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/util"
+)
+
+// CookieConfig is the attributes of the cookies which the server sets.
+type CookieConfig struct {
+	Domain   string
+	Secure   bool
+	SameSite http.SameSite
+}
+
+// DefaultCookieConfig returns CookieConfig for local development, which does not require HTTPS.
+func DefaultCookieConfig() CookieConfig {
+	return CookieConfig{
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// set sets the cookie with the attributes.
+// maxAge < 0 deletes the cookie, and maxAge = 0 makes it live until the browser is closed.
+func (c CookieConfig) set(g *gin.Context, name, value string, maxAge int, httpOnly bool) {
+	http.SetCookie(g.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Domain:   c.Domain,
+		MaxAge:   maxAge,
+		Secure:   c.Secure,
+		HttpOnly: httpOnly,
+		SameSite: c.SameSite,
+	})
+}
+
+// SetSessionCookie sets the session id to the cookie.
+// The cookie lives as long as the session can be idle, and is renewed on every renewal of the session.
+func SetSessionCookie(g *gin.Context, cookie CookieConfig, sessionID string, expiry model.SessionExpiry) {
+	cookie.set(g, model.SessionIDAtCookie, sessionID, expiry.CookieMaxAge(), true)
+}
+
+// ClearSessionCookie empties the session cookie and the CSRF token cookie.
+func ClearSessionCookie(g *gin.Context, cookie CookieConfig) {
+	cookie.set(g, model.SessionIDAtCookie, "", -1, true)
+	cookie.set(g, model.CSRFTokenAtCookie, "", -1, false)
+}
+
+// SetCSRFCookie generates the CSRF token and sets it to the cookie, and returns the token.
+// The cookie is readable from scripts, so that the client can send it back in the header.
+func SetCSRFCookie(g *gin.Context, cookie CookieConfig) (string, error) {
+	token, err := util.RandomToken()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate csrf token")
+	}
+
+	cookie.set(g, model.CSRFTokenAtCookie, token, 0, false)
+	return token, nil
+}
@@ -26,6 +26,22 @@ func TranslateFromUserToUserDTO(user *model.User) *UserDTO {
 	}
 }
 
+// translateFromUserDTOToUser translates from UserDTO to User.
+// The user given by clients is not trusted as the author,
+// which is derived from the user who requested at the application layer.
+func translateFromUserDTOToUser(dto *UserDTO) *model.User {
+	if dto == nil {
+		return nil
+	}
+
+	return &model.User{
+		ID:        dto.ID,
+		Name:      dto.Name,
+		CreatedAt: dto.CreatedAt,
+		UpdatedAt: dto.UpdatedAt,
+	}
+}
+
 // ThreadDTO is DTO of Thread.
 type ThreadDTO struct {
 	ID        uint32 `json:"id"`
@@ -38,14 +54,9 @@ type ThreadDTO struct {
 // TranslateFromThreadDTOToThread translates from ThreadDTO to Thread.
 func TranslateFromThreadDTOToThread(dto *ThreadDTO) *model.Thread {
 	return &model.Thread{
-		ID:    dto.ID,
-		Title: dto.Title,
-		User: &model.User{
-			ID:        dto.UserDTO.ID,
-			Name:      dto.UserDTO.Name,
-			CreatedAt: dto.UserDTO.CreatedAt,
-			UpdatedAt: dto.UserDTO.UpdatedAt,
-		},
+		ID:        dto.ID,
+		Title:     dto.Title,
+		User:      translateFromUserDTOToUser(dto.UserDTO),
 		CreatedAt: dto.CreatedAt,
 		UpdatedAt: dto.UpdatedAt,
 	}
@@ -64,16 +75,123 @@ type CommentDTO struct {
 // TranslateFromCommentDTOToComment translates from CommentDTO to Comment.
 func TranslateFromCommentDTOToComment(dto *CommentDTO) *model.Comment {
 	return &model.Comment{
-		ID:      dto.ID,
-		Content: dto.Content,
-		User: &model.User{
-			ID:        dto.UserDTO.ID,
-			Name:      dto.UserDTO.Name,
-			CreatedAt: dto.CreatedAt,
-			UpdatedAt: dto.UpdatedAt,
-		},
+		ID:        dto.ID,
+		Content:   dto.Content,
+		User:      translateFromUserDTOToUser(dto.UserDTO),
 		ThreadID:  dto.ThreadID,
 		CreatedAt: dto.CreatedAt,
 		UpdatedAt: dto.UpdatedAt,
 	}
 }
+
+// AdminUserDTO is DTO of User for administrators.
+// This does not have the password and the session id.
+type AdminUserDTO struct {
+	ID        uint32    `json:"id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	Banned    bool      `json:"banned"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TranslateFromUserToAdminUserDTO translates from User to AdminUserDTO.
+func TranslateFromUserToAdminUserDTO(user *model.User) *AdminUserDTO {
+	return &AdminUserDTO{
+		ID:        user.ID,
+		Name:      user.Name,
+		Role:      user.Role.String(),
+		Banned:    user.Banned,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+// AdminUserListDTO is DTO of UserList for administrators.
+type AdminUserListDTO struct {
+	Users   []*AdminUserDTO `json:"users"`
+	HasNext bool            `json:"hasNext"`
+	Cursor  uint32          `json:"cursor"`
+}
+
+// TranslateFromUserListToAdminUserListDTO translates from UserList to AdminUserListDTO.
+func TranslateFromUserListToAdminUserListDTO(list *model.UserList) *AdminUserListDTO {
+	users := make([]*AdminUserDTO, len(list.Users), len(list.Users))
+	for i, user := range list.Users {
+		users[i] = TranslateFromUserToAdminUserDTO(user)
+	}
+
+	return &AdminUserListDTO{
+		Users:   users,
+		HasNext: list.HasNext,
+		Cursor:  list.Cursor,
+	}
+}
+
+// RoleDTO is DTO of the role of User.
+type RoleDTO struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// SessionDTO is DTO of Session.
+// ID is the public id of the session, not the session id itself.
+type SessionDTO struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"userAgent"`
+	IP         string    `json:"ip"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+// TranslateFromSessionToSessionDTO translates from Session to SessionDTO.
+func TranslateFromSessionToSessionDTO(session *model.Session, current bool) *SessionDTO {
+	return &SessionDTO{
+		ID:         session.PublicID(),
+		UserAgent:  session.UserAgent,
+		IP:         session.IP,
+		Current:    current,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastAccessedAt,
+		ExpiresAt:  session.ExpiresAt,
+	}
+}
+
+// ChangePasswordDTO is DTO of changing password.
+type ChangePasswordDTO struct {
+	OldPassword string `json:"oldPassword" binding:"required"`
+	NewPassword string `json:"newPassword" binding:"required"`
+}
+
+// ResetPasswordDTO is DTO of resetting password.
+type ResetPasswordDTO struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"newPassword" binding:"required"`
+}
+
+// APITokenDTO is DTO of APIToken.
+// Token is the token itself, which is given only when the token is created.
+type APITokenDTO struct {
+	ID         uint32    `json:"id"`
+	Name       string    `json:"name"`
+	Token      string    `json:"token,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+}
+
+// TranslateFromAPITokenToAPITokenDTO translates from APIToken to APITokenDTO.
+func TranslateFromAPITokenToAPITokenDTO(apiToken *model.APIToken, token string) *APITokenDTO {
+	return &APITokenDTO{
+		ID:         apiToken.ID,
+		Name:       apiToken.Name,
+		Token:      token,
+		CreatedAt:  apiToken.CreatedAt,
+		LastUsedAt: apiToken.LastUsedAt,
+	}
+}
+
+// CreateAPITokenDTO is DTO of creating APIToken.
+type CreateAPITokenDTO struct {
+	Name string `json:"name" binding:"required"`
+}
@@ -19,4 +19,10 @@ const (
 	RequiredFailure               ErrCode = "RequiredError"
 	AlreadyExistsFailure          ErrCode = "AlreadyExistsFailure"
 	AuthenticationFailure         ErrCode = "AuthenticationFailure"
+	SessionExpiredFailure         ErrCode = "SessionExpiredFailure"
+	ForbiddenFailure              ErrCode = "ForbiddenFailure"
+	BannedFailure                 ErrCode = "BannedFailure"
+	CSRFFailure                   ErrCode = "CSRFFailure"
+	TooManyAttemptsFailure        ErrCode = "TooManyAttemptsFailure"
+	RateLimitFailure              ErrCode = "RateLimitFailure"
 )
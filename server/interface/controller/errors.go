@@ -88,6 +88,49 @@ func handleError(err error) *handledError {
 			Code:    AuthenticationFailure,
 			Message: errors.Cause(err).Error(),
 		}
+	case *model.SessionExpiredError:
+		return &handledError{
+			Status:  http.StatusUnauthorized,
+			Code:    SessionExpiredFailure,
+			Message: errors.Cause(err).Error(),
+		}
+	case *model.ForbiddenError:
+		realErr, ok := errors.Cause(err).(*model.ForbiddenError)
+		if !ok {
+			logger.Logger.Error(fmt.Sprintf("failed to assert. err = %+v", err))
+			return nil
+		}
+
+		return &handledError{
+			BaseError: realErr.BaseErr,
+			Status:    http.StatusForbidden,
+			Code:      ForbiddenFailure,
+			Message:   errors.Cause(err).Error(),
+		}
+	case *model.BannedError:
+		return &handledError{
+			Status:  http.StatusForbidden,
+			Code:    BannedFailure,
+			Message: errors.Cause(err).Error(),
+		}
+	case *model.TooManyAttemptsError:
+		return &handledError{
+			Status:  http.StatusTooManyRequests,
+			Code:    TooManyAttemptsFailure,
+			Message: errors.Cause(err).Error(),
+		}
+	case *model.RateLimitError:
+		return &handledError{
+			Status:  http.StatusTooManyRequests,
+			Code:    RateLimitFailure,
+			Message: errors.Cause(err).Error(),
+		}
+	case *model.CSRFError:
+		return &handledError{
+			Status:  http.StatusForbidden,
+			Code:    CSRFFailure,
+			Message: errors.Cause(err).Error(),
+		}
 	case *model.RepositoryError:
 		realErr, ok := errors.Cause(err).(*model.RepositoryError)
 		if !ok {
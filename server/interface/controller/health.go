@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/health"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+)
+
+// HealthController is the interface of HealthController.
+type HealthController interface {
+	InitHealthAPI(g *gin.RouterGroup)
+	Healthz(g *gin.Context)
+	Readyz(g *gin.Context)
+}
+
+// healthController is the controller of the probes of orchestrators.
+type healthController struct {
+	checker *health.Checker
+}
+
+// NewHealthController generates and returns HealthController.
+func NewHealthController(checker *health.Checker) HealthController {
+	return &healthController{
+		checker: checker,
+	}
+}
+
+// InitHealthAPI initialize Health API.
+func (c *healthController) InitHealthAPI(g *gin.RouterGroup) {
+	g.GET("/healthz", c.Healthz)
+	g.GET("/readyz", c.Readyz)
+}
+
+// Healthz responds that the process is alive. It does not check the dependencies,
+// so that the process is not restarted when only they are unavailable.
+func (c *healthController) Healthz(g *gin.Context) {
+	g.Header("Cache-Control", "no-store")
+	g.JSON(http.StatusOK, gin.H{"status": health.StatusOK})
+}
+
+// Readyz responds whether the server is ready to serve with the status of each dependency, ok or unavailable.
+// The errors of the checks are only logged, because they can reveal the internals of the dependencies.
+func (c *healthController) Readyz(g *gin.Context) {
+	ctx := g.Request.Context()
+	report := c.checker.Check(ctx)
+
+	checks := make(map[string]string, len(report.Checks))
+	for name, result := range report.Checks {
+		checks[name] = result.Status
+		if result.Status != health.StatusOK {
+			logger.FromContext(ctx).Warn("readiness check has failed",
+				zap.String("check", name),
+				zap.String("error message", result.Error),
+				zap.Int64("durationMs", result.DurationMs),
+			)
+		}
+	}
+
+	status := http.StatusOK
+	if !report.OK() {
+		status = http.StatusServiceUnavailable
+	}
+
+	g.Header("Cache-Control", "no-store")
+	g.JSON(status, gin.H{"status": report.Status, "checks": checks})
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/health"
+)
+
+func Test_healthController_Readyz(t *testing.T) {
+	// secret is the detail of the dependency which must not be responded.
+	const secret = "dial tcp 10.0.0.1:3306: connect: connection refused"
+
+	tests := []struct {
+		name       string
+		dbErr      error
+		statusCode int
+		want       map[string]interface{}
+	}{
+		{
+			name:       "When all checks pass, returns 200 with ok",
+			statusCode: http.StatusOK,
+			want: map[string]interface{}{
+				"status": health.StatusOK,
+				"checks": map[string]interface{}{"db": health.StatusOK, "migrations": health.StatusOK},
+			},
+		},
+		{
+			name:       "When a check fails, returns 503 with unavailable and without the error",
+			dbErr:      errors.New(secret),
+			statusCode: http.StatusServiceUnavailable,
+			want: map[string]interface{}{
+				"status": health.StatusUnavailable,
+				"checks": map[string]interface{}{"db": health.StatusUnavailable, "migrations": health.StatusOK},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := health.NewChecker(time.Second)
+			checker.Add("db", func(ctx context.Context) error {
+				return tt.dbErr
+			})
+			checker.Add("migrations", func(ctx context.Context) error {
+				return nil
+			})
+
+			r := gin.New()
+			NewHealthController(checker).InitHealthAPI(&r.RouterGroup)
+
+			req, err := http.NewRequest(http.MethodGet, "/readyz", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.statusCode {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.statusCode)
+			}
+			if strings.Contains(rec.Body.String(), secret) {
+				t.Errorf("body = %s, which has the error of the check", rec.Body.String())
+			}
+
+			got := map[string]interface{}{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("body = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/application"
+)
+
+// PasswordController is the interface of PasswordController.
+type PasswordController interface {
+	InitPasswordAPI(g *gin.RouterGroup)
+	InitPasswordResetAPI(g *gin.RouterGroup)
+	ChangePassword(g *gin.Context)
+	ResetPassword(g *gin.Context)
+}
+
+// passwordController is the controller of password.
+type passwordController struct {
+	pApp application.PasswordService
+}
+
+// NewPasswordController generates and returns PasswordController.
+func NewPasswordController(pApp application.PasswordService) PasswordController {
+	return &passwordController{
+		pApp: pApp,
+	}
+}
+
+// InitPasswordAPI initialize Password API, which requires authentication.
+func (c *passwordController) InitPasswordAPI(g *gin.RouterGroup) {
+	g.PUT("", c.ChangePassword)
+}
+
+// InitPasswordResetAPI initialize Password Reset API, which does not require authentication.
+func (c *passwordController) InitPasswordResetAPI(g *gin.RouterGroup) {
+	g.POST("/passwordReset", c.ResetPassword)
+}
+
+// ChangePassword changes the password of the user who requested.
+func (c *passwordController) ChangePassword(g *gin.Context) {
+	dto := &ChangePasswordDTO{}
+	if err := g.BindJSON(dto); err != nil {
+		err = handleValidatorErr(err)
+		ResponseAndLogError(g, errors.Wrap(err, "failed to bind json"))
+		return
+	}
+
+	ctx := g.Request.Context()
+	if err := c.pApp.ChangePassword(ctx, dto.OldPassword, dto.NewPassword); err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to change password"))
+		return
+	}
+
+	g.JSON(http.StatusOK, nil)
+}
+
+// ResetPassword resets the password by the password reset token.
+func (c *passwordController) ResetPassword(g *gin.Context) {
+	dto := &ResetPasswordDTO{}
+	if err := g.BindJSON(dto); err != nil {
+		err = handleValidatorErr(err)
+		ResponseAndLogError(g, errors.Wrap(err, "failed to bind json"))
+		return
+	}
+
+	ctx := g.Request.Context()
+	if err := c.pApp.ResetPassword(ctx, dto.Token, dto.NewPassword); err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to reset password"))
+		return
+	}
+
+	g.JSON(http.StatusOK, nil)
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mock_application "github.com/sekky0905/nuxt-vue-go-chat/server/application/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+)
+
+func Test_passwordController_ChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		statusCode int
+	}{
+		{
+			name:       "When the password is changed, returns status code 200",
+			body:       `{"oldPassword":"testPassword","newPassword":"newPassword"}`,
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "When the old password is wrong, returns status code 400",
+			body: `{"oldPassword":"wrongPassword","newPassword":"newPassword"}`,
+			err: &model.InvalidParamError{
+				PropertyName: model.PassWordProperty,
+			},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pa := mock_application.NewMockPasswordService(ctrl)
+			pa.EXPECT().ChangePassword(gomock.Any(), gomock.Any(), "newPassword").Return(tt.err)
+
+			pc := NewPasswordController(pa)
+			r := gin.New()
+			pc.InitPasswordAPI(r.Group("/password"))
+
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodPut, "/password", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.statusCode {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func Test_passwordController_ResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		err        error
+		statusCode int
+	}{
+		{
+			name:       "When the token is redeemable, returns status code 200",
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "When the token is invalid, returns status code 400",
+			err: &model.InvalidParamError{
+				PropertyName: model.TokenProperty,
+			},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pa := mock_application.NewMockPasswordService(ctrl)
+			pa.EXPECT().ResetPassword(gomock.Any(), "resetToken", "newPassword").Return(tt.err)
+
+			pc := NewPasswordController(pa)
+			r := gin.New()
+			pc.InitPasswordResetAPI(r.Group(""))
+
+			rec := httptest.NewRecorder()
+			body := strings.NewReader(`{"token":"resetToken","newPassword":"newPassword"}`)
+			req, err := http.NewRequest(http.MethodPost, "/passwordReset", body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.statusCode {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
@@ -1,6 +1,43 @@
 package controller
 
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+)
+
 const (
 	defaultLimit  = 20
 	defaultCursor = 1
 )
+
+// clientContext returns the context of the request which holds the client which requested.
+func clientContext(g *gin.Context) context.Context {
+	client := &model.Client{
+		UserAgent: g.Request.UserAgent(),
+		IP:        ClientIP(g),
+	}
+
+	return model.WithClient(g.Request.Context(), client)
+}
+
+// ClientIP returns the IP of the client which requested, which has been resolved by middleware.ResolveClient.
+// Otherwise it is the host of the remote address, because X-Forwarded-For and X-Real-Ip can be set by anyone.
+func ClientIP(g *gin.Context) string {
+	if client, ok := model.ClientFromContext(g.Request.Context()); ok && client.IP != "" {
+		return client.IP
+	}
+	return RemoteIP(g.Request)
+}
+
+// RemoteIP returns the host of the remote address of the request.
+func RemoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
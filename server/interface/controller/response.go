@@ -1,21 +1,44 @@
 package controller
 
 import (
+	"net/http"
+	"strconv"
+
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
 	"go.uber.org/zap"
 )
 
-// ResponseAndLogError returns response and log error.
+// retryAfterError is the error which tells when the request can be retried.
+type retryAfterError interface {
+	RetryAfterSeconds() int
+}
+
+// ResponseAndLogError returns response and log error with the logger of the request.
+// The errors of the client are logged as warnings, so that the errors of the server stand out.
 func ResponseAndLogError(g *gin.Context, err error) {
 	he := handleError(err)
 
-	errMsgField := zap.String("error message", he.Message)
-
+	fields := []zap.Field{
+		zap.Int("status", he.Status),
+		zap.String("code", string(he.Code)),
+		zap.String("error message", he.Message),
+		zap.String("error", err.Error()),
+	}
 	if he.BaseError != nil {
-		logger.Logger.Error("", errMsgField, zap.String("base error", he.BaseError.Error()))
+		fields = append(fields, zap.String("base error", he.BaseError.Error()))
+	}
+
+	l := logger.FromContext(g.Request.Context())
+	if he.Status >= http.StatusInternalServerError {
+		l.Error("failed to handle request", fields...)
 	} else {
-		logger.Logger.Error("", errMsgField)
+		l.Warn("failed to handle request", fields...)
+	}
+
+	if retryable, ok := errors.Cause(err).(retryAfterError); ok {
+		g.Header("Retry-After", strconv.Itoa(retryable.RetryAfterSeconds()))
 	}
 
 	g.JSON(he.Status, he)
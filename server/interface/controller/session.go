@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/application"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+)
+
+// SessionController is the interface of SessionController.
+type SessionController interface {
+	InitSessionAPI(g *gin.RouterGroup)
+	ListSessions(g *gin.Context)
+	RevokeSession(g *gin.Context)
+	RevokeAllSessions(g *gin.Context)
+}
+
+// sessionController is the controller of the sessions of the user who requested.
+type sessionController struct {
+	sApp   application.SessionService
+	cookie CookieConfig
+}
+
+// NewSessionController generates and returns SessionController.
+func NewSessionController(sApp application.SessionService, cookie CookieConfig) SessionController {
+	return &sessionController{
+		sApp:   sApp,
+		cookie: cookie,
+	}
+}
+
+// InitSessionAPI initialize Session API.
+func (c *sessionController) InitSessionAPI(g *gin.RouterGroup) {
+	g.GET("", c.ListSessions)
+	g.DELETE("", c.RevokeAllSessions)
+	g.DELETE("/:id", c.RevokeSession)
+}
+
+// ListSessions lists the active sessions of the user who requested.
+func (c *sessionController) ListSessions(g *gin.Context) {
+	ctx := g.Request.Context()
+	sessions, err := c.sApp.ListSessions(ctx)
+	if err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to list sessions"))
+		return
+	}
+
+	var currentID string
+	if current, ok := model.SessionFromContext(ctx); ok {
+		currentID = current.ID
+	}
+
+	dtos := make([]*SessionDTO, len(sessions), len(sessions))
+	for i, session := range sessions {
+		dtos[i] = TranslateFromSessionToSessionDTO(session, session.ID == currentID)
+	}
+
+	g.JSON(http.StatusOK, dtos)
+}
+
+// RevokeSession revokes the session specified by the public id.
+func (c *sessionController) RevokeSession(g *gin.Context) {
+	ctx := g.Request.Context()
+	if err := c.sApp.RevokeSession(ctx, g.Param("id")); err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to revoke session"))
+		return
+	}
+
+	if current, ok := model.SessionFromContext(ctx); ok && current.PublicID() == g.Param("id") {
+		ClearSessionCookie(g, c.cookie)
+	}
+
+	g.JSON(http.StatusOK, nil)
+}
+
+// RevokeAllSessions revokes all sessions of the user who requested, i.e. logout everywhere.
+func (c *sessionController) RevokeAllSessions(g *gin.Context) {
+	ctx := g.Request.Context()
+	if err := c.sApp.RevokeAllSessions(ctx); err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to revoke all sessions"))
+		return
+	}
+
+	ClearSessionCookie(g, c.cookie)
+
+	g.JSON(http.StatusOK, nil)
+}
@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mock_application "github.com/sekky0905/nuxt-vue-go-chat/server/application/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+)
+
+// withSession binds the session into the context of the request as CheckAuthentication does.
+func withSession(session *model.Session) gin.HandlerFunc {
+	return func(g *gin.Context) {
+		g.Request = g.Request.WithContext(model.WithSession(g.Request.Context(), session))
+		g.Next()
+	}
+}
+
+func Test_sessionController_ListSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	current := &model.Session{
+		ID:     model.SessionValidIDForTest,
+		UserID: model.UserValidIDForTest,
+	}
+	other := &model.Session{
+		ID:        model.SessionInValidIDForTest,
+		UserID:    model.UserValidIDForTest,
+		UserAgent: model.UserAgentForTest,
+		IP:        model.IPForTest,
+	}
+
+	sa := mock_application.NewMockSessionService(ctrl)
+	sa.EXPECT().ListSessions(gomock.Any()).Return([]*model.Session{current, other}, nil)
+
+	sc := NewSessionController(sa, DefaultCookieConfig())
+	r := gin.New()
+	r.Use(withSession(current))
+	r.GET("/sessions", sc.ListSessions)
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/sessions", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code = %v, want %v", rec.Code, http.StatusOK)
+	}
+
+	if strings.Contains(rec.Body.String(), model.SessionValidIDForTest) || strings.Contains(rec.Body.String(), model.SessionInValidIDForTest) {
+		t.Errorf("body = %s, must not contain session ids", rec.Body.String())
+	}
+
+	got := make([]*SessionDTO, 0)
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("len(body) = %d, want 2", len(got))
+	}
+	if !got[0].Current || got[0].ID != current.PublicID() {
+		t.Errorf("body[0] = %#v, want current session", got[0])
+	}
+	if got[1].Current || got[1].UserAgent != model.UserAgentForTest || got[1].IP != model.IPForTest {
+		t.Errorf("body[1] = %#v, want other session", got[1])
+	}
+}
+
+func Test_sessionController_RevokeSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	current := &model.Session{
+		ID:     model.SessionValidIDForTest,
+		UserID: model.UserValidIDForTest,
+	}
+
+	tests := []struct {
+		name        string
+		publicID    string
+		err         error
+		statusCode  int
+		clearCookie bool
+	}{
+		{
+			name:        "When other session is revoked, returns status code 200 and keeps the cookie",
+			publicID:    (&model.Session{ID: model.SessionInValidIDForTest}).PublicID(),
+			statusCode:  http.StatusOK,
+			clearCookie: false,
+		},
+		{
+			name:        "When the current session is revoked, returns status code 200 and clears the cookie",
+			publicID:    current.PublicID(),
+			statusCode:  http.StatusOK,
+			clearCookie: true,
+		},
+		{
+			name:       "When the session is not found, returns status code 404",
+			publicID:   "unknown",
+			err:        &model.NoSuchDataError{},
+			statusCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sa := mock_application.NewMockSessionService(ctrl)
+			sa.EXPECT().RevokeSession(gomock.Any(), tt.publicID).Return(tt.err)
+
+			sc := NewSessionController(sa, DefaultCookieConfig())
+			r := gin.New()
+			r.Use(withSession(current))
+			sc.InitSessionAPI(r.Group("/sessions"))
+
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/sessions/%s", tt.publicID), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.statusCode {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.statusCode)
+				return
+			}
+
+			cleared := strings.Contains(rec.Header().Get("Set-Cookie"), model.SessionIDAtCookie+"=;")
+			if cleared != tt.clearCookie {
+				t.Errorf("cookie cleared = %v, want %v", cleared, tt.clearCookie)
+			}
+		})
+	}
+}
+
+func Test_sessionController_RevokeAllSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sa := mock_application.NewMockSessionService(ctrl)
+	sa.EXPECT().RevokeAllSessions(gomock.Any()).Return(nil)
+
+	sc := NewSessionController(sa, DefaultCookieConfig())
+	r := gin.New()
+	sc.InitSessionAPI(r.Group("/sessions"))
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodDelete, "/sessions", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status code = %v, want %v", rec.Code, http.StatusOK)
+	}
+
+	if !strings.Contains(rec.Header().Get("Set-Cookie"), model.SessionIDAtCookie+"=;") {
+		t.Errorf("Set-Cookie = %s, want the cleared cookie", rec.Header().Get("Set-Cookie"))
+	}
+}
@@ -18,27 +18,31 @@ type ThreadController interface {
 	CreateThread(g *gin.Context)
 	UpdateThread(g *gin.Context)
 	DeleteThread(g *gin.Context)
+	RestoreThread(g *gin.Context)
 }
 
 // threadController is the controller of thread.
 type threadController struct {
-	tApp application.ThreadService
+	tApp   application.ThreadService
+	events ThreadEventController
 }
 
 // NewThreadController generates and returns ThreadController.
-func NewThreadController(tApp application.ThreadService) ThreadController {
+func NewThreadController(tApp application.ThreadService, events ThreadEventController) ThreadController {
 	return &threadController{
-		tApp: tApp,
+		tApp:   tApp,
+		events: events,
 	}
 }
 
 // InitThreadAPI initialize Thread API.
 func (c *threadController) InitThreadAPI(g *gin.RouterGroup) {
 	g.GET("", c.ListThreads)
-	g.GET("/:threadId", c.GetThread)
+	g.GET("/:threadId", c.getThreadOrStreamEvents)
 	g.POST("", c.CreateThread)
 	g.PUT("/:threadId", c.UpdateThread)
 	g.DELETE("/:threadId", c.DeleteThread)
+	g.POST("/:threadId/restore", c.RestoreThread)
 }
 
 // ListThreads gets ThreadList.
@@ -65,6 +69,16 @@ func (c *threadController) ListThreads(g *gin.Context) {
 	g.JSON(http.StatusOK, thread)
 }
 
+// getThreadOrStreamEvents streams the thread events when the path is "/threads/events", and gets Thread otherwise.
+// gin can not register the static path "/events" beside "/:threadId", so that they share the route.
+func (c *threadController) getThreadOrStreamEvents(g *gin.Context) {
+	if g.Param("threadId") == threadEventsPath && c.events != nil {
+		c.events.StreamThreadEvents(g)
+		return
+	}
+	c.GetThread(g)
+}
+
 // GetThread gets Thread.
 func (c *threadController) GetThread(g *gin.Context) {
 	idInt, err := strconv.Atoi(g.Param("id"))
@@ -171,3 +185,29 @@ func (c *threadController) DeleteThread(g *gin.Context) {
 
 	g.JSON(http.StatusOK, nil)
 }
+
+// RestoreThread restores the deleted Thread.
+func (c *threadController) RestoreThread(g *gin.Context) {
+	idInt, err := strconv.Atoi(g.Param("threadId"))
+	if err != nil {
+		err = &model.InvalidParamError{
+			BaseErr:       err,
+			PropertyName:  model.IDProperty,
+			PropertyValue: g.Param("threadId"),
+		}
+		err = handleValidatorErr(err)
+		ResponseAndLogError(g, errors.Wrap(err, "failed to change id from string to int"))
+		return
+	}
+
+	id := uint32(idInt)
+
+	ctx := g.Request.Context()
+	thread, err := c.tApp.RestoreThread(ctx, id)
+	if err != nil {
+		ResponseAndLogError(g, errors.Wrap(err, "failed to restore thread"))
+		return
+	}
+
+	g.JSON(http.StatusOK, thread)
+}
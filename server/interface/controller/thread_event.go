@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/sse"
+)
+
+// threadEventsPath is the path segment of thread events stream, which is "/threads/events".
+const threadEventsPath = "events"
+
+// keepAliveInterval is interval to send comment lines, which keep the connection alive through proxies.
+const keepAliveInterval = 30 * time.Second
+
+// ThreadEventController is the interface of ThreadEventController.
+type ThreadEventController interface {
+	StreamThreadEvents(g *gin.Context)
+}
+
+// threadEventController is the controller which streams changes of threads as Server-Sent Events.
+type threadEventController struct {
+	broker *sse.Broker
+}
+
+// NewThreadEventController generates and returns ThreadEventController.
+func NewThreadEventController(broker *sse.Broker) ThreadEventController {
+	return &threadEventController{
+		broker: broker,
+	}
+}
+
+// StreamThreadEvents streams changes of threads.
+// Events which the client missed are replayed when Last-Event-ID header is given,
+// or a reset event is sent when they can not be replayed.
+func (c *threadEventController) StreamThreadEvents(g *gin.Context) {
+	events, replay := c.broker.Subscribe(g.GetHeader("Last-Event-ID"))
+	defer c.broker.Unsubscribe(events)
+
+	g.Header("Content-Type", "text/event-stream")
+	g.Header("Cache-Control", "no-cache")
+	g.Header("Connection", "keep-alive")
+	g.Header("X-Accel-Buffering", "no")
+	g.Status(http.StatusOK)
+
+	for _, e := range replay {
+		if err := writeEvent(g.Writer, e); err != nil {
+			return
+		}
+	}
+	g.Writer.Flush()
+
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+
+	ctx := g.Request.Context()
+	g.Stream(func(w io.Writer) bool {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return false
+			}
+			return writeEvent(w, e) == nil
+		case <-ticker.C:
+			_, err := io.WriteString(w, ": keep-alive\n\n")
+			return err == nil
+		case <-ctx.Done():
+			return false
+		}
+	})
+}
+
+// writeEvent writes the event in the format of Server-Sent Events.
+func writeEvent(w io.Writer, e *sse.Event) error {
+	_, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, e.Data)
+	return err
+}
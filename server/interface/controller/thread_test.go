@@ -193,7 +193,7 @@ func Test_threadController_ListThreads(t *testing.T) {
 
 			at.EXPECT().ListThreads(context.Background(), tt.args.limit, tt.args.cursor).Return(tt.mockReturns.list, tt.mockReturns.err)
 
-			tc := NewThreadController(tt.fields.tApp)
+			tc := NewThreadController(tt.fields.tApp, nil)
 			r := gin.New()
 
 			r.GET("/threads", tc.ListThreads)
@@ -233,6 +233,62 @@ func Test_threadController_ListThreads(t *testing.T) {
 	}
 }
 
+// fakeThreadEventController is the ThreadEventController which records the streamed requests.
+type fakeThreadEventController struct {
+	streamed int
+}
+
+func (c *fakeThreadEventController) StreamThreadEvents(g *gin.Context) {
+	c.streamed++
+	g.Status(http.StatusOK)
+}
+
+func Test_threadController_InitThreadAPI_events(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		wantStreamed int
+	}{
+		{
+			name:         "When the path is /threads/events, streams the thread events",
+			path:         "/threads/events",
+			wantStreamed: 1,
+		},
+		{
+			name:         "When the path is a thread id, does not stream the thread events",
+			path:         "/threads/1",
+			wantStreamed: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := mock_application.NewMockThreadService(ctrl)
+			app.EXPECT().GetThread(gomock.Any(), gomock.Any()).Return(&model.Thread{}, nil).AnyTimes()
+
+			events := &fakeThreadEventController{}
+			r := gin.New()
+			NewThreadController(app, events).InitThreadAPI(r.Group("/threads"))
+
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.ServeHTTP(rec, req)
+
+			if rec.Code == http.StatusNotFound {
+				t.Errorf("status code = %v, want the route to be found", rec.Code)
+			}
+			if events.streamed != tt.wantStreamed {
+				t.Errorf("streamed = %d, want %d", events.streamed, tt.wantStreamed)
+			}
+		})
+	}
+}
+
 func Test_threadController_GetThread(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -362,7 +418,7 @@ func Test_threadController_GetThread(t *testing.T) {
 				at.EXPECT().GetThread(context.Background(), tt.args.id).Return(tt.mockReturns.thread, tt.mockReturns.err)
 			}
 
-			tc := NewThreadController(tt.fields.tApp)
+			tc := NewThreadController(tt.fields.tApp, nil)
 			r := gin.New()
 
 			r.GET("/threads/:id", tc.GetThread)
@@ -516,7 +572,7 @@ func Test_threadController_CreateThread(t *testing.T) {
 				at.EXPECT().CreateThread(context.Background(), tt.mockArg.thread).Return(tt.mockReturns.thread, tt.mockReturns.err)
 			}
 
-			tc := NewThreadController(tt.fields.tApp)
+			tc := NewThreadController(tt.fields.tApp, nil)
 			r := gin.New()
 
 			r.POST("/threads", tc.CreateThread)
@@ -709,7 +765,7 @@ func Test_threadController_UpdateThread(t *testing.T) {
 				at.EXPECT().UpdateThread(context.Background(), tt.args.id, tt.mockArg.thread).Return(tt.mockReturns.thread, tt.mockReturns.err)
 			}
 
-			tc := NewThreadController(tt.fields.tApp)
+			tc := NewThreadController(tt.fields.tApp, nil)
 			r := gin.New()
 
 			r.PUT("/threads/:id", tc.UpdateThread)
@@ -865,7 +921,7 @@ func Test_threadController_DeleteThread(t *testing.T) {
 				at.EXPECT().DeleteThread(context.Background(), tt.args.id).Return(tt.mockReturns.err)
 			}
 
-			tc := NewThreadController(tt.fields.tApp)
+			tc := NewThreadController(tt.fields.tApp, nil)
 			r := gin.New()
 
 			r.DELETE("/threads/:id", tc.DeleteThread)
@@ -899,3 +955,96 @@ func Test_threadController_DeleteThread(t *testing.T) {
 		})
 	}
 }
+
+func Test_threadController_RestoreThread(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testutil.SetFakeTime(time.Now())
+
+	restored := &model.Thread{
+		ID:        model.ThreadValidIDForTest,
+		Title:     model.TitleForTest,
+		User:      &model.User{ID: model.UserValidIDForTest, Name: model.UserNameForTest},
+		CreatedAt: testutil.TimeNow(),
+		UpdatedAt: testutil.TimeNow(),
+	}
+
+	type mockReturns struct {
+		thread *model.Thread
+		err    error
+	}
+
+	tests := []struct {
+		name        string
+		id          string
+		mockReturns mockReturns
+		statusCode  int
+		errCode     ErrCode
+	}{
+		{
+			name: "When the deleted thread is restored, returns it and status code 200",
+			id:   "1",
+			mockReturns: mockReturns{
+				thread: restored,
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "When inappropriate id is given, returns status code 400",
+			id:         "a",
+			statusCode: http.StatusBadRequest,
+			errCode:    InvalidParametersValueFailure,
+		},
+		{
+			name: "When the thread has not been deleted, returns status code 404",
+			id:   "1",
+			mockReturns: mockReturns{
+				err: &model.NoSuchDataError{
+					PropertyName:  model.IDProperty,
+					PropertyValue: "",
+				},
+			},
+			statusCode: http.StatusNotFound,
+			errCode:    NoSuchDataFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := mock_application.NewMockThreadService(ctrl)
+			if tt.statusCode != http.StatusBadRequest {
+				app.EXPECT().RestoreThread(context.Background(), model.ThreadValidIDForTest).Return(tt.mockReturns.thread, tt.mockReturns.err)
+			}
+
+			c := NewThreadController(app, nil)
+			r := gin.New()
+
+			r.POST("/threads/:threadId/restore", c.RestoreThread)
+
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/threads/%s/restore", tt.id), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.statusCode {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.statusCode)
+				return
+			}
+
+			if tt.errCode == "" {
+				got := &model.Thread{}
+				if err := json.Unmarshal(rec.Body.Bytes(), got); err != nil {
+					t.Fatal(err)
+				}
+				if got.ID != restored.ID {
+					t.Errorf("body = %#v, want %#v", got, restored)
+				}
+			} else if body := rec.Body.String(); !strings.Contains(body, string(tt.errCode)) {
+				t.Errorf("body = %#v, want %#v", body, tt.errCode)
+			}
+		})
+	}
+}
@@ -3,7 +3,7 @@ package controller
 import (
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
-	"gopkg.in/go-playground/validator.v8"
+	"gopkg.in/go-playground/validator.v9"
 )
 
 // type ValidationErrors map[string]*FieldError
@@ -20,8 +20,8 @@ func handleValidatorErr(err error) error {
 	for _, v := range errors {
 		e := &model.InvalidParamError{
 			BaseErr:       err,
-			PropertyName:  model.PropertyName(v.Field),
-			PropertyValue: v.Value,
+			PropertyName:  model.PropertyName(v.Field()),
+			PropertyValue: v.Value(),
 		}
 
 		errs.Errors = append(errs.Errors, e)
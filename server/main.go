@@ -1,80 +1,395 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"sync"
+
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/application"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/config"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/event"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/service"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/migration"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/eventbus"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/health"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/httpserver"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/memory"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/metrics"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/notifier"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/router"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/sse"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/tracing"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/ws"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/interface/controller"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/middleware"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/util"
+	"go.uber.org/zap"
 )
 
+// threadEventBufferSize is the number of thread events kept for replay.
+const threadEventBufferSize = 256
+
 func main() {
+	configPath := flag.String("config", os.Getenv(config.EnvPrefix+"_CONFIG"), "path of the config file")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	level, err := cfg.Log.ZapLevel()
+	if err != nil {
+		panic(err.Error())
+	}
+	logger.SetLevel(level)
+
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrate(cfg.DB, flag.Args()[1:], os.Stdout); err != nil {
+			logger.Logger.Error("failed to migrate", zap.String("error message", err.Error()))
+			os.Exit(1)
+		}
+		return
+	}
+
+	if flag.Arg(0) == "admin" {
+		if err := runAdmin(cfg.DB, flag.Args()[1:], os.Stdout); err != nil {
+			logger.Logger.Error("failed to run admin", zap.String("error message", err.Error()))
+			os.Exit(1)
+		}
+		return
+	}
+
+	if cfg.DB.AutoMigrate {
+		if err := autoMigrate(cfg.DB); err != nil {
+			panic(err.Error())
+		}
+	}
+
+	migrations, err := migration.Migrations()
+	if err != nil {
+		panic(err.Error())
+	}
+
+	dbm, err := db.NewDBManager(cfg.DB)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	trustedProxies, err := cfg.Server.TrustedProxyNets()
+	if err != nil {
+		panic(err.Error())
+	}
+
+	// the middleware must be used first, so that the others log with the request ID.
+	router.G.Use(middleware.RequestLogger())
+	// the middleware must be used before the rate limit, which is keyed on the client IP.
+	router.G.Use(middleware.ResolveClient(trustedProxies))
+
+	if cfg.Metrics.Enabled {
+		metrics.Registry.MustRegister(metrics.NewDBStatsCollector(dbm.Stats))
+		dbm = metrics.NewDBManager(dbm)
+
+		// the middleware must be used before the routes are added to measure them.
+		router.G.Use(middleware.Metrics())
+		router.G.GET(cfg.Metrics.Path, middleware.RequireMetricsToken(cfg.Metrics.Token), gin.WrapH(metrics.Handler()))
+	}
+
+	shutdownTracing, err := tracing.Setup(cfg.Tracing)
+	if err != nil {
+		panic(err.Error())
+	}
+	if cfg.Tracing.Enabled() {
+		dbm = tracing.NewDBManager(dbm)
+	}
+
+	checker := health.NewChecker(cfg.Health.Timeout)
+	checker.Add("db", dbm.PingContext)
+	checker.Add("migrations", func(ctx context.Context) error {
+		return migration.Check(ctx, dbm, migrations)
+	})
+
+	// the probes are added before the rate limit is used, so that they are not limited.
+	hc := controller.NewHealthController(checker)
+	hc.InitHealthAPI(&router.G.RouterGroup)
+
+	// the middleware is used after the probes and the metrics are added, so that they are not traced.
+	if cfg.Tracing.Enabled() {
+		router.G.Use(middleware.Tracing())
+	}
+
+	limits := cfg.RateLimit.Limits()
+	limiter := memory.NewRateLimitStore()
+
+	// the middleware must be used before the groups are made, which copy the middleware of the engine.
+	router.G.Use(middleware.RateLimit(limiter, "global", limits.Global))
+
 	apiV1 := router.G.Group("/v1")
 
-	dbm := db.NewDBManager()
-	ac := initializeAuthenticationController(dbm)
-	ac.InitAuthenticationAPI(apiV1)
+	expiry := cfg.Session.Expiry()
+	cookie := controller.CookieConfig{
+		Domain:   cfg.Cookie.Domain,
+		Secure:   cfg.Cookie.Secure,
+		SameSite: cfg.Cookie.SameSiteMode(),
+	}
+
+	passwordPolicy, err := loadPasswordPolicy(cfg.Password)
+	if err != nil {
+		panic(err.Error())
+	}
+	hasher := cfg.Password.PasswordHasher()
+
+	// the middleware of the groups which require authentication.
+	authenticated := []gin.HandlerFunc{
+		middleware.CheckAuthentication(dbm, expiry, cookie),
+		middleware.RateLimit(limiter, "user", limits.User),
+		middleware.CheckCSRF(cookie),
+	}
+
+	bus := eventbus.NewMemoryBus()
+	metrics.SubscribeDomainEvents(bus)
+
+	hub := ws.NewHub()
+	hub.Listen(bus)
+
+	authenticationRouting := apiV1.Group("", middleware.RateLimit(limiter, "authentication", limits.Authentication))
+
+	// login and password change share the attempts, so that the failures of the client IP are counted across them.
+	laService := initializeLoginAttemptService(cfg.LoginAttempt)
+
+	ac := initializeAuthenticationController(dbm, bus, expiry, cookie, passwordPolicy, hasher, laService)
+	ac.InitAuthenticationAPI(authenticationRouting)
+
+	logoutRouting := apiV1.Group("")
+	logoutRouting.Use(authenticated...)
+	ac.InitLogoutAPI(logoutRouting)
+
+	// the background workers are stopped after the server has shut down, and before the db is closed.
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+
+	reaper := application.NewSessionReaper(dbm, db.NewSessionRepository(), expiry, application.DefaultSessionReapInterval, application.DefaultSessionReapBatchSize)
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		reaper.Run(workerCtx)
+	}()
+
+	purger := application.NewContentPurger(dbm, db.NewThreadRepository(), db.NewCommentRepository(), cfg.SoftDelete.Retention, cfg.SoftDelete.PurgeInterval, cfg.SoftDelete.PurgeBatchSize)
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		purger.Run(workerCtx)
+	}()
+
+	sessionRouting := apiV1.Group("/sessions")
+	sessionRouting.Use(authenticated...)
+
+	sc := initializeSessionController(dbm, expiry, cookie)
+	sc.InitSessionAPI(sessionRouting)
+
+	apiTokenRouting := apiV1.Group("/apiTokens")
+	apiTokenRouting.Use(authenticated...)
+
+	atc := controller.NewAPITokenController(application.NewAPITokenServiceWithTracing(application.NewAPITokenService(dbm, db.NewAPITokenRepository())))
+	atc.InitAPITokenAPI(apiTokenRouting)
+
+	pApp := initializePasswordService(dbm, passwordPolicy, hasher, laService)
+
+	pc := controller.NewPasswordController(pApp)
+	pc.InitPasswordResetAPI(authenticationRouting)
+
+	passwordRouting := apiV1.Group("/password")
+	passwordRouting.Use(authenticated...)
+	pc.InitPasswordAPI(passwordRouting)
 
 	threadRouting := apiV1.Group("/threads")
 
 	// use middleware
-	threadRouting.Use(middleware.CheckAuthentication())
+	threadRouting.Use(authenticated...)
+	threadRouting.Use(middleware.RateLimitWrites(limiter, "post", limits.Post))
 
-	cc := initializeCommentController(dbm)
+	cc := initializeCommentController(dbm, bus)
 	cc.InitCommentAPI(threadRouting)
 
-	tc := initializeThreadController(dbm)
+	csc := initializeCommentStreamController(dbm, bus, hub)
+	csc.InitCommentStreamAPI(threadRouting)
+
+	broker := sse.NewBroker(threadEventBufferSize)
+	broker.Listen(bus)
+
+	tc := initializeThreadController(dbm, bus, broker)
 	tc.InitThreadAPI(threadRouting)
 
+	adminRouting := apiV1.Group("/admin")
+	adminRouting.Use(authenticated...)
+	adminRouting.Use(middleware.RequireRole(model.RoleAdmin))
+
+	adc := initializeAdminController(dbm, bus, pApp)
+	adc.InitAdminAPI(adminRouting)
+
 	router.G.NoRoute(func(g *gin.Context) {
-		g.File("./../client/nuxt-vue-go-chat/dist/index.html")
+		g.File(filepath.Join(cfg.Server.ClientDir, "index.html"))
 	})
-	router.G.Static("/_nuxt", "./../client/nuxt-vue-go-chat/dist/_nuxt/")
+	router.G.Static("/_nuxt", filepath.Join(cfg.Server.ClientDir, "_nuxt"))
 
-	if err := router.G.Run(":8080"); err != nil {
-		panic(err.Error())
+	ctx, stop := httpserver.SignalContext(context.Background())
+	defer stop()
+
+	srv := httpserver.New(cfg.Server, router.G)
+	// the websockets are hijacked and the event streams do not end by themselves, so that they are closed on shutdown.
+	srv.RegisterOnShutdown(hub.Close)
+	srv.RegisterOnShutdown(broker.Close)
+
+	runErr := httpserver.Run(ctx, srv, cfg.Server, checker.Drain)
+	if runErr != nil {
+		logger.Logger.Error("failed to run server", zap.String("error message", runErr.Error()))
+	}
+
+	stopWorkers()
+	workers.Wait()
+
+	tracingCtx, cancelTracing := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	if err := shutdownTracing(tracingCtx); err != nil {
+		logger.Logger.Error("failed to shut down tracing", zap.String("error message", err.Error()))
+	}
+	cancelTracing()
+
+	if err := dbm.Close(); err != nil {
+		logger.Logger.Error("failed to close db", zap.String("error message", err.Error()))
+	}
+
+	logger.Logger.Info("server has shut down")
+
+	if runErr != nil {
+		os.Exit(1)
 	}
 }
 
 // initializeAuthenticationController generates and returns AuthenticationController.
-func initializeAuthenticationController(m query.DBManager) controller.AuthenticationController {
+func initializeAuthenticationController(m query.DBManager, events event.Publisher, expiry model.SessionExpiry, cookie controller.CookieConfig, passwordPolicy model.PasswordPolicy, hasher util.PasswordHasher, laService service.LoginAttemptService) controller.AuthenticationController {
 	txCloser := db.CloseTransaction
 
 	uRepo := db.NewUserRepository()
 	sRepo := db.NewSessionRepository()
-	uService := service.NewUserService(m, uRepo)
-	sService := service.NewSessionService(sRepo)
-	aService := service.NewAuthenticationService(uRepo)
+	uService := service.NewUserService(m, uRepo, model.DefaultUserNamePolicy(), passwordPolicy, hasher)
+	sService := service.NewSessionService(sRepo, expiry)
+	aService := service.NewAuthenticationService(uRepo, hasher)
+	di := application.NewAuthenticationServiceDIInput(uRepo, sRepo, uService, sService, aService, laService)
+	aApp := application.NewAuthenticationServiceWithTracing(application.NewAuthenticationServiceWithMetrics(application.NewAuthenticationService(m, di, events, txCloser)))
 
-	di := application.NewAuthenticationServiceDIInput(uRepo, sRepo, uService, sService, aService)
-	aApp := application.NewAuthenticationService(m, di, txCloser)
+	return controller.NewAuthenticationController(aApp, expiry, cookie)
+}
 
-	return controller.NewAuthenticationController(aApp)
+// initializeLoginAttemptService generates and returns LoginAttemptService of the store in the config.
+func initializeLoginAttemptService(loginAttempt config.LoginAttempt) service.LoginAttemptService {
+	laRepo := db.NewLoginAttemptRepository()
+	if loginAttempt.Store == config.StoreMemory {
+		laRepo = memory.NewLoginAttemptRepository()
+	}
+	return service.NewLoginAttemptService(laRepo, loginAttempt.User.Policy(), loginAttempt.IP.Policy())
 }
 
-// initializeThreadCController generates and returns ThreadCController.
-func initializeThreadController(m query.DBManager) controller.ThreadController {
+// initializeSessionController generates and returns SessionController.
+func initializeSessionController(m query.DBManager, expiry model.SessionExpiry, cookie controller.CookieConfig) controller.SessionController {
+	txCloser := db.CloseTransaction
+
+	sRepo := db.NewSessionRepository()
+	sApp := application.NewSessionServiceWithTracing(application.NewSessionService(m, sRepo, expiry, txCloser))
+
+	return controller.NewSessionController(sApp, cookie)
+}
+
+// initializeThreadService generates and returns ThreadService.
+func initializeThreadService(m query.DBManager, events event.Publisher) application.ThreadService {
 	txCloser := db.CloseTransaction
 
 	tRepo := db.NewThreadRepository()
+	cRepo := db.NewCommentRepository()
 	tService := service.NewThreadService(tRepo)
+	policy := service.NewAuthorizationPolicy(service.AllowRoles(model.RoleModerator, model.RoleAdmin))
+
+	return application.NewThreadServiceWithTracing(application.NewThreadService(m, tService, tRepo, cRepo, policy, events, txCloser))
+}
 
-	tApp := application.NewThreadService(m, tService, tRepo, txCloser)
+// initializeThreadCController generates and returns ThreadCController.
+func initializeThreadController(m query.DBManager, events event.Publisher, broker *sse.Broker) controller.ThreadController {
+	tec := controller.NewThreadEventController(broker)
+
+	return controller.NewThreadController(initializeThreadService(m, events), tec)
+}
 
-	return controller.NewThreadController(tApp)
+// initializeCommentStreamController generates and returns CommentStreamController.
+func initializeCommentStreamController(m query.DBManager, events event.Publisher, hub *ws.Hub) controller.CommentStreamController {
+	return controller.NewCommentStreamController(hub, initializeThreadService(m, events))
 }
 
 // initializeCommentController generates and returns CommentController.
-func initializeCommentController(m query.DBManager) controller.CommentController {
+func initializeCommentController(m query.DBManager, events event.Publisher) controller.CommentController {
 	txCloser := db.CloseTransaction
 
 	cRepo := db.NewCommentRepository()
+	tRepo := db.NewThreadRepository()
+	crRepo := db.NewCommentRevisionRepository()
 	cService := service.NewCommentService(cRepo)
+	policy := service.NewAuthorizationPolicy(service.AllowRoles(model.RoleModerator, model.RoleAdmin))
 
-	cApp := application.NewCommentService(m, cService, cRepo, txCloser)
+	cApp := application.NewCommentServiceWithTracing(application.NewCommentService(m, cService, cRepo, tRepo, crRepo, policy, events, txCloser))
 
 	return controller.NewCommentController(cApp)
 }
+
+// initializeAdminController generates and returns AdminController.
+func initializeAdminController(m query.DBManager, events event.Publisher, pApp application.PasswordService) controller.AdminController {
+	txCloser := db.CloseTransaction
+
+	uRepo := db.NewUserRepository()
+	aApp := application.NewAdminServiceWithTracing(application.NewAdminService(m, uRepo, events, txCloser))
+
+	return controller.NewAdminController(aApp, pApp)
+}
+
+// initializePasswordService generates and returns PasswordService.
+func initializePasswordService(m query.DBManager, passwordPolicy model.PasswordPolicy, hasher util.PasswordHasher, laService service.LoginAttemptService) application.PasswordService {
+	txCloser := db.CloseTransaction
+
+	uRepo := db.NewUserRepository()
+	sRepo := db.NewSessionRepository()
+	tRepo := db.NewPasswordResetTokenRepository()
+	atRepo := db.NewAPITokenRepository()
+	uService := service.NewUserService(m, uRepo, model.DefaultUserNamePolicy(), passwordPolicy, hasher)
+
+	di := application.NewPasswordServiceDIInput(uRepo, sRepo, tRepo, atRepo, uService, laService, notifier.NewLogNotifier(), model.DefaultPasswordResetTokenTTL)
+	return application.NewPasswordServiceWithTracing(application.NewPasswordService(m, di, txCloser))
+}
+
+// loadPasswordPolicy returns PasswordPolicy with the deny list read from the file.
+func loadPasswordPolicy(c config.Password) (model.PasswordPolicy, error) {
+	policy := c.Policy()
+
+	f, err := os.Open(c.DenyListPath)
+	if err != nil {
+		return policy, errors.Wrap(err, "failed to open password deny list")
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			logger.Logger.Error("failed to close password deny list", zap.String("error message", err.Error()))
+		}
+	}()
+
+	denyList, err := model.ReadPasswordDenyList(f)
+	if err != nil {
+		return policy, errors.Wrap(err, "failed to read password deny list")
+	}
+	policy.DenyList = denyList
+
+	return policy, nil
+}
@@ -1,31 +1,139 @@
 package middleware
 
 import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/query"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
 	"github.com/sekky0905/nuxt-vue-go-chat/server/interface/controller"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/util"
+	"go.uber.org/zap"
 )
 
-// CheckAuthentication checks authentication of user who requested.
-func CheckAuthentication() gin.HandlerFunc {
+// bearerScheme is the scheme of Authorization header for API tokens.
+const bearerScheme = "Bearer"
+
+// CheckAuthentication checks authentication of user who requested
+// and binds the session and the user into the context of the request.
+// The user is authenticated by the API token in Authorization header if it is given, otherwise by the session cookie.
+// The session which has expired is deleted, and the session which is alive is renewed.
+func CheckAuthentication(m query.DBManager, expiry model.SessionExpiry, cookie controller.CookieConfig) gin.HandlerFunc {
 	return func(g *gin.Context) {
-		id, err := g.Cookie(model.SessionIDAtCookie)
-		if err != nil {
+		ctx := g.Request.Context()
+		now := time.Now()
+
+		var session *model.Session
+		var userID uint32
+		if token, ok := bearerToken(g.Request); ok {
+			apiToken, err := authenticateAPIToken(ctx, m, token, now)
+			if err != nil {
+				controller.ResponseAndLogError(g, err)
+				g.Abort()
+				return
+			}
+			userID = apiToken.UserID
+		} else {
+			s, err := authenticateSession(g, m, expiry, cookie, now)
+			if err != nil {
+				controller.ResponseAndLogError(g, err)
+				g.Abort()
+				return
+			}
+			session = s
+			userID = s.UserID
+		}
+
+		user, err := db.NewUserRepository().GetUserByID(ctx, m, userID)
+		if err != nil || user == nil {
 			controller.ResponseAndLogError(g, &model.AuthenticationErr{})
 			g.Abort()
 			return
 		}
 
-		ctx := g.Request.Context()
-		repo := db.NewSessionRepository()
-		m := db.NewDBManager()
-		session, err := repo.GetSessionByID(ctx, m, id)
-		if err != nil || session == nil {
-			controller.ResponseAndLogError(g, &model.AuthenticationErr{})
+		if user.Banned {
+			controller.ResponseAndLogError(g, &model.BannedError{UserID: user.ID})
 			g.Abort()
 			return
 		}
+
+		if session != nil {
+			if session.ShouldRenew(now, expiry) {
+				if err := db.NewSessionRepository().RenewSession(ctx, m, session.ID, now); err != nil {
+					logger.FromContext(ctx).Warn("failed to renew session", zap.String("error message", err.Error()))
+				} else {
+					session.LastAccessedAt = now
+					controller.SetSessionCookie(g, cookie, session.ID, expiry)
+				}
+			}
+
+			ctx = model.WithSession(ctx, session)
+		}
+
+		ctx = model.WithUser(ctx, user)
+		ctx = logger.With(ctx, zap.Uint32("userID", user.ID))
+		g.Request = g.Request.WithContext(ctx)
+
 		g.Next()
 	}
 }
+
+// bearerToken returns the token in Authorization header of the request.
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if len(header) <= len(bearerScheme) || !strings.EqualFold(header[:len(bearerScheme)], bearerScheme) || header[len(bearerScheme)] != ' ' {
+		return "", false
+	}
+
+	token := strings.TrimSpace(header[len(bearerScheme)+1:])
+	return token, token != ""
+}
+
+// authenticateAPIToken returns the API token specified by the token.
+func authenticateAPIToken(ctx context.Context, m query.SQLManager, token string, now time.Time) (*model.APIToken, error) {
+	repo := db.NewAPITokenRepository()
+	apiToken, err := repo.GetAPITokenByHash(ctx, m, util.HashToken(token))
+	if err != nil || apiToken == nil {
+		return nil, &model.AuthenticationErr{}
+	}
+
+	if apiToken.ShouldTouch(now) {
+		if err := repo.TouchAPIToken(ctx, m, apiToken.ID, now); err != nil {
+			logger.FromContext(ctx).Warn("failed to touch api token", zap.String("error message", err.Error()))
+		}
+	}
+
+	return apiToken, nil
+}
+
+// authenticateSession returns the session specified by the session cookie.
+// The session which has expired is deleted with the cookie.
+func authenticateSession(g *gin.Context, m query.SQLManager, expiry model.SessionExpiry, cookie controller.CookieConfig, now time.Time) (*model.Session, error) {
+	id, err := g.Cookie(model.SessionIDAtCookie)
+	if err != nil {
+		return nil, &model.AuthenticationErr{}
+	}
+
+	ctx := g.Request.Context()
+	repo := db.NewSessionRepository()
+	session, err := repo.GetSessionByID(ctx, m, id)
+	if err != nil || session == nil {
+		return nil, &model.AuthenticationErr{}
+	}
+
+	if session.IsExpired(now, expiry) {
+		if err := repo.DeleteSession(ctx, m, session.ID); err != nil {
+			logger.FromContext(ctx).Warn("failed to delete expired session", zap.String("error message", err.Error()))
+		}
+
+		controller.ClearSessionCookie(g, cookie)
+		return nil, &model.SessionExpiredError{SessionID: session.ID}
+	}
+
+	return session, nil
+}
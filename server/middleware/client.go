@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/interface/controller"
+)
+
+// forwarded headers which are set by the proxies.
+const (
+	forwardedForHeader = "X-Forwarded-For"
+	realIPHeader       = "X-Real-Ip"
+)
+
+// ResolveClient binds the client which requested into the context of the request.
+// The IP is taken from X-Forwarded-For or X-Real-Ip only when the remote address is one of trustedProxies,
+// because the client can set them to anything.
+// It must be used before the middleware which use the IP, e.g. RateLimit.
+func ResolveClient(trustedProxies []*net.IPNet) gin.HandlerFunc {
+	return func(g *gin.Context) {
+		client := &model.Client{
+			UserAgent: g.Request.UserAgent(),
+			IP:        clientIP(g, trustedProxies),
+		}
+		g.Request = g.Request.WithContext(model.WithClient(g.Request.Context(), client))
+
+		g.Next()
+	}
+}
+
+// clientIP returns the IP of the client.
+// It is the rightmost address of X-Forwarded-For which is not a trusted proxy,
+// because the addresses on the left of it have been given by the client.
+func clientIP(g *gin.Context, trustedProxies []*net.IPNet) string {
+	remote := controller.RemoteIP(g.Request)
+	if !isTrustedProxy(remote, trustedProxies) {
+		return remote
+	}
+
+	if forwarded := g.GetHeader(forwardedForHeader); forwarded != "" {
+		addrs := strings.Split(forwarded, ",")
+		ip := remote
+		for i := len(addrs) - 1; i >= 0; i-- {
+			addr := strings.TrimSpace(addrs[i])
+			if net.ParseIP(addr) == nil {
+				break
+			}
+			ip = addr
+			if !isTrustedProxy(addr, trustedProxies) {
+				break
+			}
+		}
+		return ip
+	}
+
+	if realIP := strings.TrimSpace(g.GetHeader(realIPHeader)); net.ParseIP(realIP) != nil {
+		return realIP
+	}
+
+	return remote
+}
+
+// isTrustedProxy returns whether ip is one of trustedProxies.
+func isTrustedProxy(ip string, trustedProxies []*net.IPNet) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+
+	for _, proxy := range trustedProxies {
+		if proxy.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
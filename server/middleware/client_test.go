@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+)
+
+func TestResolveClient(t *testing.T) {
+	_, proxies, err := net.ParseCIDR("10.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		header     map[string]string
+		want       string
+	}{
+		{
+			name:       "When the remote address is not a trusted proxy, ignores X-Forwarded-For",
+			remoteAddr: model.IPForTest + ":1234",
+			header:     map[string]string{forwardedForHeader: "198.51.100.1"},
+			want:       model.IPForTest,
+		},
+		{
+			name:       "When the remote address is not a trusted proxy, ignores X-Real-Ip",
+			remoteAddr: model.IPForTest + ":1234",
+			header:     map[string]string{realIPHeader: "198.51.100.1"},
+			want:       model.IPForTest,
+		},
+		{
+			name:       "When the remote address is a trusted proxy, returns the rightmost address which is not a trusted proxy",
+			remoteAddr: "10.0.0.1:1234",
+			header:     map[string]string{forwardedForHeader: "198.51.100.1, " + model.IPForTest + ", 10.0.0.2"},
+			want:       model.IPForTest,
+		},
+		{
+			name:       "When all of X-Forwarded-For are trusted proxies, returns the leftmost one",
+			remoteAddr: "10.0.0.1:1234",
+			header:     map[string]string{forwardedForHeader: "10.0.0.3, 10.0.0.2"},
+			want:       "10.0.0.3",
+		},
+		{
+			name:       "When X-Forwarded-For has an invalid address, returns the address on the right of it",
+			remoteAddr: "10.0.0.1:1234",
+			header:     map[string]string{forwardedForHeader: "unknown, 10.0.0.2"},
+			want:       "10.0.0.2",
+		},
+		{
+			name:       "When the remote address is a trusted proxy without X-Forwarded-For, returns X-Real-Ip",
+			remoteAddr: "10.0.0.1:1234",
+			header:     map[string]string{realIPHeader: model.IPForTest},
+			want:       model.IPForTest,
+		},
+		{
+			name:       "When the remote address is a trusted proxy without the headers, returns the remote address",
+			remoteAddr: "10.0.0.1:1234",
+			want:       "10.0.0.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *model.Client
+
+			r := gin.New()
+			r.Use(ResolveClient([]*net.IPNet{proxies}))
+			r.GET("/threads", func(g *gin.Context) {
+				got, _ = model.ClientFromContext(g.Request.Context())
+				g.JSON(http.StatusOK, nil)
+			})
+
+			req, err := http.NewRequest(http.MethodGet, "/threads", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.RemoteAddr = tt.remoteAddr
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got == nil {
+				t.Fatal("client is not bound into the context")
+			}
+			if got.IP != tt.want {
+				t.Errorf("client IP = %s, want %s", got.IP, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/interface/controller"
+	"go.uber.org/zap"
+)
+
+// CheckCSRF checks the CSRF token of the state-changing request by the double-submit cookie pattern,
+// i.e. the token in the header must match the token in the cookie, which other sites can not read.
+// It must be used after CheckAuthentication, and the request which is authenticated by API token is exempt
+// because it does not carry the credentials which browsers send automatically.
+// The token is issued again on the safe request if the cookie has been lost.
+func CheckCSRF(cookie controller.CookieConfig) gin.HandlerFunc {
+	return func(g *gin.Context) {
+		if _, ok := model.SessionFromContext(g.Request.Context()); !ok {
+			g.Next()
+			return
+		}
+
+		token, err := g.Cookie(model.CSRFTokenAtCookie)
+		if err != nil {
+			token = ""
+		}
+
+		if isSafeMethod(g.Request.Method) {
+			if token == "" {
+				if _, err := controller.SetCSRFCookie(g, cookie); err != nil {
+					logger.FromContext(g.Request.Context()).Warn("failed to issue csrf token", zap.String("error message", err.Error()))
+				}
+			}
+
+			g.Next()
+			return
+		}
+
+		header := g.GetHeader(model.CSRFTokenHeader)
+		if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(header)) != 1 {
+			controller.ResponseAndLogError(g, &model.CSRFError{})
+			g.Abort()
+			return
+		}
+
+		g.Next()
+	}
+}
+
+// isSafeMethod returns whether the method does not change state or not.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
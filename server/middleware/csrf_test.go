@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mock_application "github.com/sekky0905/nuxt-vue-go-chat/server/application/mock"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/interface/controller"
+)
+
+func TestCheckCSRF(t *testing.T) {
+	session := &model.Session{
+		ID:     model.SessionValidIDForTest,
+		UserID: model.UserValidIDForTest,
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		withSession bool
+		cookie      string
+		header      string
+		statusCode  int
+		issueToken  bool
+	}{
+		{
+			name:        "When the token in the header matches the cookie, the request passes",
+			method:      http.MethodPost,
+			withSession: true,
+			cookie:      "token",
+			header:      "token",
+			statusCode:  http.StatusOK,
+		},
+		{
+			name:        "When the token in the header does not match the cookie, returns status code 403",
+			method:      http.MethodDelete,
+			withSession: true,
+			cookie:      "token",
+			header:      "other",
+			statusCode:  http.StatusForbidden,
+		},
+		{
+			name:        "When the token is missing, returns status code 403",
+			method:      http.MethodPut,
+			withSession: true,
+			statusCode:  http.StatusForbidden,
+		},
+		{
+			name:        "When the request is authenticated by API token, the request passes without the token",
+			method:      http.MethodPost,
+			withSession: false,
+			statusCode:  http.StatusOK,
+		},
+		{
+			name:        "When the safe request has no token, the request passes and the token is issued",
+			method:      http.MethodGet,
+			withSession: true,
+			statusCode:  http.StatusOK,
+			issueToken:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			if tt.withSession {
+				r.Use(func(g *gin.Context) {
+					g.Request = g.Request.WithContext(model.WithSession(g.Request.Context(), session))
+					g.Next()
+				})
+			}
+			r.Use(CheckCSRF(controller.DefaultCookieConfig()))
+			r.Handle(tt.method, "/threads", func(g *gin.Context) {
+				g.JSON(http.StatusOK, nil)
+			})
+
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest(tt.method, "/threads", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: model.CSRFTokenAtCookie, Value: tt.cookie})
+			}
+			if tt.header != "" {
+				req.Header.Set(model.CSRFTokenHeader, tt.header)
+			}
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.statusCode {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.statusCode)
+			}
+
+			issued := strings.HasPrefix(rec.Header().Get("Set-Cookie"), model.CSRFTokenAtCookie+"=")
+			if issued != tt.issueToken {
+				t.Errorf("token issued = %v, want %v", issued, tt.issueToken)
+			}
+		})
+	}
+}
+
+func TestCheckCSRF_logout(t *testing.T) {
+	session := &model.Session{
+		ID:     model.SessionValidIDForTest,
+		UserID: model.UserValidIDForTest,
+	}
+
+	tests := []struct {
+		name       string
+		header     string
+		statusCode int
+		logout     int
+	}{
+		{
+			name:       "When the token in the header matches the cookie, logs out",
+			header:     "token",
+			statusCode: http.StatusOK,
+			logout:     1,
+		},
+		{
+			name:       "When the token is missing, returns status code 403 and does not log out",
+			statusCode: http.StatusForbidden,
+			logout:     0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			aa := mock_application.NewMockAuthenticationService(ctrl)
+			aa.EXPECT().Logout(gomock.Any(), model.SessionValidIDForTest).Return(nil).Times(tt.logout)
+
+			r := gin.New()
+			logoutRouting := r.Group("")
+			logoutRouting.Use(func(g *gin.Context) {
+				// binds the session as CheckAuthentication does.
+				g.Request = g.Request.WithContext(model.WithSession(g.Request.Context(), session))
+				g.Next()
+			})
+			logoutRouting.Use(CheckCSRF(controller.DefaultCookieConfig()))
+
+			ac := controller.NewAuthenticationController(aa, model.DefaultSessionExpiry(), controller.DefaultCookieConfig())
+			ac.InitLogoutAPI(logoutRouting)
+
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodDelete, "/logout", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.AddCookie(&http.Cookie{Name: model.SessionIDAtCookie, Value: model.SessionValidIDForTest})
+			req.AddCookie(&http.Cookie{Name: model.CSRFTokenAtCookie, Value: "token"})
+			if tt.header != "" {
+				req.Header.Set(model.CSRFTokenHeader, tt.header)
+			}
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.statusCode {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
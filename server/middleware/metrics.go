@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/metrics"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/interface/controller"
+)
+
+// unmatchedRoute is the route label of the requests which match no route,
+// so that the arbitrary paths do not make the labels unbounded.
+const unmatchedRoute = "unmatched"
+
+// Metrics records the count and the latency of the requests per route and status.
+// The route is the registered path, e.g. /v1/threads/:threadId.
+func Metrics() gin.HandlerFunc {
+	return func(g *gin.Context) {
+		start := time.Now()
+		g.Next()
+
+		metrics.ObserveHTTPRequest(g.Request.Method, matchedRoute(g), g.Writer.Status(), time.Since(start))
+	}
+}
+
+// RequireMetricsToken refuses the requests which do not have token in Authorization header as the bearer token,
+// because the metrics expose the internals of the server. Nothing is refused when token is empty.
+func RequireMetricsToken(token string) gin.HandlerFunc {
+	return func(g *gin.Context) {
+		if token == "" {
+			g.Next()
+			return
+		}
+
+		given, ok := bearerToken(g.Request)
+		if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			controller.ResponseAndLogError(g, &model.AuthenticationErr{})
+			g.Abort()
+			return
+		}
+
+		g.Next()
+	}
+}
+
+// matchedRoute returns the registered path of the route which the request matches, or unmatchedRoute.
+func matchedRoute(g *gin.Context) string {
+	if route := g.FullPath(); route != "" {
+		return route
+	}
+	return unmatchedRoute
+}
@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/metrics"
+)
+
+func Test_matchedRoute(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{
+			name: "When there are no params, returns the path",
+			path: "/v1/threads",
+			want: "/v1/threads",
+		},
+		{
+			name: "When there are params, returns the path with their names",
+			path: "/v1/threads/1/comments/2",
+			want: "/v1/threads/:threadId/comments/:id",
+		},
+		{
+			name: "When the value of the param equals a static segment, returns the path with its name",
+			path: "/v1/threads/v1/comments/threads",
+			want: "/v1/threads/:threadId/comments/:id",
+		},
+		{
+			name: "When there is a catch-all param, returns the path with its name",
+			path: "/_nuxt/js/app.js",
+			want: "/_nuxt/*filepath",
+		},
+		{
+			name: "When the request matches no route, returns unmatched",
+			path: "/no-such-route/1",
+			want: unmatchedRoute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			record := func(g *gin.Context) {
+				got = matchedRoute(g)
+			}
+
+			r := gin.New()
+			r.GET("/v1/threads", record)
+			r.GET("/v1/threads/:threadId/comments/:id", record)
+			r.GET("/_nuxt/*filepath", record)
+			r.NoRoute(record)
+
+			req, err := http.NewRequest(http.MethodGet, tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("matchedRoute() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	r := gin.New()
+	r.Use(Metrics())
+	r.GET("/metrics-test/:id", func(g *gin.Context) {
+		g.Status(http.StatusNoContent)
+	})
+	r.NoRoute(func(g *gin.Context) {
+		g.Status(http.StatusNotFound)
+	})
+
+	for _, path := range []string{"/metrics-test/1", "/metrics-test/2", "/no-such-route/1"} {
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/metrics", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metrics.Handler().ServeHTTP(rec, req)
+
+	body, err := ioutil.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`nvgc_http_requests_total{method="GET",route="/metrics-test/:id",status="204"} 2`,
+		`nvgc_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`nvgc_http_request_duration_seconds_count{method="GET",route="/metrics-test/:id",status="204"} 2`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics do not contain %s", want)
+		}
+	}
+}
+
+func TestRequireMetricsToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		statusCode    int
+	}{
+		{
+			name:       "When the token is not configured, the metrics are public",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:          "When the token is given, returns the metrics",
+			token:         "secret",
+			authorization: "Bearer secret",
+			statusCode:    http.StatusOK,
+		},
+		{
+			name:          "When the token is wrong, returns 401",
+			token:         "secret",
+			authorization: "Bearer wrong",
+			statusCode:    http.StatusUnauthorized,
+		},
+		{
+			name:       "When the token is not given, returns 401",
+			token:      "secret",
+			statusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/metrics", RequireMetricsToken(tt.token), func(g *gin.Context) {
+				g.Status(http.StatusOK)
+			})
+
+			req, err := http.NewRequest(http.MethodGet, "/metrics", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.statusCode {
+				t.Errorf("status code = %v, want %v", rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
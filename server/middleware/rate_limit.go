@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/repository"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/interface/controller"
+	"go.uber.org/zap"
+)
+
+// headers of rate limit.
+const (
+	rateLimitLimitHeader     = "X-RateLimit-Limit"
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	rateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// RateLimit limits the requests by token bucket of the store.
+// The bucket is keyed by name and the authenticated user ID, or the client IP if not authenticated,
+// so it must be used after CheckAuthentication to limit per user.
+// The requests are allowed if the store fails, so that the store is not a single point of failure.
+func RateLimit(store repository.RateLimitStore, name string, limit model.RateLimit) gin.HandlerFunc {
+	return func(g *gin.Context) {
+		key := rateLimitKey(g, name)
+
+		status, err := store.Take(g.Request.Context(), key, limit, time.Now())
+		if err != nil {
+			logger.FromContext(g.Request.Context()).Warn("failed to take rate limit token", zap.String("error message", err.Error()))
+			g.Next()
+			return
+		}
+
+		g.Header(rateLimitLimitHeader, strconv.Itoa(status.Limit))
+		g.Header(rateLimitRemainingHeader, strconv.Itoa(status.Remaining))
+		g.Header(rateLimitResetHeader, strconv.FormatInt(status.ResetAt.Unix(), 10))
+
+		if !status.Allowed {
+			controller.ResponseAndLogError(g, &model.RateLimitError{RetryAfter: status.RetryAfter})
+			g.Abort()
+			return
+		}
+
+		g.Next()
+	}
+}
+
+// RateLimitWrites limits only the state-changing requests as RateLimit.
+func RateLimitWrites(store repository.RateLimitStore, name string, limit model.RateLimit) gin.HandlerFunc {
+	limiter := RateLimit(store, name, limit)
+
+	return func(g *gin.Context) {
+		if isSafeMethod(g.Request.Method) {
+			g.Next()
+			return
+		}
+
+		limiter(g)
+	}
+}
+
+// rateLimitKey returns the key of the bucket of the request.
+func rateLimitKey(g *gin.Context, name string) string {
+	if user, ok := model.UserFromContext(g.Request.Context()); ok {
+		return fmt.Sprintf("%s:user:%d", name, user.ID)
+	}
+
+	return fmt.Sprintf("%s:ip:%s", name, controller.ClientIP(g))
+}
@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/memory"
+)
+
+func TestRateLimit(t *testing.T) {
+	limit := model.RateLimit{Limit: 2, Period: time.Minute}
+
+	r := gin.New()
+	r.Use(func(g *gin.Context) {
+		if g.GetHeader("X-Test-User") != "" {
+			user := &model.User{ID: model.UserValidIDForTest}
+			g.Request = g.Request.WithContext(model.WithUser(g.Request.Context(), user))
+		}
+		g.Next()
+	})
+	r.Use(RateLimit(memory.NewRateLimitStore(), "test", limit))
+	r.GET("/threads", func(g *gin.Context) {
+		g.JSON(http.StatusOK, nil)
+	})
+
+	spoofed := 0
+	request := func(user bool) *httptest.ResponseRecorder {
+		spoofed++
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/threads", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.RemoteAddr = model.IPForTest + ":1234"
+		// the forwarded headers are spoofed on every request, which must not give a new bucket.
+		req.Header.Set("X-Forwarded-For", fmt.Sprintf("198.51.100.%d", spoofed))
+		req.Header.Set("X-Real-Ip", fmt.Sprintf("198.51.100.%d", spoofed))
+		if user {
+			req.Header.Set("X-Test-User", "1")
+		}
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i, wantRemaining := range []string{"1", "0"} {
+		rec := request(false)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("request %d: status code = %v, want %v", i, rec.Code, http.StatusOK)
+		}
+		if got := rec.Header().Get(rateLimitRemainingHeader); got != wantRemaining {
+			t.Errorf("request %d: %s = %s, want %s", i, rateLimitRemainingHeader, got, wantRemaining)
+		}
+		if got := rec.Header().Get(rateLimitLimitHeader); got != "2" {
+			t.Errorf("request %d: %s = %s, want 2", i, rateLimitLimitHeader, got)
+		}
+		if rec.Header().Get(rateLimitResetHeader) == "" {
+			t.Errorf("request %d: %s is empty", i, rateLimitResetHeader)
+		}
+	}
+
+	rec := request(false)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("status code = %v, want %v", rec.Code, http.StatusTooManyRequests)
+	}
+	if rec.Header().Get("Retry-After") != "30" {
+		t.Errorf("Retry-After = %s, want 30", rec.Header().Get("Retry-After"))
+	}
+
+	// the authenticated user has own bucket even from the same IP.
+	if rec := request(true); rec.Code != http.StatusOK {
+		t.Errorf("status code of the user = %v, want %v", rec.Code, http.StatusOK)
+	}
+}
+
+func TestRateLimitWrites(t *testing.T) {
+	limit := model.RateLimit{Limit: 1, Period: time.Minute}
+
+	r := gin.New()
+	r.Use(RateLimitWrites(memory.NewRateLimitStore(), "test", limit))
+	r.GET("/threads", func(g *gin.Context) {
+		g.JSON(http.StatusOK, nil)
+	})
+	r.POST("/threads", func(g *gin.Context) {
+		g.JSON(http.StatusOK, nil)
+	})
+
+	tests := []struct {
+		method     string
+		statusCode int
+	}{
+		{method: http.MethodPost, statusCode: http.StatusOK},
+		{method: http.MethodGet, statusCode: http.StatusOK},
+		{method: http.MethodGet, statusCode: http.StatusOK},
+		{method: http.MethodPost, statusCode: http.StatusTooManyRequests},
+	}
+	for i, tt := range tests {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest(tt.method, "/threads", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != tt.statusCode {
+			t.Errorf("request %d: status code = %v, want %v", i, rec.Code, tt.statusCode)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/interface/controller"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/util"
+	"go.uber.org/zap"
+)
+
+// RequestIDHeader is the header of the ID of the request.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength is the max length of the request ID given by the client.
+const maxRequestIDLength = 128
+
+// RequestLogger assigns the request ID, which is propagated from X-Request-ID header if it is valid,
+// and binds the logger which has the request ID, the method and the route into the context of the request.
+// It logs a line of the access log after the request has been handled.
+// It must be used before the other middleware, so that they log with the request ID.
+func RequestLogger() gin.HandlerFunc {
+	return func(g *gin.Context) {
+		start := time.Now()
+
+		id := g.GetHeader(RequestIDHeader)
+		if !isValidRequestID(id) {
+			id = util.UUID()
+		}
+		g.Header(RequestIDHeader, id)
+
+		// gin has matched the route before the handlers run, so that the route is known here.
+		ctx := logger.With(g.Request.Context(),
+			zap.String("requestID", id),
+			zap.String("method", g.Request.Method),
+			zap.String("route", matchedRoute(g)),
+		)
+		g.Request = g.Request.WithContext(ctx)
+
+		g.Next()
+
+		// the logger is taken again, because it may have been given the user ID after authentication.
+		logger.FromContext(g.Request.Context()).Info("access",
+			zap.String("path", g.Request.URL.Path),
+			zap.Int("status", g.Writer.Status()),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("clientIP", controller.ClientIP(g)),
+			zap.Int("bytes", g.Writer.Size()),
+		)
+	}
+}
+
+// isValidRequestID returns whether the request ID given by the client is safe to log and respond.
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range id {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9':
+		case r == '-' || r == '_' || r == '.' || r == ':':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	original := logger.Logger
+	logger.Logger = zap.New(core)
+	defer func() {
+		logger.Logger = original
+	}()
+
+	r := gin.New()
+	r.Use(RequestLogger())
+	r.Use(func(g *gin.Context) {
+		// binds the user as CheckAuthentication does.
+		g.Request = g.Request.WithContext(logger.With(g.Request.Context(), zap.Uint32("userID", model.UserValidIDForTest)))
+		g.Next()
+	})
+	r.GET("/v1/threads/:threadId", func(g *gin.Context) {
+		logger.FromContext(g.Request.Context()).Info("handled")
+		g.Status(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name      string
+		requestID string
+		wantID    string
+	}{
+		{
+			name:      "When the valid request ID is given, propagates it",
+			requestID: "abc-123_DEF.4:5",
+			wantID:    "abc-123_DEF.4:5",
+		},
+		{
+			name:      "When the request ID is not given, generates it",
+			requestID: "",
+		},
+		{
+			name:      "When the invalid request ID is given, generates it",
+			requestID: "abc\ndef",
+		},
+		{
+			name:      "When the too long request ID is given, generates it",
+			requestID: strings.Repeat("a", maxRequestIDLength+1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.TakeAll()
+
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/v1/threads/1", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.requestID != "" {
+				req.Header.Set(RequestIDHeader, tt.requestID)
+			}
+			r.ServeHTTP(rec, req)
+
+			id := rec.Header().Get(RequestIDHeader)
+			if tt.wantID != "" && id != tt.wantID {
+				t.Errorf("%s = %s, want %s", RequestIDHeader, id, tt.wantID)
+			}
+			if tt.wantID == "" && (id == "" || id == tt.requestID) {
+				t.Errorf("%s = %s, want generated one", RequestIDHeader, id)
+			}
+
+			entries := logs.AllUntimed()
+			if len(entries) != 2 {
+				t.Fatalf("the number of log entries = %d, want 2", len(entries))
+			}
+			if entries[0].Message != "handled" || entries[1].Message != "access" {
+				t.Errorf("log messages = %s, %s, want handled, access", entries[0].Message, entries[1].Message)
+			}
+
+			for _, entry := range entries {
+				fields := entry.ContextMap()
+				if fields["requestID"] != id {
+					t.Errorf("%s: requestID = %v, want %s", entry.Message, fields["requestID"], id)
+				}
+				if fields["route"] != "/v1/threads/:threadId" {
+					t.Errorf("%s: route = %v, want /v1/threads/:threadId", entry.Message, fields["route"])
+				}
+				if fields["method"] != http.MethodGet {
+					t.Errorf("%s: method = %v, want GET", entry.Message, fields["method"])
+				}
+			}
+
+			access := entries[1].ContextMap()
+			if access["userID"] != model.UserValidIDForTest {
+				t.Errorf("access: userID = %v, want %d", access["userID"], model.UserValidIDForTest)
+			}
+			if access["status"] != int64(http.StatusNoContent) {
+				t.Errorf("access: status = %v, want %d", access["status"], http.StatusNoContent)
+			}
+		})
+	}
+}
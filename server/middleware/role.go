@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/domain/model"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/interface/controller"
+)
+
+// RequireRole checks whether the user who requested has one of the roles.
+// This must be used after CheckAuthentication.
+func RequireRole(roles ...model.Role) gin.HandlerFunc {
+	return func(g *gin.Context) {
+		user, ok := model.UserFromContext(g.Request.Context())
+		if !ok {
+			controller.ResponseAndLogError(g, &model.AuthenticationErr{})
+			g.Abort()
+			return
+		}
+
+		if !user.HasRole(roles...) {
+			controller.ResponseAndLogError(g, &model.ForbiddenError{
+				UserID:          user.ID,
+				PropertyName:    model.RoleProperty,
+				PropertyValue:   user.Role,
+				DomainModelName: model.DomainModelNameUser,
+			})
+			g.Abort()
+			return
+		}
+
+		g.Next()
+	}
+}
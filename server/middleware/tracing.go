@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/tracing"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/interface/controller"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Tracing starts the root span of the request, which continues the trace propagated by traceparent header,
+// and binds it into the context of the request. The trace ID is added to the logger of the request,
+// so that it must be used after RequestLogger.
+func Tracing() gin.HandlerFunc {
+	return func(g *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(g.Request.Context(), propagation.HeaderCarrier(g.Request.Header))
+
+		route := matchedRoute(g)
+		ctx, span := tracing.Start(ctx, g.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.method", g.Request.Method),
+				attribute.String("http.route", route),
+				attribute.String("http.target", g.Request.URL.Path),
+				attribute.String("http.client_ip", controller.ClientIP(g)),
+			),
+		)
+		defer span.End()
+
+		if sc := span.SpanContext(); sc.IsValid() {
+			ctx = logger.With(ctx, zap.String("traceID", sc.TraceID().String()))
+		}
+		g.Request = g.Request.WithContext(ctx)
+
+		g.Next()
+
+		status := g.Writer.Status()
+		span.SetAttributes(attribute.Int("http.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
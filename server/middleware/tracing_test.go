@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	r := gin.New()
+	r.Use(Tracing())
+	r.GET("/v1/threads/:threadId", func(g *gin.Context) {
+		g.Status(http.StatusInternalServerError)
+	})
+
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/threads/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("the number of spans = %d, want 1", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name() != "GET /v1/threads/:threadId" {
+		t.Errorf("name = %s, want GET /v1/threads/:threadId", span.Name())
+	}
+	if got := span.SpanContext().TraceID().String(); got != traceID {
+		t.Errorf("trace ID = %s, want the propagated one %s", got, traceID)
+	}
+	if span.Status().Code != codes.Error {
+		t.Errorf("status = %v, want %v", span.Status().Code, codes.Error)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/config"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/db/migration"
+	"github.com/sekky0905/nuxt-vue-go-chat/server/infra/logger"
+	"go.uber.org/zap"
+)
+
+// migrateUsage is the usage of migrate subcommand.
+const migrateUsage = "usage: migrate up | down [steps] | status"
+
+// runMigrate runs migrate subcommand with args, i.e. up, down [steps] or status.
+func runMigrate(c config.DB, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	migrator, closeDB, err := newMigrator(c)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		lines := make([]string, 0, len(applied))
+		for _, m := range applied {
+			lines = append(lines, fmt.Sprintf("applied %d_%s", m.Version, m.Name))
+		}
+		if err == nil && len(applied) == 0 {
+			lines = append(lines, "no pending migrations")
+		}
+		if writeErr := writeLines(out, lines); writeErr != nil && err == nil {
+			err = writeErr
+		}
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return errors.Errorf("steps must be a positive integer, %s", args[1])
+			}
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		lines := make([]string, 0, len(reverted))
+		for _, m := range reverted {
+			lines = append(lines, fmt.Sprintf("reverted %d_%s", m.Version, m.Name))
+		}
+		if writeErr := writeLines(out, lines); writeErr != nil && err == nil {
+			err = writeErr
+		}
+		return err
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		return writeLines(out, formatStatuses(statuses))
+	default:
+		return errors.New(migrateUsage)
+	}
+}
+
+// autoMigrate applies the pending migrations before the server starts.
+func autoMigrate(c config.DB) error {
+	migrator, closeDB, err := newMigrator(c)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	if _, err := migrator.Up(context.Background()); err != nil {
+		return errors.Wrap(err, "failed to migrate")
+	}
+	return nil
+}
+
+// newMigrator generates and returns Migrator of the embedded migrations with the connection pool of its own,
+// and the function which closes the pool.
+func newMigrator(c config.DB) (*migration.Migrator, func(), error) {
+	migrations, err := migration.Migrations()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read migrations")
+	}
+
+	conn, err := db.Open(c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	closeDB := func() {
+		if err := conn.Close(); err != nil {
+			logger.Logger.Error("failed to close db", zap.String("error message", err.Error()))
+		}
+	}
+
+	return migration.NewMigrator(conn, migrations, c.MigrationLockTimeout), closeDB, nil
+}
+
+// formatStatuses formats the statuses of the migrations as the lines of a table.
+func formatStatuses(statuses []*migration.Status) []string {
+	format := "%-8s  %-24s  %-8s  %s"
+	lines := []string{strings.TrimSpace(fmt.Sprintf(format, "VERSION", "NAME", "STATUS", "APPLIED AT"))}
+	for _, s := range statuses {
+		status, appliedAt := "pending", ""
+		if s.Applied {
+			status, appliedAt = "applied", s.AppliedAt.Format(time.RFC3339)
+		}
+		if s.Dirty {
+			status = "dirty"
+		}
+		line := fmt.Sprintf(format, strconv.FormatUint(s.Migration.Version, 10), s.Migration.Name, status, appliedAt)
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	return lines
+}
+
+// writeLines writes the lines to out.
+func writeLines(out io.Writer, lines []string) error {
+	for _, line := range lines {
+		if _, err := io.WriteString(out, line+"\n"); err != nil {
+			return errors.Wrap(err, "failed to write output")
+		}
+	}
+	return nil
+}
@@ -50,3 +50,22 @@ func GenerateCommentHelper(startNum, endNum int) []*model.Comment {
 
 	return comments
 }
+
+// GenerateUserHelper generates and returns User slice.
+func GenerateUserHelper(startNum, endNum int) []*model.User {
+	num := endNum - startNum + 1
+
+	users := make([]*model.User, num, num)
+	i := 0
+	for j := startNum; j < endNum+1; j++ {
+		user := &model.User{
+			ID:   uint32(j),
+			Name: fmt.Sprintf("%s%d", model.UserNameForTest, j),
+			Role: model.RoleUser,
+		}
+		users[i] = user
+		i++
+	}
+
+	return users
+}
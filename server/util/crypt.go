@@ -1,18 +1,233 @@
 package util
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
 	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
-// HashPassword returns hashed password.
-func HashPassword(password string) (string, error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	return string(hash), errors.Wrap(err, "failed to generate from password")
+// DefaultBcryptCost is the default cost of bcrypt.
+const DefaultBcryptCost = bcrypt.DefaultCost
+
+// argon2idPrefix is the prefix of the hash encoded by argon2idHasher.
+const argon2idPrefix = "$argon2id$"
+
+// PasswordHasher hashes passwords, and verifies passwords with the encoded hashes.
+// The encoded hash contains the algorithm and its parameters, so that the hash made with outdated ones can be detected.
+type PasswordHasher interface {
+	// Hash returns the encoded hash of the password.
+	Hash(password string) (string, error)
+	// Verify returns whether the password matches the encoded hash,
+	// and whether the hash should be rehashed because it has been made with outdated parameters.
+	Verify(password, encoded string) (ok bool, rehash bool, err error)
+	// Supports returns whether the encoded hash has been made by the algorithm of the hasher.
+	Supports(encoded string) bool
+}
+
+// bcryptHasher is PasswordHasher of bcrypt.
+type bcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher generates and returns PasswordHasher of bcrypt.
+func NewBcryptHasher(cost int) PasswordHasher {
+	return &bcryptHasher{
+		cost: cost,
+	}
+}
+
+// Hash returns the encoded hash of the password.
+func (h *bcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate from password")
+	}
+	return string(hash), nil
+}
+
+// Verify returns whether the password matches the encoded hash, and whether the cost of the hash is outdated.
+func (h *bcryptHasher) Verify(password, encoded string) (bool, bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if err == bcrypt.ErrMismatchedHashAndPassword {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, errors.Wrap(err, "failed to compare hash and password")
+	}
+
+	cost, err := bcrypt.Cost([]byte(encoded))
+	if err != nil {
+		return false, false, errors.Wrap(err, "failed to get cost")
+	}
+
+	return true, cost != h.cost, nil
+}
+
+// Supports returns whether the encoded hash has been made by bcrypt.
+func (h *bcryptHasher) Supports(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2")
+}
+
+// Argon2idParams is the parameters of argon2id.
+// Memory is in KiB.
+type Argon2idParams struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  int
+	KeyLength   int
+}
+
+// DefaultArgon2idParams returns Argon2idParams recommended by the document of argon2.
+func DefaultArgon2idParams() Argon2idParams {
+	return Argon2idParams{
+		Memory:      64 * 1024,
+		Iterations:  1,
+		Parallelism: 4,
+		SaltLength:  16,
+		KeyLength:   32,
+	}
+}
+
+// argon2idHasher is PasswordHasher of argon2id.
+// The hash is encoded in the PHC string format, i.e. $argon2id$v=19$m=65536,t=1,p=4$<salt>$<key>.
+type argon2idHasher struct {
+	params Argon2idParams
+}
+
+// NewArgon2idHasher generates and returns PasswordHasher of argon2id.
+func NewArgon2idHasher(params Argon2idParams) PasswordHasher {
+	return &argon2idHasher{
+		params: params,
+	}
+}
+
+// Hash returns the encoded hash of the password.
+func (h *argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", errors.Wrap(err, "failed to read random bytes")
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, uint32(h.params.KeyLength))
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version,
+		h.params.Memory, h.params.Iterations, h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
 }
 
-// CheckHashOfPassword checks whether given hashed is the value of hashed password or not.
-func CheckHashOfPassword(password, hashed string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password))
-	return err == nil
+// Verify returns whether the password matches the encoded hash, and whether the parameters of the hash are outdated.
+func (h *argon2idHasher) Verify(password, encoded string) (bool, bool, error) {
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false, false, err
+	}
+
+	got := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(got, key) != 1 {
+		return false, false, nil
+	}
+
+	return true, params != h.params, nil
+}
+
+// Supports returns whether the encoded hash has been made by argon2id.
+func (h *argon2idHasher) Supports(encoded string) bool {
+	return strings.HasPrefix(encoded, argon2idPrefix)
+}
+
+// decodeArgon2id decodes the hash encoded by argon2idHasher.
+func decodeArgon2id(encoded string) (Argon2idParams, []byte, []byte, error) {
+	var params Argon2idParams
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, errors.New("invalid format of argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, errors.Wrap(err, "failed to parse version of argon2id hash")
+	}
+	if version != argon2.Version {
+		return params, nil, nil, errors.Errorf("unsupported version of argon2id hash, %d", version)
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, errors.Wrap(err, "failed to parse parameters of argon2id hash")
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, errors.Wrap(err, "failed to decode salt of argon2id hash")
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, errors.Wrap(err, "failed to decode key of argon2id hash")
+	}
+
+	params.SaltLength = len(salt)
+	params.KeyLength = len(key)
+
+	return params, salt, key, nil
+}
+
+// versionedHasher is PasswordHasher which hashes passwords by the current hasher,
+// and verifies the hashes made by the current or the previous hashers.
+type versionedHasher struct {
+	current  PasswordHasher
+	previous []PasswordHasher
+}
+
+// NewVersionedHasher generates and returns PasswordHasher which hashes passwords by current.
+// The hashes made by previous are verified and should be rehashed by current.
+func NewVersionedHasher(current PasswordHasher, previous ...PasswordHasher) PasswordHasher {
+	return &versionedHasher{
+		current:  current,
+		previous: previous,
+	}
+}
+
+// Hash returns the encoded hash of the password made by the current hasher.
+func (h *versionedHasher) Hash(password string) (string, error) {
+	return h.current.Hash(password)
+}
+
+// Verify returns whether the password matches the encoded hash,
+// and whether the hash has been made by the previous hashers or with outdated parameters.
+func (h *versionedHasher) Verify(password, encoded string) (bool, bool, error) {
+	if h.current.Supports(encoded) {
+		return h.current.Verify(password, encoded)
+	}
+
+	for _, previous := range h.previous {
+		if previous.Supports(encoded) {
+			ok, _, err := previous.Verify(password, encoded)
+			return ok, ok, err
+		}
+	}
+
+	return false, false, errors.New("unsupported password hash")
+}
+
+// Supports returns whether the encoded hash has been made by the current or the previous hashers.
+func (h *versionedHasher) Supports(encoded string) bool {
+	if h.current.Supports(encoded) {
+		return true
+	}
+
+	for _, previous := range h.previous {
+		if previous.Supports(encoded) {
+			return true
+		}
+	}
+
+	return false
 }
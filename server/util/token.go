@@ -0,0 +1,28 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+)
+
+// tokenBytes is the number of random bytes of a token.
+const tokenBytes = 32
+
+// RandomToken generates a random token which is safe to be used as a credential.
+func RandomToken() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed to read random bytes")
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HashToken returns the hash of the token to be stored.
+// Tokens have enough entropy, so a fast hash is enough unlike passwords.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}